@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AccountParameters define the desired state of a DigitalOcean Account. An
+// Account is observe-only, so there is nothing to configure.
+type AccountParameters struct{}
+
+// An AccountObservation reflects the observed limits and usage of a
+// DigitalOcean Account.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Account
+type AccountObservation struct {
+	// The email address used by the account.
+	Email string `json:"email,omitempty"`
+
+	// The unique universal identifier for the account.
+	UUID string `json:"uuid,omitempty"`
+
+	// A string indicating the current status of the account, e.g. "active",
+	// "warning" or "locked".
+	Status string `json:"status,omitempty"`
+
+	// The total number of Droplets the account may have active at one time.
+	DropletLimit int `json:"dropletLimit,omitempty"`
+
+	// The number of Droplets the account currently has.
+	DropletCount int `json:"dropletCount,omitempty"`
+
+	// The total number of floating IPs the account may have.
+	FloatingIPLimit int `json:"floatingIPLimit,omitempty"`
+
+	// The number of floating IPs the account currently has.
+	FloatingIPCount int `json:"floatingIPCount,omitempty"`
+
+	// The total number of volumes the account may have.
+	VolumeLimit int `json:"volumeLimit,omitempty"`
+
+	// The number of volumes the account currently has.
+	VolumeCount int `json:"volumeCount,omitempty"`
+}
+
+// An AccountSpec defines the desired state of an Account.
+type AccountSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccountParameters `json:"forProvider,omitempty"`
+}
+
+// An AccountStatus represents the observed state of an Account.
+type AccountStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccountObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Account is an observe-only managed resource that represents the limits
+// and usage of the DigitalOcean account its ProviderConfig authenticates as.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DROPLETS",type="string",JSONPath=".status.atProvider.dropletCount",priority=1
+// +kubebuilder:printcolumn:name="DROPLET-LIMIT",type="string",JSONPath=".status.atProvider.dropletLimit",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Accounts.
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
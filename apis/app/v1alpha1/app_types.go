@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AppParameters define the desired state of a DigitalOcean App Platform app.
+type AppParameters struct {
+	// Spec: The app spec, in the format of the App Platform API. The name of
+	// the app defaults to the name of the managed resource. Fields that are
+	// not set are left to the defaults of App Platform.
+	// https://docs.digitalocean.com/products/app-platform/reference/app-spec/
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+
+	// DeploymentGeneration: Increase to trigger a deployment of the app that
+	// rebuilds it from its sources, e.g. to pick up a new image behind an
+	// unchanged tag. Changing the app spec deploys the app regardless.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DeploymentGeneration *int64 `json:"deploymentGeneration,omitempty"`
+
+	// RollbackToDeploymentID: The ID of a previous deployment of the app to
+	// roll back to. The app stays pinned to that deployment, and changes
+	// to its spec are not deployed, until this is unset, which reverts the
+	// rollback.
+	// +optional
+	RollbackToDeploymentID *string `json:"rollbackToDeploymentId,omitempty"`
+}
+
+// An AppDeployment is a deployment of an app.
+type AppDeployment struct {
+	// The ID of the deployment.
+	ID string `json:"id"`
+
+	// The phase of the deployment, e.g. BUILDING, ACTIVE or ERROR.
+	Phase string `json:"phase,omitempty"`
+
+	// What caused the deployment.
+	Cause string `json:"cause,omitempty"`
+
+	// The time the deployment was created, in RFC3339 text format.
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// An AppObservation reflects the observed state of a DigitalOcean App
+// Platform app.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Apps
+type AppObservation struct {
+	// The ID of the app.
+	ID string `json:"id,omitempty"`
+
+	// The default URL the app is reachable at.
+	DefaultIngress string `json:"defaultIngress,omitempty"`
+
+	// The live URL of the app.
+	LiveURL string `json:"liveUrl,omitempty"`
+
+	// The ID of the deployment that is serving the app.
+	ActiveDeploymentID string `json:"activeDeploymentId,omitempty"`
+
+	// The ID of the deployment that is in progress, if any.
+	InProgressDeploymentID string `json:"inProgressDeploymentId,omitempty"`
+
+	// The ID of the deployment the app is rolled back to, if any.
+	PinnedDeploymentID string `json:"pinnedDeploymentId,omitempty"`
+
+	// The deployment generation that was last deployed.
+	DeploymentGeneration int64 `json:"deploymentGeneration,omitempty"`
+
+	// The most recent deployments of the app, newest first.
+	Deployments []AppDeployment `json:"deployments,omitempty"`
+}
+
+// An AppSpec defines the desired state of an App.
+type AppSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AppParameters `json:"forProvider"`
+}
+
+// An AppStatus represents the observed state of an App.
+type AppStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AppObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An App is a managed resource that represents a DigitalOcean App Platform
+// app. Its external-name is the ID of the app.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.atProvider.liveUrl"
+// +kubebuilder:printcolumn:name="DEPLOYMENT",type="string",JSONPath=".status.atProvider.activeDeploymentId",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type App struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AppSpec   `json:"spec"`
+	Status AppStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AppList contains a list of Apps.
+type AppList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []App `json:"items"`
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CDNParameters define the desired state of a DigitalOcean CDN endpoint.
+// Most fields map directly to a CDN endpoint:
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/CDN-Endpoints
+type CDNParameters struct {
+	// Origin: The fully qualified domain name of the Space the CDN endpoint
+	// serves content from, e.g. "static-images.nyc3.digitaloceanspaces.com".
+	// +immutable
+	Origin string `json:"origin"`
+
+	// TTL: The time in seconds content is cached by the CDN endpoint.
+	// Defaults to 3600.
+	// +optional
+	// +kubebuilder:validation:Enum=60;600;3600;86400;604800
+	TTL *int `json:"ttl,omitempty"`
+
+	// CustomDomain: The fully qualified domain name of a custom subdomain
+	// the CDN endpoint serves content on, e.g. "static.example.com". It
+	// requires a certificate.
+	// +optional
+	CustomDomain *string `json:"customDomain,omitempty"`
+
+	// CertificateID: The ID of the TLS certificate of the custom domain.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1.Certificate
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1.CertificateID()
+	// +crossplane:generate:reference:refFieldName=CertificateRef
+	// +crossplane:generate:reference:selectorFieldName=CertificateSelector
+	CertificateID *string `json:"certificateId,omitempty"`
+
+	// CertificateRef references the Certificate of the custom domain. The
+	// ID of the Certificate is resolved on every reconcile, so the CDN
+	// endpoint follows a Let's Encrypt certificate that is renewed with a
+	// new ID.
+	// +optional
+	CertificateRef *xpv1.Reference `json:"certificateRef,omitempty"`
+
+	// CertificateSelector selects a reference to the Certificate of the
+	// custom domain.
+	// +optional
+	CertificateSelector *xpv1.Selector `json:"certificateSelector,omitempty"`
+}
+
+// A CDNObservation reflects the observed state of a DigitalOcean CDN
+// endpoint.
+type CDNObservation struct {
+	// ID of the CDN endpoint.
+	ID string `json:"id,omitempty"`
+
+	// Endpoint is the fully qualified domain name the CDN endpoint serves
+	// content on.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CertificateID is the ID of the certificate the custom domain of the
+	// CDN endpoint is served with.
+	CertificateID string `json:"certificateId,omitempty"`
+
+	// CreationTimestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// A CDNSpec defines the desired state of a CDN.
+type CDNSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CDNParameters `json:"forProvider"`
+}
+
+// A CDNStatus represents the observed state of a CDN.
+type CDNStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CDNObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CDN is a managed resource that represents a DigitalOcean CDN endpoint.
+// Its external-name is the ID of the CDN endpoint.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".status.atProvider.endpoint"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type CDN struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CDNSpec   `json:"spec"`
+	Status CDNStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CDNList contains a list of CDNs.
+type CDNList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CDN `json:"items"`
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Certificate types.
+const (
+	TypeCustom      = "custom"
+	TypeLetsEncrypt = "lets_encrypt"
+)
+
+// Certificate states.
+const (
+	StatePending  = "pending"
+	StateVerified = "verified"
+	StateError    = "error"
+)
+
+// CertificateParameters define the desired state of a DigitalOcean
+// certificate. The name of the certificate is the name of the managed
+// resource. Certificates cannot be changed once they are created.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Certificates
+type CertificateParameters struct {
+	// Type: Either "lets_encrypt" for a certificate DigitalOcean issues and
+	// renews, or "custom" for a certificate that is uploaded.
+	// +kubebuilder:validation:Enum=custom;lets_encrypt
+	// +immutable
+	Type string `json:"type"`
+
+	// DNSNames: The domains a lets_encrypt certificate is issued for. The
+	// domains must be managed by DigitalOcean DNS.
+	// +optional
+	// +immutable
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// LeafCertificate: The PEM encoded public certificate of a custom
+	// certificate.
+	// +optional
+	// +immutable
+	LeafCertificate *string `json:"leafCertificate,omitempty"`
+
+	// CertificateChain: The PEM encoded intermediate certificates of a
+	// custom certificate.
+	// +optional
+	// +immutable
+	CertificateChain *string `json:"certificateChain,omitempty"`
+
+	// PrivateKeySecretRef references the key of a Secret that holds the PEM
+	// encoded private key of a custom certificate.
+	// +optional
+	// +immutable
+	PrivateKeySecretRef *xpv1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+}
+
+// A CertificateObservation reflects the observed state of a DigitalOcean
+// certificate.
+type CertificateObservation struct {
+	// ID of the certificate. A lets_encrypt certificate gets a new ID each
+	// time it is renewed.
+	ID string `json:"id,omitempty"`
+
+	// Name of the certificate.
+	Name string `json:"name,omitempty"`
+
+	// Type of the certificate.
+	Type string `json:"type,omitempty"`
+
+	// A State string indicating whether the certificate was issued, i.e.
+	// "pending", "verified" or "error".
+	State string `json:"state,omitempty"`
+
+	// DNSNames the certificate is valid for.
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// NotAfter is the time the certificate expires at in RFC3339 text format.
+	NotAfter string `json:"notAfter,omitempty"`
+
+	// SHA1Fingerprint of the certificate.
+	SHA1Fingerprint string `json:"sha1Fingerprint,omitempty"`
+
+	// CreationTimestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// A CertificateSpec defines the desired state of a Certificate.
+type CertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CertificateParameters `json:"forProvider"`
+}
+
+// A CertificateStatus represents the observed state of a Certificate.
+type CertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Certificate is a managed resource that represents a DigitalOcean
+// certificate.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.notAfter",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CertificateList contains a list of Certificates.
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// CertificateID returns an extractor that returns the ID of a Certificate
+// once it was issued. A lets_encrypt certificate gets a new ID each time it
+// is renewed, so the ID is read from its status rather than from its
+// external-name.
+func CertificateID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*Certificate)
+		if !ok || cr.Status.AtProvider.State != StateVerified {
+			return ""
+		}
+		return cr.Status.AtProvider.ID
+	}
+}
@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 )
 
 // Known Droplet statuses.
@@ -30,6 +32,19 @@ const (
 	StatusArchive = "archive"
 )
 
+// Networks whose address may be published as a Droplet connection detail.
+const (
+	ConnectionDetailsNetworkPublic  = "public"
+	ConnectionDetailsNetworkPrivate = "private"
+	ConnectionDetailsNetworkBoth    = "both"
+)
+
+// Strategies to replace a Droplet with when an immutable field changed.
+const (
+	RecreateStrategyDestroyBeforeCreate = "DestroyBeforeCreate"
+	RecreateStrategyCreateBeforeDestroy = "CreateBeforeDestroy"
+)
+
 // DropletParameters define the desired state of a DigitalOcean Droplet.
 // Most fields map directly to a Droplet:
 // https://developers.digitalocean.com/documentation/v2/#droplets
@@ -39,17 +54,55 @@ type DropletParameters struct {
 	// +immutable
 	Region string `json:"region"`
 
-	// Size: The unique slug identifier for the size that you wish to select
-	// for this Droplet.
+	// RegionFallback: An ordered list of unique slug identifiers for the
+	// regions to deploy in if the preferred region is out of capacity. All
+	// of them must support the requested size.
+	// +optional
 	// +immutable
+	RegionFallback []string `json:"regionFallback,omitempty"`
+
+	// Size: The unique slug identifier for the size that you wish to select
+	// for this Droplet. Changing the size resizes the Droplet, which powers
+	// it off for the duration of the resize.
 	Size string `json:"size"`
 
+	// ResizeDisk: A boolean indicating whether a resize also grows the disk
+	// of the Droplet. A disk resize is permanent: the Droplet cannot be
+	// resized to a size with a smaller disk afterwards. When false only CPU
+	// and RAM are resized, which can be reverted.
+	// +optional
+	ResizeDisk *bool `json:"resizeDisk,omitempty"`
+
+	// ValidateSize: A boolean indicating whether the size is validated
+	// against the sizes offered by DigitalOcean before the Droplet is
+	// created. An unknown size is rejected with the closest known sizes
+	// rather than the generic error of the API. The sizes are listed once
+	// and cached for all Droplets.
+	// +optional
+	ValidateSize *bool `json:"validateSize,omitempty"`
+
+	// ValidateRegion: A boolean indicating whether the region and fallback
+	// regions are validated before the Droplet is created. A region that is
+	// unavailable or lacks a feature the Droplet needs, e.g. block storage
+	// for its volumes or IPv6, is rejected with the missing features rather
+	// than the generic error of the API. The regions are listed once and
+	// cached for all Droplets.
+	// +optional
+	ValidateRegion *bool `json:"validateRegion,omitempty"`
+
 	// Image: The image ID of a public or private image, or the unique slug
 	// identifier for a public image. This image will be the base image for
 	// your Droplet.
 	// +immutable
 	Image string `json:"image"`
 
+	// RebuildFrom: The image ID of a public or private image or snapshot, or
+	// the unique slug identifier for a public image, to rebuild the Droplet
+	// from. Whenever the Droplet does not run this image it is rebuilt in
+	// place, keeping its ID and IP addresses. All data on its disk is lost.
+	// +optional
+	RebuildFrom *string `json:"rebuildFrom,omitempty"`
+
 	// SSHKeys: An array containing the IDs or fingerprints of the SSH keys
 	// that you wish to embed in the Droplet's root account upon creation.
 	// +optional
@@ -57,26 +110,28 @@ type DropletParameters struct {
 	SSHKeys []string `json:"sshKeys,omitempty"`
 
 	// Backups: A boolean indicating whether automated backups should be enabled
-	// for the Droplet. Automated backups can only be enabled when the Droplet is
-	// created.
+	// for the Droplet. Backups can be enabled and disabled after the Droplet
+	// is created.
 	// +optional
-	// +immutable
 	Backups *bool `json:"backups,omitempty"`
 
-	// IPv6: A boolean indicating whether IPv6 is enabled on the Droplet.
+	// IPv6: A boolean indicating whether IPv6 is enabled on the Droplet. IPv6
+	// can be enabled after the Droplet is created, but not disabled.
 	// +optional
-	// +immutable
 	IPv6 *bool `json:"ipv6,omitempty"`
 
 	// PrivateNetworking: This parameter has been deprecated. Use 'vpc_uuid'
 	// instead to specify a VPC network for the Droplet. If no `vpc_uuid` is
-	// provided, the Droplet will be placed in the default VPC.
+	// provided, the Droplet will be placed in the default VPC. Some older
+	// accounts still require private networking to be requested explicitly;
+	// it defaults to true if 'vpc_uuid' is set, and cannot be disabled then.
 	// +optional
 	// +immutable
 	PrivateNetworking *bool `json:"privateNetworking,omitempty"`
 
 	// Monitoring: A boolean indicating whether to install the DigitalOcean
-	// agent for monitoring.
+	// agent for monitoring. The agent can only be installed when the Droplet
+	// is created.
 	// +optional
 	// +immutable
 	Monitoring *bool `json:"monitoring,omitempty"`
@@ -89,11 +144,28 @@ type DropletParameters struct {
 	Volumes []string `json:"volumes,omitempty"`
 
 	// Tags: A flat array of tag names as strings to apply to the Droplet after it
-	// is created. Tag names can either be existing or new tags.
+	// is created. Tag names can either be existing or new tags. A
+	// "crossplane:<uid>" tag is additionally applied to identify the Droplet
+	// created for this managed resource.
 	// +optional
 	// +immutable
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.Tag
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.TagName()
+	// +crossplane:generate:reference:refFieldName=TagRefs
+	// +crossplane:generate:reference:selectorFieldName=TagSelector
 	Tags []string `json:"tags,omitempty"`
 
+	// TagRefs reference the Tags to apply to the Droplet. The names of the
+	// referenced Tags are resolved into tags while tags is empty.
+	// +optional
+	// +immutable
+	TagRefs []xpv1.Reference `json:"tagRefs,omitempty"`
+
+	// TagSelector selects references to the Tags to apply to the Droplet.
+	// +optional
+	// +immutable
+	TagSelector *xpv1.Selector `json:"tagSelector,omitempty"`
+
 	// VPCUUID: A string specifying the UUID of the VPC to which the Droplet
 	// will be assigned. If excluded, beginning on April 7th, 2020, the Droplet
 	// will be assigned to your account's default VPC for the region.
@@ -108,6 +180,100 @@ type DropletParameters struct {
 	// +optional
 	// +immutable
 	WithDropletAgent *bool `json:"withDropletAgent,omitempty"`
+
+	// UserData: A string containing user data, often a cloud-config file or
+	// a script, that configures the Droplet on its first boot. It cannot
+	// exceed 64 KiB. cloud-init reads it from the Droplet's metadata service
+	// at http://169.254.169.254/metadata/v1/user-data, which also serves the
+	// Droplet's tags and region at /metadata/v1/tags and
+	// /metadata/v1/region, so they need not be repeated in the user data.
+	// DigitalOcean does not support separate vendor data.
+	// +optional
+	// +immutable
+	UserData *string `json:"userData,omitempty"`
+
+	// CompressUserData: A boolean indicating whether user data larger than
+	// 48 KiB is gzip-compressed to stay within the 64 KiB limit. Compressed
+	// user data is sent as a cloud-config file that installs it as a
+	// per-instance script, so only user data that is a script starting with
+	// '#!' can be compressed.
+	// +optional
+	// +immutable
+	CompressUserData *bool `json:"compressUserData,omitempty"`
+
+	// ConnectionDetailsNetwork: The network whose IPv4 address is published
+	// to the 'endpoint' and 'host' connection details. Use 'private' for
+	// Droplets that are only reachable within their VPC. When set to 'both'
+	// the public address is published to 'endpoint' and 'host' and the
+	// private address to 'private_host'.
+	// +optional
+	// +kubebuilder:validation:Enum=public;private;both
+	// +kubebuilder:default=public
+	ConnectionDetailsNetwork string `json:"connectionDetailsNetwork,omitempty"`
+
+	// RotationPolicy: Whether the connection details are written to the
+	// connection secret every time the Droplet is observed ('Always', the
+	// default) or only when they change ('OnChange').
+	// +optional
+	RotationPolicy *dov1alpha1.ConnectionRotationPolicy `json:"rotationPolicy,omitempty"`
+
+	// ReverseDNS: The fully qualified domain name the PTR records of the
+	// Droplet's IP addresses point to. DigitalOcean derives the PTR records
+	// from the name of the Droplet, so the Droplet is created with, or
+	// renamed to, this name.
+	// +optional
+	ReverseDNS *string `json:"reverseDns,omitempty"`
+
+	// GracefulShutdown: A boolean indicating whether the Droplet is shut down
+	// before it is destroyed, giving its workloads a chance to flush their
+	// state. The Droplet is destroyed anyway if it does not shut down in
+	// time. When false the Droplet is destroyed right away.
+	// +optional
+	GracefulShutdown *bool `json:"gracefulShutdown,omitempty"`
+
+	// CleanupTagsOnDelete: A boolean indicating whether the tags of the
+	// Droplet, including the one identifying the Droplet created for this
+	// managed resource, are deleted once the Droplet is destroyed. Only tags
+	// that no other resource carries are deleted.
+	// +optional
+	CleanupTagsOnDelete *bool `json:"cleanupTagsOnDelete,omitempty"`
+
+	// RecreateOnImmutableChange: A boolean indicating whether the Droplet is
+	// replaced by a new one when a field that cannot be updated, such as its
+	// region or image, changed. The new Droplet is created from the desired
+	// parameters, including its tags, and LoadBalancers referencing this
+	// managed resource follow it. When false such changes are only reported
+	// by the ImmutableFieldChanged condition.
+	// +optional
+	RecreateOnImmutableChange *bool `json:"recreateOnImmutableChange,omitempty"`
+
+	// RecreateStrategy: The order in which the Droplet is replaced when it is
+	// recreated. 'DestroyBeforeCreate' destroys the Droplet before its
+	// replacement is created. 'CreateBeforeDestroy' creates the replacement
+	// first and only destroys the Droplet once the replacement exists, so
+	// that both briefly run side by side.
+	// +optional
+	// +kubebuilder:validation:Enum=DestroyBeforeCreate;CreateBeforeDestroy
+	// +kubebuilder:default=DestroyBeforeCreate
+	RecreateStrategy string `json:"recreateStrategy,omitempty"`
+
+	// ProjectID: The ID of the project the Droplet is assigned to. It is
+	// assigned to the default project of the account if no project is set,
+	// and moved when the project changes.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1.Project
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	ProjectID *string `json:"projectId,omitempty"`
+
+	// ProjectRef references the Project the Droplet is assigned to.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects a reference to the Project the Droplet is
+	// assigned to.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
 }
 
 // A DropletObservation reflects the observed state of a Droplet on DigitalOcean.
@@ -118,6 +284,99 @@ type DropletObservation struct {
 	// ID for the resource. This identifier is defined by the server.
 	ID int `json:"id,omitempty"`
 
+	// Size is the unique slug identifier for the current size of the Droplet.
+	Size string `json:"size,omitempty"`
+
+	// Disk is the size of the disk of the Droplet in gigabytes.
+	Disk int `json:"disk,omitempty"`
+
+	// Region is the unique slug identifier for the region the Droplet was
+	// deployed in. It differs from the preferred region if the Droplet was
+	// deployed in a fallback region.
+	Region string `json:"region,omitempty"`
+
+	// ImageID is the ID of the image the Droplet was created or last rebuilt
+	// from.
+	ImageID int `json:"imageId,omitempty"`
+
+	// ImageSlug is the unique slug identifier of the image the Droplet was
+	// created or last rebuilt from, if it is a public image.
+	ImageSlug string `json:"imageSlug,omitempty"`
+
+	// ImageType is the type of the image the Droplet was created or last
+	// rebuilt from, e.g. "base" for a public image, or "snapshot", "backup"
+	// or "custom" for a private image that can only be referenced by ID.
+	ImageType string `json:"imageType,omitempty"`
+
+	// KernelID is the ID of the kernel the Droplet boots. It is only reported
+	// for Droplets whose kernel is managed by DigitalOcean rather than by the
+	// image.
+	KernelID int `json:"kernelId,omitempty"`
+
+	// KernelName is the name of the kernel the Droplet boots.
+	KernelName string `json:"kernelName,omitempty"`
+
+	// KernelVersion is the version of the kernel the Droplet boots.
+	KernelVersion string `json:"kernelVersion,omitempty"`
+
+	// Features are the features enabled on the Droplet, e.g. "backups",
+	// "ipv6", "monitoring" or "private_networking".
+	Features []string `json:"features,omitempty"`
+
+	// ReverseDNS is the domain name the PTR records of the Droplet's IP
+	// addresses point to. It is empty if the name of the Droplet is not a
+	// fully qualified domain name.
+	ReverseDNS string `json:"reverseDns,omitempty"`
+
+	// LatestBackupID is the ID of the most recent automatic backup of the
+	// Droplet. It is only reported if backups are enabled.
+	LatestBackupID int `json:"latestBackupId,omitempty"`
+
+	// LatestBackupCreated is the time the most recent automatic backup of the
+	// Droplet was created, in RFC3339 text format.
+	LatestBackupCreated string `json:"latestBackupCreated,omitempty"`
+
+	// Locked is true while an action in progress, e.g. a resize, prevents
+	// the Droplet from being modified or deleted.
+	Locked bool `json:"locked,omitempty"`
+
+	// VolumeIDs are the IDs of the block storage volumes attached to the
+	// Droplet.
+	VolumeIDs []string `json:"volumeIds,omitempty"`
+
+	// VPCUUID is the UUID of the VPC the Droplet is assigned to.
+	VPCUUID string `json:"vpcUuid,omitempty"`
+
+	// PublicIPv4 is the public IPv4 address of the Droplet.
+	PublicIPv4 string `json:"publicIPv4,omitempty"`
+
+	// PublicIPv4Gateway is the gateway of the public IPv4 network of the
+	// Droplet.
+	PublicIPv4Gateway string `json:"publicIPv4Gateway,omitempty"`
+
+	// PublicIPv4CIDR is the public IPv4 address of the Droplet with the
+	// prefix length of its network, e.g. "203.0.113.10/20".
+	PublicIPv4CIDR string `json:"publicIPv4CIDR,omitempty"`
+
+	// PublicIPv6 is the public IPv6 address of the Droplet. It is only
+	// reported if IPv6 is enabled, and may be assigned after the IPv4
+	// address.
+	PublicIPv6 string `json:"publicIPv6,omitempty"`
+
+	// PublicIPv6Gateway is the gateway of the public IPv6 network of the
+	// Droplet.
+	PublicIPv6Gateway string `json:"publicIPv6Gateway,omitempty"`
+
+	// PublicIPv6CIDR is the public IPv6 address of the Droplet with the
+	// prefix length of its network, e.g. "2001:db8::10/64".
+	PublicIPv6CIDR string `json:"publicIPv6CIDR,omitempty"`
+
+	// Action is the action in progress on the Droplet that was started by
+	// the provider, e.g. a resize or a rebuild. It is cleared once the
+	// action is completed or errored.
+	// +optional
+	Action *dov1alpha1.Action `json:"action,omitempty"`
+
 	// A Status string indicating the state of the Droplet instance.
 	//
 	// Possible values:
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DropletGroupParameters define the desired state of a group of identical
+// DigitalOcean Droplets that share a tag. The Droplets of the group are
+// named like the managed resource, followed by their index.
+type DropletGroupParameters struct {
+	// Tag: The tag that identifies the Droplets of the group. It is applied
+	// to every Droplet the group creates.
+	// +immutable
+	Tag string `json:"tag"`
+
+	// Count: The number of Droplets in the group.
+	// +kubebuilder:validation:Minimum=0
+	Count int `json:"count"`
+
+	// ManageByTagExclusive: A boolean indicating whether every Droplet with
+	// the tag of the group is managed by it, including Droplets the group
+	// did not create. Only then the group is deleted while such Droplets
+	// exist, because all Droplets with the tag are deleted at once. Defaults
+	// to false.
+	// +optional
+	ManageByTagExclusive *bool `json:"manageByTagExclusive,omitempty"`
+
+	// Region: The unique slug identifier for the region the Droplets are
+	// deployed in.
+	// +immutable
+	Region string `json:"region"`
+
+	// Size: The unique slug identifier for the size of the Droplets.
+	// +immutable
+	Size string `json:"size"`
+
+	// Image: The image ID of a public or private image, or the unique slug
+	// identifier for a public image, the Droplets are created from.
+	// +immutable
+	Image string `json:"image"`
+
+	// SSHKeys: An array containing the IDs or fingerprints of the SSH keys
+	// to embed in the root account of the Droplets.
+	// +optional
+	// +immutable
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// Tags: Further tags to apply to the Droplets.
+	// +optional
+	// +immutable
+	Tags []string `json:"tags,omitempty"`
+
+	// VPCUUID: The UUID of the VPC the Droplets are assigned to.
+	// +optional
+	// +immutable
+	VPCUUID *string `json:"vpcUuid,omitempty"`
+
+	// UserData: A string containing user data that cloud-init consumes to
+	// configure the Droplets on first boot.
+	// +optional
+	// +immutable
+	UserData *string `json:"userData,omitempty"`
+}
+
+// A DropletGroupObservation reflects the observed state of a group of
+// DigitalOcean Droplets.
+type DropletGroupObservation struct {
+	// MemberIDs are the IDs of the Droplets that are managed by the group.
+	MemberIDs []int `json:"memberIds,omitempty"`
+
+	// ActiveCount is the number of members that are active.
+	ActiveCount int `json:"activeCount,omitempty"`
+
+	// ForeignIDs are the IDs of the Droplets with the tag of the group that
+	// the group did not create.
+	ForeignIDs []int `json:"foreignIds,omitempty"`
+}
+
+// A DropletGroupSpec defines the desired state of a DropletGroup.
+type DropletGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DropletGroupParameters `json:"forProvider"`
+}
+
+// A DropletGroupStatus represents the observed state of a DropletGroup.
+type DropletGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DropletGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DropletGroup is a managed resource that represents a group of identical
+// DigitalOcean Droplets. The Droplets are deleted together by their tag.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TAG",type="string",JSONPath=".spec.forProvider.tag"
+// +kubebuilder:printcolumn:name="COUNT",type="integer",JSONPath=".spec.forProvider.count"
+// +kubebuilder:printcolumn:name="ACTIVE",type="integer",JSONPath=".status.atProvider.activeCount"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type DropletGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DropletGroupSpec   `json:"spec"`
+	Status DropletGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DropletGroupList contains a list of DropletGroups.
+type DropletGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DropletGroup `json:"items"`
+}
@@ -45,6 +45,16 @@ var (
 	DropletGroupVersionKind = SchemeGroupVersion.WithKind(DropletKind)
 )
 
+// DropletGroup type metadata. DropletGroupKind is the group kind of a
+// Droplet, so the type metadata of a DropletGroup spell out its resource.
+var (
+	DropletGroupResourceKind             = reflect.TypeOf(DropletGroup{}).Name()
+	DropletGroupResourceGroupKind        = schema.GroupKind{Group: Group, Kind: DropletGroupResourceKind}.String()
+	DropletGroupResourceKindAPIVersion   = DropletGroupResourceKind + "." + SchemeGroupVersion.String()
+	DropletGroupResourceGroupVersionKind = SchemeGroupVersion.WithKind(DropletGroupResourceKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Droplet{}, &DropletList{})
+	SchemeBuilder.Register(&DropletGroup{}, &DropletGroupList{})
 }
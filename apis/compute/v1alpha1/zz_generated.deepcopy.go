@@ -22,6 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +54,164 @@ func (in *Droplet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroup) DeepCopyInto(out *DropletGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroup.
+func (in *DropletGroup) DeepCopy() *DropletGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DropletGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroupList) DeepCopyInto(out *DropletGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DropletGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroupList.
+func (in *DropletGroupList) DeepCopy() *DropletGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DropletGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroupObservation) DeepCopyInto(out *DropletGroupObservation) {
+	*out = *in
+	if in.MemberIDs != nil {
+		in, out := &in.MemberIDs, &out.MemberIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForeignIDs != nil {
+		in, out := &in.ForeignIDs, &out.ForeignIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroupObservation.
+func (in *DropletGroupObservation) DeepCopy() *DropletGroupObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroupObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroupParameters) DeepCopyInto(out *DropletGroupParameters) {
+	*out = *in
+	if in.ManageByTagExclusive != nil {
+		in, out := &in.ManageByTagExclusive, &out.ManageByTagExclusive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VPCUUID != nil {
+		in, out := &in.VPCUUID, &out.VPCUUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroupParameters.
+func (in *DropletGroupParameters) DeepCopy() *DropletGroupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroupSpec) DeepCopyInto(out *DropletGroupSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroupSpec.
+func (in *DropletGroupSpec) DeepCopy() *DropletGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DropletGroupStatus) DeepCopyInto(out *DropletGroupStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletGroupStatus.
+func (in *DropletGroupStatus) DeepCopy() *DropletGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DropletGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DropletList) DeepCopyInto(out *DropletList) {
 	*out = *in
@@ -87,6 +247,21 @@ func (in *DropletList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DropletObservation) DeepCopyInto(out *DropletObservation) {
 	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeIDs != nil {
+		in, out := &in.VolumeIDs, &out.VolumeIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(apisv1alpha1.Action)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletObservation.
@@ -102,6 +277,31 @@ func (in *DropletObservation) DeepCopy() *DropletObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 	*out = *in
+	if in.RegionFallback != nil {
+		in, out := &in.RegionFallback, &out.RegionFallback
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResizeDisk != nil {
+		in, out := &in.ResizeDisk, &out.ResizeDisk
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateSize != nil {
+		in, out := &in.ValidateSize, &out.ValidateSize
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateRegion != nil {
+		in, out := &in.ValidateRegion, &out.ValidateRegion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RebuildFrom != nil {
+		in, out := &in.RebuildFrom, &out.RebuildFrom
+		*out = new(string)
+		**out = **in
+	}
 	if in.SSHKeys != nil {
 		in, out := &in.SSHKeys, &out.SSHKeys
 		*out = make([]string, len(*in))
@@ -137,6 +337,16 @@ func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TagRefs != nil {
+		in, out := &in.TagRefs, &out.TagRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagSelector != nil {
+		in, out := &in.TagSelector, &out.TagSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VPCUUID != nil {
 		in, out := &in.VPCUUID, &out.VPCUUID
 		*out = new(string)
@@ -147,6 +357,56 @@ func (in *DropletParameters) DeepCopyInto(out *DropletParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompressUserData != nil {
+		in, out := &in.CompressUserData, &out.CompressUserData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(apisv1alpha1.ConnectionRotationPolicy)
+		**out = **in
+	}
+	if in.ReverseDNS != nil {
+		in, out := &in.ReverseDNS, &out.ReverseDNS
+		*out = new(string)
+		**out = **in
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CleanupTagsOnDelete != nil {
+		in, out := &in.CleanupTagsOnDelete, &out.CleanupTagsOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RecreateOnImmutableChange != nil {
+		in, out := &in.RecreateOnImmutableChange, &out.RecreateOnImmutableChange
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProjectID != nil {
+		in, out := &in.ProjectID, &out.ProjectID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletParameters.
@@ -180,7 +440,7 @@ func (in *DropletSpec) DeepCopy() *DropletSpec {
 func (in *DropletStatus) DeepCopyInto(out *DropletStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DropletStatus.
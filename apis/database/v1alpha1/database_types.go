@@ -17,6 +17,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 )
 
 // Known Database Cluster statuses
@@ -28,12 +30,21 @@ const (
 	StatusForking   = "forking"
 )
 
+// Known online migration statuses
+const (
+	MigrationStatusRunning  = "running"
+	MigrationStatusSyncing  = "syncing"
+	MigrationStatusCanceled = "canceled"
+	MigrationStatusError    = "error"
+	MigrationStatusDone     = "done"
+)
+
 // A DODatabaseClusterParameters defines the desired state of a DigitalOcean Database Cluster.
 // All fields map directly to a Database Cluster
 // https://docs.digitalocean.com/reference/api/api-reference/#operation/create_database_cluster
 type DODatabaseClusterParameters struct {
-	// Engine: A slug representing the database engine used for the cluster. The possible values are: "pg" for PostgreSQL, "mysql" for MySQL, "redis" for Redis, and "mongodb" for MongoDB.
-	// +kubebuilder:validation:Enum="pg";"mysql";"redis";"mongodb"
+	// Engine: A slug representing the database engine used for the cluster. The possible values are: "pg" for PostgreSQL, "mysql" for MySQL, "redis" for Redis, "mongodb" for MongoDB, "kafka" for Kafka, and "opensearch" for OpenSearch.
+	// +kubebuilder:validation:Enum="pg";"mysql";"redis";"mongodb";"kafka";"opensearch"
 	// +immutable
 	Engine *string `json:"engine"`
 
@@ -63,6 +74,160 @@ type DODatabaseClusterParameters struct {
 	// +optional
 	// +immutable
 	Tags []string `json:"tags,omitempty"`
+
+	// StorageSizeMiB: The amount of storage of the database cluster in MiB, including any storage added on top of the base amount provided by the size (Optional).
+	// Only PostgreSQL and MySQL clusters support additional storage. It must be a multiple of 10240 MiB (10 GiB) and can only be increased.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StorageSizeMiB *int64 `json:"storageSizeMiB,omitempty"`
+
+	// RestoreFrom: A backup of another database cluster to create the
+	// database cluster from (Optional).
+	// +optional
+	// +immutable
+	RestoreFrom *DODatabaseClusterRestoreFrom `json:"restoreFrom,omitempty"`
+
+	// TrustedKubernetesClusterID: The ID of a Kubernetes cluster whose nodes
+	// are added to the trusted sources of the database cluster (Optional).
+	// When it is referenced it is kept in sync with the ID of the referenced
+	// cluster, even if the cluster is recreated. Other trusted sources are
+	// left untouched.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1.DOKubernetesCluster
+	TrustedKubernetesClusterID *string `json:"trustedKubernetesClusterId,omitempty"`
+
+	// TrustedKubernetesClusterIDRef references a DOKubernetesCluster whose
+	// nodes are added to the trusted sources of the database cluster.
+	// +optional
+	TrustedKubernetesClusterIDRef *xpv1.Reference `json:"trustedKubernetesClusterIdRef,omitempty"`
+
+	// TrustedKubernetesClusterIDSelector selects a reference to a
+	// DOKubernetesCluster whose nodes are added to the trusted sources of the
+	// database cluster.
+	// +optional
+	TrustedKubernetesClusterIDSelector *xpv1.Selector `json:"trustedKubernetesClusterIdSelector,omitempty"`
+
+	// Migration: An online migration of a database from an external source
+	// into the database cluster. It is started once the cluster is online,
+	// and stopped if it is removed or the cluster is deleted while it is in
+	// progress.
+	// +optional
+	Migration *DODatabaseClusterMigration `json:"migration,omitempty"`
+
+	// BackupSchedule: The time of day at which the daily backup of the
+	// database cluster is started (Optional). Only PostgreSQL and MySQL
+	// clusters support scheduling backups; the backups of other engines are
+	// only reported in the status.
+	// +optional
+	BackupSchedule *DODatabaseClusterBackupSchedule `json:"backupSchedule,omitempty"`
+
+	// PasswordRotationTrigger: An arbitrary value that rotates the password
+	// of the default user of the database cluster whenever it is changed to
+	// a new value, e.g. a date. The new password is published to the
+	// connection secret once the cluster is online. The password is not
+	// rotated when the trigger is removed, nor when the cluster is created.
+	// Database users are not managed as resources of their own, so the
+	// trigger only rotates the password of the default user, whose
+	// credentials are the connection details. The passwords of other users
+	// of the cluster are left unchanged.
+	// +optional
+	PasswordRotationTrigger *string `json:"passwordRotationTrigger,omitempty"`
+
+	// RotationPolicy: Whether the connection details are written to the
+	// connection secret every time the cluster is observed ('Always', the
+	// default) or only when they change ('OnChange').
+	// +optional
+	RotationPolicy *dov1alpha1.ConnectionRotationPolicy `json:"rotationPolicy,omitempty"`
+
+	// ProjectID: The ID of the project the database cluster is assigned to. It is
+	// assigned to the default project of the account if no project is set,
+	// and moved when the project changes.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1.Project
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	ProjectID *string `json:"projectId,omitempty"`
+
+	// ProjectRef references the Project the database cluster is assigned to.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects a reference to the Project the database cluster is
+	// assigned to.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+}
+
+// A DODatabaseClusterBackupSchedule specifies the time of day at which the
+// daily backup of a Database Cluster is started.
+type DODatabaseClusterBackupSchedule struct {
+	// Hour: The hour in UTC at which the backup is started, from 0 to 23.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	Hour int `json:"hour"`
+
+	// Minute: The minute of the hour at which the backup is started, from 0
+	// to 59. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	Minute *int `json:"minute,omitempty"`
+}
+
+// A DODatabaseClusterMigration specifies the source of an online migration
+// into a Database Cluster.
+type DODatabaseClusterMigration struct {
+	// Host: The FQDN or IP address of the source database server.
+	Host string `json:"host"`
+
+	// Port: The port the source database server listens on.
+	Port int `json:"port"`
+
+	// Database: The name of the source database.
+	Database string `json:"database"`
+
+	// Username: The user to connect to the source database as.
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the Secret key that contains the password
+	// of the user.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+
+	// DisableSSL: Connect to the source database without SSL.
+	// +optional
+	DisableSSL *bool `json:"disableSSL,omitempty"`
+
+	// IgnoreDBs: The names of the databases on the source server that are
+	// not migrated.
+	// +optional
+	IgnoreDBs []string `json:"ignoreDBs,omitempty"`
+}
+
+// A DODatabaseClusterRestoreFrom specifies the backup a Database Cluster is
+// restored from when it is created.
+type DODatabaseClusterRestoreFrom struct {
+	// SourceClusterName: The name of the database cluster on DigitalOcean
+	// whose backup is restored.
+	// +optional
+	// +crossplane:generate:reference:type=DODatabaseCluster
+	// +crossplane:generate:reference:extractor=ClusterName()
+	SourceClusterName *string `json:"sourceClusterName,omitempty"`
+
+	// SourceClusterNameRef references a DODatabaseCluster whose backup is
+	// restored.
+	// +optional
+	SourceClusterNameRef *xpv1.Reference `json:"sourceClusterNameRef,omitempty"`
+
+	// SourceClusterNameSelector selects a reference to a DODatabaseCluster
+	// whose backup is restored.
+	// +optional
+	SourceClusterNameSelector *xpv1.Selector `json:"sourceClusterNameSelector,omitempty"`
+
+	// BackupCreatedAt: The time the backup to restore was created. It must
+	// be within the backup retention window of the source cluster. Defaults
+	// to the most recent backup.
+	// +optional
+	BackupCreatedAt *metav1.Time `json:"backupCreatedAt,omitempty"`
 }
 
 // A DODatabaseClusterObservation reflects the observed state of a Database Cluster on DigitalOcean.
@@ -112,6 +277,9 @@ type DODatabaseClusterObservation struct {
 	// An array of strings containing the names of databases created in the database cluster.
 	DbNames []string `json:"dbNames,omitempty"`
 
+	// The amount of storage of the database cluster in MiB.
+	StorageSizeMiB int64 `json:"storageSizeMiB,omitempty"`
+
 	Connection DODatabaseClusterConnection `json:"connection,omitempty"`
 
 	PrivateConnection DODatabaseClusterConnection `json:"private_connection"`
@@ -120,6 +288,64 @@ type DODatabaseClusterObservation struct {
 
 	// +kubebuilder:validation:Optional
 	MaintenanceWindow DODatabaseClusterMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// TrustedKubernetesClusterID is the ID of the Kubernetes cluster that was
+	// last added to the trusted sources of the database cluster.
+	TrustedKubernetesClusterID string `json:"trustedKubernetesClusterId,omitempty"`
+
+	// Migration is the online migration that was last started into the
+	// database cluster.
+	Migration *DODatabaseClusterMigrationObservation `json:"migration,omitempty"`
+
+	// Backups reflects the backup schedule of the database cluster and the
+	// backups that are retained.
+	Backups *DODatabaseClusterBackupsObservation `json:"backups,omitempty"`
+
+	// PasswordRotationTrigger is the password rotation trigger the password
+	// of the default user was last rotated for.
+	PasswordRotationTrigger string `json:"passwordRotationTrigger,omitempty"`
+}
+
+// A DODatabaseClusterBackupsObservation reflects the observed backup schedule
+// and retained backups of a Database Cluster.
+type DODatabaseClusterBackupsObservation struct {
+	// The hour in UTC at which the daily backup is started. It is only
+	// reported for engines that support scheduling backups.
+	Hour *int `json:"hour,omitempty"`
+
+	// The minute of the hour at which the daily backup is started. It is
+	// only reported for engines that support scheduling backups.
+	Minute *int `json:"minute,omitempty"`
+
+	// The number of backups that are retained.
+	Count int `json:"count"`
+
+	// The time the most recent backup was created, in RFC3339 text format.
+	LatestCreatedAt string `json:"latestCreatedAt,omitempty"`
+
+	// The time the oldest retained backup was created, in RFC3339 text
+	// format. A cluster can be restored from any point in time since then.
+	OldestCreatedAt string `json:"oldestCreatedAt,omitempty"`
+}
+
+// A DODatabaseClusterMigrationObservation reflects the observed state of an
+// online migration into a Database Cluster.
+type DODatabaseClusterMigrationObservation struct {
+	// The ID of the online migration.
+	ID string `json:"id"`
+
+	// A string representing the current status of the online migration.
+	//
+	// Possible values:
+	//	"running"
+	//	"syncing"
+	//	"canceled"
+	//	"error"
+	//	"done"
+	Status string `json:"status,omitempty"`
+
+	// The time the online migration was started, in RFC3339 text format.
+	CreatedAt string `json:"createdAt,omitempty"`
 }
 
 // A DODatabaseClusterConnection defines the connection information for a Database Cluster.
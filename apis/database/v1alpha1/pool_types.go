@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+// Modes of a connection pool.
+const (
+	PoolModeSession     = "session"
+	PoolModeTransaction = "transaction"
+	PoolModeStatement   = "statement"
+)
+
+// DatabaseConnectionPoolParameters define the desired state of a connection
+// pool of a DigitalOcean PostgreSQL database cluster. The name of the pool is
+// the external-name of the DatabaseConnectionPool, which defaults to the name
+// of the managed resource.
+type DatabaseConnectionPoolParameters struct {
+	// ClusterID: The ID of the database cluster the pool belongs to.
+	// +optional
+	// +immutable
+	// +crossplane:generate:reference:type=DODatabaseCluster
+	ClusterID *string `json:"clusterId,omitempty"`
+
+	// ClusterIDRef references a DODatabaseCluster to retrieve its ID.
+	// +optional
+	ClusterIDRef *xpv1.Reference `json:"clusterIdRef,omitempty"`
+
+	// ClusterIDSelector selects a reference to a DODatabaseCluster to
+	// retrieve its ID.
+	// +optional
+	ClusterIDSelector *xpv1.Selector `json:"clusterIdSelector,omitempty"`
+
+	// Mode: The PgBouncer transaction mode of the pool.
+	// +kubebuilder:validation:Enum=session;transaction;statement
+	Mode string `json:"mode"`
+
+	// Size: The number of connections the pool holds to the database.
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size"`
+
+	// Database: The name of the database the pool connects to.
+	Database string `json:"database"`
+
+	// User: The name of the database user the pool connects as. Defaults to
+	// the user of each inbound connection.
+	// +optional
+	User *string `json:"user,omitempty"`
+
+	// RotationPolicy: Whether the connection details are written to the
+	// connection secret every time the pool is observed ('Always', the
+	// default) or only when they change ('OnChange').
+	// +optional
+	RotationPolicy *dov1alpha1.ConnectionRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// A DatabaseConnectionPoolObservation reflects the observed state of a
+// connection pool of a DigitalOcean database cluster.
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/databases_add_connectionPool
+type DatabaseConnectionPoolObservation struct {
+	// The name of the pool.
+	Name string `json:"name,omitempty"`
+
+	// The PgBouncer transaction mode of the pool.
+	Mode string `json:"mode,omitempty"`
+
+	// The number of connections the pool holds to the database.
+	Size int `json:"size,omitempty"`
+
+	// The name of the database the pool connects to.
+	Database string `json:"database,omitempty"`
+
+	// The name of the database user the pool connects as.
+	User string `json:"user,omitempty"`
+}
+
+// A DatabaseConnectionPoolSpec defines the desired state of a
+// DatabaseConnectionPool.
+type DatabaseConnectionPoolSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DatabaseConnectionPoolParameters `json:"forProvider"`
+}
+
+// A DatabaseConnectionPoolStatus represents the observed state of a
+// DatabaseConnectionPool.
+type DatabaseConnectionPoolStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DatabaseConnectionPoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DatabaseConnectionPool is a managed resource that represents a connection
+// pool of a DigitalOcean database cluster. It publishes the connection details
+// of the pool, so that every pool of a cluster is bound through a connection
+// secret of its own.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".status.atProvider.database"
+// +kubebuilder:printcolumn:name="MODE",type="string",JSONPath=".status.atProvider.mode",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type DatabaseConnectionPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseConnectionPoolSpec   `json:"spec"`
+	Status DatabaseConnectionPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseConnectionPoolList contains a list of DatabaseConnectionPools.
+type DatabaseConnectionPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseConnectionPool `json:"items"`
+}
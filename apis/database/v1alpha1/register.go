@@ -42,6 +42,15 @@ var (
 	DBGroupVersionKind = SchemeGroupVersion.WithKind(DBKind)
 )
 
+// DatabaseConnectionPool type metadata.
+var (
+	DatabaseConnectionPoolKind             = reflect.TypeOf(DatabaseConnectionPool{}).Name()
+	DatabaseConnectionPoolGroupKind        = schema.GroupKind{Group: Group, Kind: DatabaseConnectionPoolKind}.String()
+	DatabaseConnectionPoolKindAPIVersion   = DatabaseConnectionPoolKind + "." + SchemeGroupVersion.String()
+	DatabaseConnectionPoolGroupVersionKind = SchemeGroupVersion.WithKind(DatabaseConnectionPoolKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&DODatabaseCluster{}, &DODatabaseClusterList{})
+	SchemeBuilder.Register(&DatabaseConnectionPool{}, &DatabaseConnectionPoolList{})
 }
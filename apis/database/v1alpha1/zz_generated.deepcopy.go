@@ -22,6 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +54,51 @@ func (in *DODatabaseCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterBackupSchedule) DeepCopyInto(out *DODatabaseClusterBackupSchedule) {
+	*out = *in
+	if in.Minute != nil {
+		in, out := &in.Minute, &out.Minute
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterBackupSchedule.
+func (in *DODatabaseClusterBackupSchedule) DeepCopy() *DODatabaseClusterBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterBackupsObservation) DeepCopyInto(out *DODatabaseClusterBackupsObservation) {
+	*out = *in
+	if in.Hour != nil {
+		in, out := &in.Hour, &out.Hour
+		*out = new(int)
+		**out = **in
+	}
+	if in.Minute != nil {
+		in, out := &in.Minute, &out.Minute
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterBackupsObservation.
+func (in *DODatabaseClusterBackupsObservation) DeepCopy() *DODatabaseClusterBackupsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterBackupsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterConnection) DeepCopyInto(out *DODatabaseClusterConnection) {
 	*out = *in
@@ -154,6 +201,47 @@ func (in *DODatabaseClusterMaintenanceWindow) DeepCopy() *DODatabaseClusterMaint
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterMigration) DeepCopyInto(out *DODatabaseClusterMigration) {
+	*out = *in
+	out.PasswordSecretRef = in.PasswordSecretRef
+	if in.DisableSSL != nil {
+		in, out := &in.DisableSSL, &out.DisableSSL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreDBs != nil {
+		in, out := &in.IgnoreDBs, &out.IgnoreDBs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterMigration.
+func (in *DODatabaseClusterMigration) DeepCopy() *DODatabaseClusterMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterMigrationObservation) DeepCopyInto(out *DODatabaseClusterMigrationObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterMigrationObservation.
+func (in *DODatabaseClusterMigrationObservation) DeepCopy() *DODatabaseClusterMigrationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterMigrationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterObservation) DeepCopyInto(out *DODatabaseClusterObservation) {
 	*out = *in
@@ -180,6 +268,16 @@ func (in *DODatabaseClusterObservation) DeepCopyInto(out *DODatabaseClusterObser
 		copy(*out, *in)
 	}
 	in.MaintenanceWindow.DeepCopyInto(&out.MaintenanceWindow)
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DODatabaseClusterMigrationObservation)
+		**out = **in
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = new(DODatabaseClusterBackupsObservation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterObservation.
@@ -215,6 +313,66 @@ func (in *DODatabaseClusterParameters) DeepCopyInto(out *DODatabaseClusterParame
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StorageSizeMiB != nil {
+		in, out := &in.StorageSizeMiB, &out.StorageSizeMiB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(DODatabaseClusterRestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedKubernetesClusterID != nil {
+		in, out := &in.TrustedKubernetesClusterID, &out.TrustedKubernetesClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TrustedKubernetesClusterIDRef != nil {
+		in, out := &in.TrustedKubernetesClusterIDRef, &out.TrustedKubernetesClusterIDRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.TrustedKubernetesClusterIDSelector != nil {
+		in, out := &in.TrustedKubernetesClusterIDSelector, &out.TrustedKubernetesClusterIDSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DODatabaseClusterMigration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupSchedule != nil {
+		in, out := &in.BackupSchedule, &out.BackupSchedule
+		*out = new(DODatabaseClusterBackupSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRotationTrigger != nil {
+		in, out := &in.PasswordRotationTrigger, &out.PasswordRotationTrigger
+		*out = new(string)
+		**out = **in
+	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(apisv1alpha1.ConnectionRotationPolicy)
+		**out = **in
+	}
+	if in.ProjectID != nil {
+		in, out := &in.ProjectID, &out.ProjectID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterParameters.
@@ -227,6 +385,40 @@ func (in *DODatabaseClusterParameters) DeepCopy() *DODatabaseClusterParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DODatabaseClusterRestoreFrom) DeepCopyInto(out *DODatabaseClusterRestoreFrom) {
+	*out = *in
+	if in.SourceClusterName != nil {
+		in, out := &in.SourceClusterName, &out.SourceClusterName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceClusterNameRef != nil {
+		in, out := &in.SourceClusterNameRef, &out.SourceClusterNameRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.SourceClusterNameSelector != nil {
+		in, out := &in.SourceClusterNameSelector, &out.SourceClusterNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupCreatedAt != nil {
+		in, out := &in.BackupCreatedAt, &out.BackupCreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DODatabaseClusterRestoreFrom.
+func (in *DODatabaseClusterRestoreFrom) DeepCopy() *DODatabaseClusterRestoreFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(DODatabaseClusterRestoreFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DODatabaseClusterSpec) DeepCopyInto(out *DODatabaseClusterSpec) {
 	*out = *in
@@ -291,3 +483,151 @@ func (in *DODatabaseUserMySQLSettings) DeepCopy() *DODatabaseUserMySQLSettings {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPool) DeepCopyInto(out *DatabaseConnectionPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPool.
+func (in *DatabaseConnectionPool) DeepCopy() *DatabaseConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseConnectionPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPoolList) DeepCopyInto(out *DatabaseConnectionPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseConnectionPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPoolList.
+func (in *DatabaseConnectionPoolList) DeepCopy() *DatabaseConnectionPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseConnectionPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPoolObservation) DeepCopyInto(out *DatabaseConnectionPoolObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPoolObservation.
+func (in *DatabaseConnectionPoolObservation) DeepCopy() *DatabaseConnectionPoolObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPoolObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPoolParameters) DeepCopyInto(out *DatabaseConnectionPoolParameters) {
+	*out = *in
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterIDRef != nil {
+		in, out := &in.ClusterIDRef, &out.ClusterIDRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ClusterIDSelector != nil {
+		in, out := &in.ClusterIDSelector, &out.ClusterIDSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(string)
+		**out = **in
+	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(apisv1alpha1.ConnectionRotationPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPoolParameters.
+func (in *DatabaseConnectionPoolParameters) DeepCopy() *DatabaseConnectionPoolParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPoolParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPoolSpec) DeepCopyInto(out *DatabaseConnectionPoolSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPoolSpec.
+func (in *DatabaseConnectionPoolSpec) DeepCopy() *DatabaseConnectionPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseConnectionPoolStatus) DeepCopyInto(out *DatabaseConnectionPoolStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionPoolStatus.
+func (in *DatabaseConnectionPoolStatus) DeepCopy() *DatabaseConnectionPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseConnectionPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -74,3 +74,59 @@ func (mg *DODatabaseCluster) SetProviderReference(r *xpv1.Reference) {
 func (mg *DODatabaseCluster) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
 	mg.Spec.WriteConnectionSecretToReference = r
 }
+
+// GetCondition of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this DatabaseConnectionPool.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *DatabaseConnectionPool) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this DatabaseConnectionPool.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *DatabaseConnectionPool) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this DatabaseConnectionPool.
+func (mg *DatabaseConnectionPool) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
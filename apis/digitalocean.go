@@ -20,21 +20,47 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	accountv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/account/v1alpha1"
+	appv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/app/v1alpha1"
+	cdnv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/cdn/v1alpha1"
+	certificatev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
 	computev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
 	dbv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	dnsv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/dns/v1alpha1"
+	firewallv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/firewall/v1alpha1"
+	functionsv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/functions/v1alpha1"
 	kubev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
 	lbv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+	projectv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1"
+	reservedipv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/reservedip/v1alpha1"
+	sshkeyv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/sshkey/v1alpha1"
+	storagev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/storage/v1alpha1"
+	tagv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1"
 	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	vpcv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1"
 )
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
 		dov1alpha1.SchemeBuilder.AddToScheme,
+		accountv1alpha1.SchemeBuilder.AddToScheme,
+		appv1alpha1.SchemeBuilder.AddToScheme,
+		cdnv1alpha1.SchemeBuilder.AddToScheme,
+		certificatev1alpha1.SchemeBuilder.AddToScheme,
 		computev1alpha1.SchemeBuilder.AddToScheme,
 		dbv1alpha1.SchemeBuilder.AddToScheme,
+		dnsv1alpha1.SchemeBuilder.AddToScheme,
+		firewallv1alpha1.SchemeBuilder.AddToScheme,
+		functionsv1alpha1.SchemeBuilder.AddToScheme,
 		kubev1alpha1.SchemeBuilder.AddToScheme,
 		lbv1alpha1.SchemeBuilder.AddToScheme,
+		projectv1alpha1.SchemeBuilder.AddToScheme,
+		reservedipv1alpha1.SchemeBuilder.AddToScheme,
+		sshkeyv1alpha1.SchemeBuilder.AddToScheme,
+		storagev1alpha1.SchemeBuilder.AddToScheme,
+		tagv1alpha1.SchemeBuilder.AddToScheme,
+		vpcv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DomainParameters define the desired state of a DigitalOcean DNS domain.
+// The name of the domain is the external-name of the Domain, which defaults
+// to the name of the managed resource.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Domains
+type DomainParameters struct {
+	// IPAddress: An IPv4 address of an A record that is created for the apex
+	// of the domain along with it.
+	// +optional
+	// +immutable
+	IPAddress *string `json:"ipAddress,omitempty"`
+
+	// AdoptExistingRecords: Import the domain along with its records. An
+	// existing domain named like the Domain is adopted instead of failing
+	// to create it, and the Records of the domain adopt the existing record
+	// with their name and type instead of creating a duplicate.
+	// +optional
+	AdoptExistingRecords *bool `json:"adoptExistingRecords,omitempty"`
+}
+
+// A DomainObservation reflects the observed state of a DigitalOcean DNS
+// domain.
+type DomainObservation struct {
+	// The name of the domain.
+	Name string `json:"name,omitempty"`
+
+	// The TTL in seconds of the records of the domain that do not set one.
+	TTL int `json:"ttl,omitempty"`
+}
+
+// A DomainSpec defines the desired state of a Domain.
+type DomainSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DomainParameters `json:"forProvider,omitempty"`
+}
+
+// A DomainStatus represents the observed state of a Domain.
+type DomainStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DomainObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Domain is a managed resource that represents a DigitalOcean DNS domain.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".status.atProvider.name"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Domain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DomainSpec   `json:"spec"`
+	Status DomainStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DomainList contains a list of Domains.
+type DomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Domain `json:"items"`
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RecordParameters define the desired state of a DigitalOcean DNS record.
+// Most fields map directly to a Domain Record:
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Domain-Records
+type RecordParameters struct {
+	// Domain: The name of the domain the record is created in.
+	// +optional
+	// +immutable
+	// +crossplane:generate:reference:type=Domain
+	// +crossplane:generate:reference:refFieldName=DomainRef
+	// +crossplane:generate:reference:selectorFieldName=DomainSelector
+	Domain *string `json:"domain,omitempty"`
+
+	// DomainRef references the Domain the record is created in.
+	// +optional
+	// +immutable
+	DomainRef *xpv1.Reference `json:"domainRef,omitempty"`
+
+	// DomainSelector selects a reference to the Domain the record is created
+	// in.
+	// +optional
+	// +immutable
+	DomainSelector *xpv1.Selector `json:"domainSelector,omitempty"`
+
+	// Type: The type of the record.
+	// +kubebuilder:validation:Enum=A;AAAA;CAA;CNAME;MX;NS;SRV;TXT
+	// +immutable
+	Type string `json:"type"`
+
+	// Name: The host name of the record relative to the domain, or "@" for
+	// the apex of the domain.
+	Name string `json:"name"`
+
+	// Data: The value of the record, e.g. an IP address for an A record or
+	// a host name for a CNAME record.
+	Data string `json:"data"`
+
+	// TTL: The time in seconds resolvers cache the record for. It must be
+	// at least 30. The TTL of the domain is used if it is not set.
+	// +optional
+	TTL *int `json:"ttl,omitempty"`
+
+	// Priority: The priority of an MX or SRV record. Clients prefer the
+	// records of the same name with the lowest priority.
+	// +optional
+	Priority *int `json:"priority,omitempty"`
+
+	// Port: The port of an SRV record.
+	// +optional
+	Port *int `json:"port,omitempty"`
+
+	// Weight: The weight of an SRV record. Clients distribute requests
+	// across the SRV records of the same name and priority in proportion to
+	// their weights. Records of other types of the same name are served
+	// round robin.
+	// +optional
+	Weight *int `json:"weight,omitempty"`
+
+	// Flags: The flags of a CAA record.
+	// +optional
+	Flags *int `json:"flags,omitempty"`
+
+	// Tag: The tag of a CAA record, one of "issue", "issuewild" or "iodef".
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+}
+
+// A RecordObservation reflects the observed state of a DigitalOcean DNS
+// record.
+type RecordObservation struct {
+	// ID of the record.
+	ID int `json:"id,omitempty"`
+
+	// Domain the record is created in.
+	Domain string `json:"domain,omitempty"`
+
+	// Type of the record.
+	Type string `json:"type,omitempty"`
+
+	// Name of the record relative to its domain.
+	Name string `json:"name,omitempty"`
+
+	// Data of the record.
+	Data string `json:"data,omitempty"`
+
+	// TTL of the record in seconds.
+	TTL int `json:"ttl,omitempty"`
+
+	// Priority of an MX or SRV record.
+	Priority int `json:"priority,omitempty"`
+
+	// Port of an SRV record.
+	Port int `json:"port,omitempty"`
+
+	// Weight of an SRV record.
+	Weight int `json:"weight,omitempty"`
+}
+
+// A RecordSpec defines the desired state of a Record.
+type RecordSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RecordParameters `json:"forProvider"`
+}
+
+// A RecordStatus represents the observed state of a Record.
+type RecordStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RecordObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Record is a managed resource that represents a DigitalOcean DNS record.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".status.atProvider.name"
+// +kubebuilder:printcolumn:name="DATA",type="string",JSONPath=".status.atProvider.data",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Record struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RecordSpec   `json:"spec"`
+	Status RecordStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RecordList contains a list of Records.
+type RecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Record `json:"items"`
+}
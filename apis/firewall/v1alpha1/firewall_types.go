@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Known Firewall statuses.
+const (
+	StatusWaiting   = "waiting"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// An EgressTemplate names a common set of outbound rules that allow traffic
+// to any address.
+// +kubebuilder:validation:Enum=AllowDNS;AllowHTTP;AllowHTTPS;AllowNTP;AllowICMP;AllowAll
+type EgressTemplate string
+
+// Known egress templates.
+const (
+	// EgressAllowDNS allows DNS queries, i.e. udp and tcp to port 53.
+	EgressAllowDNS EgressTemplate = "AllowDNS"
+
+	// EgressAllowHTTP allows tcp to port 80.
+	EgressAllowHTTP EgressTemplate = "AllowHTTP"
+
+	// EgressAllowHTTPS allows tcp to port 443.
+	EgressAllowHTTPS EgressTemplate = "AllowHTTPS"
+
+	// EgressAllowNTP allows time synchronization, i.e. udp to port 123.
+	EgressAllowNTP EgressTemplate = "AllowNTP"
+
+	// EgressAllowICMP allows icmp, e.g. ping.
+	EgressAllowICMP EgressTemplate = "AllowICMP"
+
+	// EgressAllowAll allows all tcp, udp and icmp traffic, like the
+	// outbound rules the DigitalOcean console suggests.
+	EgressAllowAll EgressTemplate = "AllowAll"
+)
+
+// FirewallParameters define the desired state of a DigitalOcean Firewall.
+// Most fields map directly to a Firewall:
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Firewalls
+type FirewallParameters struct {
+	// InboundRules: The rules that allow traffic to reach the Droplets of
+	// the Firewall. Traffic that no rule allows is dropped.
+	// +optional
+	InboundRules []FirewallInboundRule `json:"inboundRules,omitempty"`
+
+	// OutboundRules: The rules that allow traffic to leave the Droplets of
+	// the Firewall. Traffic that no rule allows is dropped.
+	// +optional
+	OutboundRules []FirewallOutboundRule `json:"outboundRules,omitempty"`
+
+	// DenyAllInbound: A boolean indicating whether inbound traffic is only
+	// allowed from the specific sources of inboundRules. Inbound rules that
+	// allow traffic from any address, i.e. from 0.0.0.0/0 or ::/0, are
+	// rejected, so that e.g. "allow SSH from the office and deny the rest"
+	// cannot be opened to the internet by mistake. Defaults to false.
+	// +optional
+	DenyAllInbound *bool `json:"denyAllInbound,omitempty"`
+
+	// EgressTemplates: The names of common sets of outbound rules that are
+	// added to outboundRules, e.g. AllowDNS and AllowHTTPS. Rules that are
+	// already declared are not added again.
+	// +optional
+	EgressTemplates []EgressTemplate `json:"egressTemplates,omitempty"`
+
+	// DropletIDs: The IDs of the Droplets the Firewall is applied to.
+	// +optional
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// Tags: The names of the Droplet tags the Firewall is applied to,
+	// including Droplets that are tagged after it was created.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.Tag
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.TagName()
+	// +crossplane:generate:reference:refFieldName=TagRefs
+	// +crossplane:generate:reference:selectorFieldName=TagSelector
+	Tags []string `json:"tags,omitempty"`
+
+	// TagRefs reference the Tags the Firewall is applied to. The names of
+	// the referenced Tags are resolved into tags while tags is empty.
+	// +optional
+	TagRefs []xpv1.Reference `json:"tagRefs,omitempty"`
+
+	// TagSelector selects references to the Tags the Firewall is applied to.
+	// +optional
+	TagSelector *xpv1.Selector `json:"tagSelector,omitempty"`
+}
+
+// A FirewallInboundRule allows traffic from the supplied sources to reach
+// the Droplets of a Firewall.
+type FirewallInboundRule struct {
+	// Protocol: The protocol of the traffic the rule allows.
+	// +kubebuilder:validation:Enum=tcp;udp;icmp
+	Protocol string `json:"protocol"`
+
+	// PortRange: The ports the rule allows traffic to, e.g. "22" or
+	// "8000-9000", or "all" for every port. It is ignored for icmp.
+	// +optional
+	PortRange string `json:"portRange,omitempty"`
+
+	// Sources the rule allows traffic from.
+	Sources FirewallRuleTarget `json:"sources"`
+}
+
+// A FirewallOutboundRule allows traffic from the Droplets of a Firewall to
+// reach the supplied destinations.
+type FirewallOutboundRule struct {
+	// Protocol: The protocol of the traffic the rule allows.
+	// +kubebuilder:validation:Enum=tcp;udp;icmp
+	Protocol string `json:"protocol"`
+
+	// PortRange: The ports the rule allows traffic to, e.g. "53" or
+	// "8000-9000", or "all" for every port. It is ignored for icmp.
+	// +optional
+	PortRange string `json:"portRange,omitempty"`
+
+	// Destinations the rule allows traffic to.
+	Destinations FirewallRuleTarget `json:"destinations"`
+}
+
+// A FirewallRuleTarget is the set of sources or destinations of a Firewall
+// rule.
+type FirewallRuleTarget struct {
+	// Addresses: IPv4 and IPv6 addresses and CIDR blocks, e.g.
+	// "192.0.2.0/24" or "::/0".
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// DropletIDs: The IDs of Droplets.
+	// +optional
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// Tags: The names of Droplet tags.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// LoadBalancerUIDs: The IDs of LoadBalancers.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1.LB
+	// +crossplane:generate:reference:refFieldName=LoadBalancerUIDRefs
+	// +crossplane:generate:reference:selectorFieldName=LoadBalancerUIDSelector
+	LoadBalancerUIDs []string `json:"loadBalancerUids,omitempty"`
+
+	// LoadBalancerUIDRefs reference the LBs that are targeted. The IDs of
+	// the referenced LBs are resolved into loadBalancerUids while it is
+	// empty.
+	// +optional
+	LoadBalancerUIDRefs []xpv1.Reference `json:"loadBalancerUidRefs,omitempty"`
+
+	// LoadBalancerUIDSelector selects references to the LBs that are
+	// targeted.
+	// +optional
+	LoadBalancerUIDSelector *xpv1.Selector `json:"loadBalancerUidSelector,omitempty"`
+
+	// KubernetesIDs: The IDs of Kubernetes clusters, whose nodes are
+	// targeted.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1.DOKubernetesCluster
+	// +crossplane:generate:reference:refFieldName=KubernetesIDRefs
+	// +crossplane:generate:reference:selectorFieldName=KubernetesIDSelector
+	KubernetesIDs []string `json:"kubernetesIds,omitempty"`
+
+	// KubernetesIDRefs reference the Kubernetes clusters whose nodes are
+	// targeted. The IDs of the referenced clusters are resolved into
+	// kubernetesIds while it is empty.
+	// +optional
+	KubernetesIDRefs []xpv1.Reference `json:"kubernetesIdRefs,omitempty"`
+
+	// KubernetesIDSelector selects references to the Kubernetes clusters
+	// whose nodes are targeted.
+	// +optional
+	KubernetesIDSelector *xpv1.Selector `json:"kubernetesIdSelector,omitempty"`
+}
+
+// A FirewallPendingChange is a change to the Droplets of a Firewall that is
+// not applied yet.
+type FirewallPendingChange struct {
+	// DropletID: The ID of the Droplet the change applies to.
+	DropletID int `json:"dropletId,omitempty"`
+
+	// Removing: Whether the Droplet is being removed from the Firewall
+	// rather than added to it.
+	Removing bool `json:"removing,omitempty"`
+
+	// Status of the change.
+	Status string `json:"status,omitempty"`
+}
+
+// A FirewallObservation reflects the observed state of a DigitalOcean
+// Firewall.
+type FirewallObservation struct {
+	// ID of the Firewall.
+	ID string `json:"id,omitempty"`
+
+	// Name of the Firewall.
+	Name string `json:"name,omitempty"`
+
+	// A Status string indicating whether the rules of the Firewall are
+	// applied, e.g. "waiting", "succeeded" or "failed".
+	Status string `json:"status,omitempty"`
+
+	// DropletIDs are the IDs of the Droplets the Firewall is applied to.
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// PendingChanges are the changes to the Droplets of the Firewall that
+	// are not applied yet.
+	PendingChanges []FirewallPendingChange `json:"pendingChanges,omitempty"`
+
+	// CreationTimestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+// A FirewallSpec defines the desired state of a Firewall.
+type FirewallSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FirewallParameters `json:"forProvider"`
+}
+
+// A FirewallStatus represents the observed state of a Firewall.
+type FirewallStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FirewallObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Firewall is a managed resource that represents a DigitalOcean Firewall.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Firewall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirewallSpec   `json:"spec"`
+	Status FirewallStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FirewallList contains a list of Firewalls.
+type FirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Firewall `json:"items"`
+}
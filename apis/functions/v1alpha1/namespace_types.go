@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+// FunctionNamespaceParameters define the desired state of a DigitalOcean
+// Functions namespace. A namespace cannot be changed once it is created.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Functions
+type FunctionNamespaceParameters struct {
+	// Region: The slug identifier of the region the namespace is
+	// provisioned in. Only some regions support Functions.
+	// +immutable
+	Region string `json:"region"`
+
+	// Label: The label of the namespace. Defaults to the name of the
+	// managed resource.
+	// +optional
+	// +immutable
+	Label *string `json:"label,omitempty"`
+
+	// RotationPolicy: Whether the connection details are written to the
+	// connection secret every time the namespace is observed ('Always', the
+	// default) or only when they change ('OnChange').
+	// +optional
+	RotationPolicy *dov1alpha1.ConnectionRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// A FunctionNamespaceObservation reflects the observed state of a Functions
+// namespace on DigitalOcean.
+type FunctionNamespaceObservation struct {
+	// ID of the namespace.
+	ID string `json:"id,omitempty"`
+
+	// UUID of the namespace, which deployment tooling authenticates as
+	// together with its key.
+	UUID string `json:"uuid,omitempty"`
+
+	// APIHost is the host of the API functions are deployed to and invoked
+	// through.
+	APIHost string `json:"apiHost,omitempty"`
+
+	// Label of the namespace.
+	Label string `json:"label,omitempty"`
+
+	// Region the namespace is provisioned in.
+	Region string `json:"region,omitempty"`
+
+	// CreatedAt is the time the namespace was created, in RFC3339 text
+	// format.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// UpdatedAt is the time the namespace was last updated, in RFC3339 text
+	// format.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// A FunctionNamespaceSpec defines the desired state of a FunctionNamespace.
+type FunctionNamespaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FunctionNamespaceParameters `json:"forProvider"`
+}
+
+// A FunctionNamespaceStatus represents the observed state of a
+// FunctionNamespace.
+type FunctionNamespaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FunctionNamespaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A FunctionNamespace is a managed resource that represents a DigitalOcean
+// Functions namespace. The host of its API and the credentials to deploy
+// functions to it are published to its connection secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".status.atProvider.region",priority=1
+// +kubebuilder:printcolumn:name="API-HOST",type="string",JSONPath=".status.atProvider.apiHost",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type FunctionNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FunctionNamespaceSpec   `json:"spec"`
+	Status FunctionNamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FunctionNamespaceList contains a list of FunctionNamespaces.
+type FunctionNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FunctionNamespace `json:"items"`
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 )
 
 // Known Kubernetes Cluster Statuses
@@ -33,6 +36,30 @@ const (
 	StatusDeleting     = "deleting"
 )
 
+// Known states of the worker nodes of a Kubernetes Cluster.
+const (
+	NodeStateRunning      = "running"
+	NodeStateProvisioning = "provisioning"
+	NodeStateDraining     = "draining"
+	NodeStateDeleting     = "deleting"
+)
+
+// ReasonDegraded is the reason of the Ready condition of a Kubernetes
+// Cluster that is degraded.
+const ReasonDegraded xpv1.ConditionReason = "Degraded"
+
+// Degraded returns a condition that indicates a Kubernetes Cluster or any of
+// its worker nodes is degraded, for the supplied reason.
+func Degraded(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDegraded,
+		Message:            msg,
+	}
+}
+
 // DOKubernetesClusterParameters define the desired state of a DigitalOcean Kubernetes Cluster
 // Most fields map directly to a KubernetesCluster.
 // See docs https://docs.digitalocean.com/reference/api/api-reference/#operation/create_kubernetes_cluster
@@ -51,10 +78,19 @@ type DOKubernetesClusterParameters struct {
 	VPCUUID *string `json:"vpcuui,omitempty"`
 
 	// An array of tags applied to the Kubernetes cluster. All clusters are automatically tagged k8s and k8s:$K8S_CLUSTER_ID.
+	// An empty array removes all other tags from the cluster, while omitting it leaves them as they are.
 	// +kubebuilder:validation:Optional
-	Tags []string `json:"tags,omitempty"`
+	Tags *[]string `json:"tags,omitempty"`
+
+	// A boolean value indicating whether the tags of the Kubernetes cluster are also applied to its node pools,
+	// and thereby to the Droplets of their nodes.
+	// +kubebuilder:validation:Optional
+	PropagateTags *bool `json:"propagateTags,omitempty"`
 
 	// An array of objects specifying the details of the worker nodes available to the Kubernetes cluster.
+	// The first entry is the default node pool: changes to its count, autoscaling settings and
+	// tags are reconciled after creation. The other node pools are only used when creating the cluster.
+	// The default node pool is matched by name, so it must not also be managed by another resource.
 	NodePools []KubernetesNodePool `json:"nodePools"`
 
 	// An object specifying the maintenance window policy for the Kubernetes cluster.
@@ -70,8 +106,29 @@ type DOKubernetesClusterParameters struct {
 	SurgeUpgrade *bool `json:"surgeUpgrade,omitempty"`
 
 	// A boolean value indicating whether the control plane is run in a highly available configuration in the cluster. Highly available control planes incur less downtime.
+	// A highly available control plane can be enabled on an existing cluster but it cannot be disabled afterwards.
 	// +kubebuilder:validation:Optional
 	HighlyAvailable *bool `json:"highlyAvailable,omitempty"`
+
+	// An object specifying the cluster-level configuration of the cluster
+	// autoscaler, which scales the node pools that have autoscaling enabled.
+	// It is applied right after the cluster is created, and changes to it are
+	// reconciled.
+	// +kubebuilder:validation:Optional
+	ClusterAutoscalerConfiguration *KubernetesClusterAutoscalerConfiguration `json:"clusterAutoscalerConfiguration,omitempty"`
+
+	// The number of seconds the kubeconfig and token published to the
+	// connection secret are valid for. Both are re-published before they
+	// expire. Defaults to the DigitalOcean default of seven days.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=600
+	KubeconfigExpirySeconds *int64 `json:"kubeconfigExpirySeconds,omitempty"`
+
+	// Whether the kubeconfig and token are written to the connection secret
+	// every time they are published ('Always', the default) or only when
+	// they change ('OnChange').
+	// +kubebuilder:validation:Optional
+	RotationPolicy *dov1alpha1.ConnectionRotationPolicy `json:"rotationPolicy,omitempty"`
 }
 
 // DOKubernetesClusterObservation reflects the observed state of a KubernetesCluster on DigitalOcean.
@@ -136,6 +193,73 @@ type DOKubernetesClusterObservation struct {
 
 	// A read-only boolean value indicating if a container registry is integrated with the cluster.
 	RegistryEnabled bool `json:"registryEnabled,omitempty"`
+
+	// The time the kubeconfig published to the connection secret expires.
+	KubeconfigExpiresAt *metav1.Time `json:"kubeconfigExpiresAt,omitempty"`
+
+	// The time the token published to the connection secret expires.
+	TokenExpiresAt *metav1.Time `json:"tokenExpiresAt,omitempty"`
+
+	// NodeHealth aggregates the health of the worker nodes of all node
+	// pools.
+	NodeHealth KubernetesNodeHealth `json:"nodeHealth,omitempty"`
+
+	// The cluster-level configuration of the cluster autoscaler.
+	ClusterAutoscalerConfiguration *KubernetesClusterAutoscalerConfiguration `json:"clusterAutoscalerConfiguration,omitempty"`
+}
+
+// KubernetesClusterAutoscalerConfiguration configures when the cluster
+// autoscaler scales down the nodes of a Kubernetes Cluster.
+type KubernetesClusterAutoscalerConfiguration struct {
+	// The utilization of a node, as a fraction of its allocatable CPU and
+	// memory requested by pods, below which the node is considered unneeded
+	// and may be scaled down, from 0 to 1, e.g. 0.65.
+	// +kubebuilder:validation:Optional
+	ScaleDownUtilizationThreshold *float64 `json:"scaleDownUtilizationThreshold,omitempty"`
+
+	// How long a node must be unneeded before it is scaled down, as a
+	// duration such as '1m0s'.
+	// +kubebuilder:validation:Optional
+	ScaleDownUnneededTime *string `json:"scaleDownUnneededTime,omitempty"`
+}
+
+// KubernetesNodeHealth aggregates the health of the worker nodes of a
+// Kubernetes Cluster.
+type KubernetesNodeHealth struct {
+	// The number of worker nodes in all node pools.
+	Total int `json:"total"`
+
+	// The number of worker nodes that are running.
+	Ready int `json:"ready"`
+
+	// The number of worker nodes that are being provisioned.
+	Provisioning int `json:"provisioning"`
+
+	// The number of worker nodes that are neither running, being
+	// provisioned, drained nor deleted.
+	Degraded int `json:"degraded"`
+
+	// The status of every worker node.
+	Nodes []KubernetesNodeHealthStatus `json:"nodes,omitempty"`
+}
+
+// KubernetesNodeHealthStatus is the status of a worker node of a Kubernetes
+// Cluster.
+type KubernetesNodeHealthStatus struct {
+	// The name of the node.
+	Name string `json:"name"`
+
+	// The name of the node pool the node belongs to.
+	NodePool string `json:"nodePool"`
+
+	// The ID of the Droplet used for the node.
+	DropletID string `json:"dropletID,omitempty"`
+
+	// The state of the node.
+	State string `json:"state,omitempty"`
+
+	// A message relating to the state of the node.
+	Message string `json:"message,omitempty"`
 }
 
 // KubernetesNodePool represents a node pool that makes up a Kubernetes Cluster
@@ -166,7 +290,9 @@ type KubernetesNodePool struct {
 	AutoScale bool `json:"autoScale,omitempty"`
 
 	// The minimum number of nodes that this node pool can be auto-scaled to. The value will be 0 if auto_scale is set to false.
+	// Node pools other than the default (i.e. first) one can be auto-scaled to 0 nodes.
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
 	MinNodes int `json:"minNodes,omitempty"`
 
 	// The maximum number of nodes that this node pool can be auto-scaled to. The value will be 0 if auto_scale is set to false.
@@ -186,8 +312,9 @@ type KubernetesNodePoolObservation struct {
 	// A human-readable name for the node pool.
 	Name string `json:"name,omitempty"`
 
-	// The number of Droplet instances in the node pool.
-	Count int `json:"count,omitempty"`
+	// The number of Droplet instances in the node pool. It is 0 while a pool
+	// that can be auto-scaled to zero has no nodes.
+	Count int `json:"count"`
 
 	// An array containing the tags applied to the node pool. All node pools are automatically tagged k8s, k8s-worker, and k8s:$K8S_CLUSTER_ID.
 	// +kubebuilder:validation:Optional
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DOKubernetesNodePoolParameters define the desired state of a node pool
+// that is added to an existing DigitalOcean Kubernetes Cluster.
+// Most fields map directly to a Node Pool:
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/add_kubernetes_node_pool
+type DOKubernetesNodePoolParameters struct {
+	// Cluster: The ID of the Kubernetes cluster the node pool is added to.
+	// +optional
+	// +immutable
+	// +crossplane:generate:reference:type=DOKubernetesCluster
+	// +crossplane:generate:reference:refFieldName=ClusterRef
+	// +crossplane:generate:reference:selectorFieldName=ClusterSelector
+	Cluster *string `json:"cluster,omitempty"`
+
+	// ClusterRef references the Kubernetes cluster the node pool is added
+	// to.
+	// +optional
+	// +immutable
+	ClusterRef *xpv1.Reference `json:"clusterRef,omitempty"`
+
+	// ClusterSelector selects a reference to the Kubernetes cluster the node
+	// pool is added to.
+	// +optional
+	// +immutable
+	ClusterSelector *xpv1.Selector `json:"clusterSelector,omitempty"`
+
+	// The node pool. Its name must not be the name of the default (i.e.
+	// first) node pool of a DOKubernetesCluster of the same cluster, which
+	// is reconciled by that DOKubernetesCluster. The size and name cannot
+	// be changed, and only the count, autoscaling and tags are reconciled.
+	KubernetesNodePool `json:",inline"`
+}
+
+// A DOKubernetesNodePoolSpec defines the desired state of a
+// DOKubernetesNodePool.
+type DOKubernetesNodePoolSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DOKubernetesNodePoolParameters `json:"forProvider"`
+}
+
+// A DOKubernetesNodePoolStatus represents the observed state of a
+// DOKubernetesNodePool.
+type DOKubernetesNodePoolStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KubernetesNodePoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DOKubernetesNodePool is a managed resource that represents a node pool of
+// a DigitalOcean Kubernetes Cluster that is managed separately from the
+// cluster.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="COUNT",type="integer",JSONPath=".status.atProvider.count"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type DOKubernetesNodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DOKubernetesNodePoolSpec   `json:"spec"`
+	Status DOKubernetesNodePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DOKubernetesNodePoolList contains a list of DOKubernetesNodePools.
+type DOKubernetesNodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DOKubernetesNodePool `json:"items"`
+}
@@ -45,6 +45,14 @@ var (
 	DOKubernetesClusterGroupVersionKind = SchemeGroupVersion.WithKind(DOKubernetesClusterKind)
 )
 
+// DOKubernetesNodePool type metadata.
+var (
+	DOKubernetesNodePoolKind             = reflect.TypeOf(DOKubernetesNodePool{}).Name()
+	DOKubernetesNodePoolGroupKind        = schema.GroupKind{Group: Group, Kind: DOKubernetesNodePoolKind}.String()
+	DOKubernetesNodePoolKindAPIVersion   = DOKubernetesNodePoolKind + "." + SchemeGroupVersion.String()
+	DOKubernetesNodePoolGroupVersionKind = SchemeGroupVersion.WithKind(DOKubernetesNodePoolKind)
+)
+
 // DOContainerRegistry type metadata.
 var (
 	DOContainerRegistryKind             = reflect.TypeOf(DOContainerRegistry{}).Name()
@@ -53,7 +61,17 @@ var (
 	DOContainerRegistryGroupVersionKind = SchemeGroupVersion.WithKind(DOContainerRegistryKind)
 )
 
+// RegistryGarbageCollection type metadata.
+var (
+	RegistryGarbageCollectionKind             = reflect.TypeOf(RegistryGarbageCollection{}).Name()
+	RegistryGarbageCollectionGroupKind        = schema.GroupKind{Group: Group, Kind: RegistryGarbageCollectionKind}.String()
+	RegistryGarbageCollectionKindAPIVersion   = RegistryGarbageCollectionKind + "." + SchemeGroupVersion.String()
+	RegistryGarbageCollectionGroupVersionKind = SchemeGroupVersion.WithKind(RegistryGarbageCollectionKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&DOKubernetesCluster{}, &DOKubernetesClusterList{})
+	SchemeBuilder.Register(&DOKubernetesNodePool{}, &DOKubernetesNodePoolList{})
 	SchemeBuilder.Register(&DOContainerRegistry{}, &DOContainerRegistryList{})
+	SchemeBuilder.Register(&RegistryGarbageCollection{}, &RegistryGarbageCollectionList{})
 }
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Phases of a garbage collection run.
+const (
+	GarbageCollectionRequested = "requested"
+	GarbageCollectionBlocked   = "blocked"
+	GarbageCollectionRunning   = "running"
+	GarbageCollectionCompleted = "completed"
+)
+
+// RegistryGarbageCollectionParameters define the desired state of a
+// DigitalOcean Container Registry garbage collection run.
+// Most fields map directly to a Garbage Collection:
+// https://docs.digitalocean.com/reference/api/api-reference/#operation/registry_run_garbageCollection
+type RegistryGarbageCollectionParameters struct {
+	// Registry: The name of the Container Registry to collect garbage in.
+	// +optional
+	// +immutable
+	// +crossplane:generate:reference:type=DOContainerRegistry
+	// +crossplane:generate:reference:refFieldName=RegistryRef
+	// +crossplane:generate:reference:selectorFieldName=RegistrySelector
+	Registry *string `json:"registry,omitempty"`
+
+	// RegistryRef references the Container Registry to collect garbage in.
+	// +optional
+	// +immutable
+	RegistryRef *xpv1.Reference `json:"registryRef,omitempty"`
+
+	// RegistrySelector selects a reference to the Container Registry to
+	// collect garbage in.
+	// +optional
+	// +immutable
+	RegistrySelector *xpv1.Selector `json:"registrySelector,omitempty"`
+
+	// Type: The type of garbage collection to run. Defaults to
+	// 'unreferenced blobs only'.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum="untagged manifests only";"unreferenced blobs only";"untagged manifests and unreferenced blobs"
+	Type *string `json:"type,omitempty"`
+}
+
+// A RegistryGarbageCollectionObservation reflects the observed state of a
+// garbage collection run on DigitalOcean.
+type RegistryGarbageCollectionObservation struct {
+	// UUID of the garbage collection run.
+	UUID string `json:"uuid,omitempty"`
+
+	// The name of the Container Registry the garbage is collected in.
+	RegistryName string `json:"registryName,omitempty"`
+
+	// The status of the run as reported by DigitalOcean, e.g. 'scanning
+	// manifests' or 'succeeded'.
+	Status string `json:"status,omitempty"`
+
+	// The phase of the run: requested, blocked while it waits for write
+	// access to the registry to end, running, or completed whether or not
+	// it succeeded.
+	Phase string `json:"phase,omitempty"`
+
+	// The type of garbage collection.
+	Type string `json:"type,omitempty"`
+
+	// The time at which the run was requested.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// The time at which the run was last updated.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+
+	// The number of blobs deleted by the run.
+	BlobsDeleted uint64 `json:"blobsDeleted,omitempty"`
+
+	// The amount of storage freed by the run in bytes.
+	FreedBytes uint64 `json:"freedBytes,omitempty"`
+}
+
+// A RegistryGarbageCollectionSpec defines the desired state of a
+// RegistryGarbageCollection.
+type RegistryGarbageCollectionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RegistryGarbageCollectionParameters `json:"forProvider"`
+}
+
+// A RegistryGarbageCollectionStatus represents the observed state of a
+// RegistryGarbageCollection.
+type RegistryGarbageCollectionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RegistryGarbageCollectionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RegistryGarbageCollection is a managed resource that triggers and tracks
+// a garbage collection run in a DigitalOcean Container Registry. A run is only
+// triggered once no other run is active in the registry. Deleting the
+// resource stops tracking the run without cancelling it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.atProvider.phase"
+// +kubebuilder:printcolumn:name="FREED",type="integer",JSONPath=".status.atProvider.freedBytes"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type RegistryGarbageCollection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegistryGarbageCollectionSpec   `json:"spec"`
+	Status RegistryGarbageCollectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RegistryGarbageCollectionList contains a list of RegistryGarbageCollections.
+type RegistryGarbageCollectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegistryGarbageCollection `json:"items"`
+}
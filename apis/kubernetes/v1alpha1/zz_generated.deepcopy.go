@@ -22,6 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -230,6 +232,20 @@ func (in *DOKubernetesClusterObservation) DeepCopyInto(out *DOKubernetesClusterO
 	}
 	out.MaintenancePolicy = in.MaintenancePolicy
 	out.Status = in.Status
+	if in.KubeconfigExpiresAt != nil {
+		in, out := &in.KubeconfigExpiresAt, &out.KubeconfigExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TokenExpiresAt != nil {
+		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	in.NodeHealth.DeepCopyInto(&out.NodeHealth)
+	if in.ClusterAutoscalerConfiguration != nil {
+		in, out := &in.ClusterAutoscalerConfiguration, &out.ClusterAutoscalerConfiguration
+		*out = new(KubernetesClusterAutoscalerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesClusterObservation.
@@ -252,8 +268,17 @@ func (in *DOKubernetesClusterParameters) DeepCopyInto(out *DOKubernetesClusterPa
 	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.PropagateTags != nil {
+		in, out := &in.PropagateTags, &out.PropagateTags
+		*out = new(bool)
+		**out = **in
 	}
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
@@ -282,6 +307,21 @@ func (in *DOKubernetesClusterParameters) DeepCopyInto(out *DOKubernetesClusterPa
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ClusterAutoscalerConfiguration != nil {
+		in, out := &in.ClusterAutoscalerConfiguration, &out.ClusterAutoscalerConfiguration
+		*out = new(KubernetesClusterAutoscalerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeconfigExpirySeconds != nil {
+		in, out := &in.KubeconfigExpirySeconds, &out.KubeconfigExpirySeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(apisv1alpha1.ConnectionRotationPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesClusterParameters.
@@ -328,6 +368,155 @@ func (in *DOKubernetesClusterStatus) DeepCopy() *DOKubernetesClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOKubernetesNodePool) DeepCopyInto(out *DOKubernetesNodePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesNodePool.
+func (in *DOKubernetesNodePool) DeepCopy() *DOKubernetesNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(DOKubernetesNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOKubernetesNodePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOKubernetesNodePoolList) DeepCopyInto(out *DOKubernetesNodePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DOKubernetesNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesNodePoolList.
+func (in *DOKubernetesNodePoolList) DeepCopy() *DOKubernetesNodePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(DOKubernetesNodePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DOKubernetesNodePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOKubernetesNodePoolParameters) DeepCopyInto(out *DOKubernetesNodePoolParameters) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.KubernetesNodePool.DeepCopyInto(&out.KubernetesNodePool)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesNodePoolParameters.
+func (in *DOKubernetesNodePoolParameters) DeepCopy() *DOKubernetesNodePoolParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DOKubernetesNodePoolParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOKubernetesNodePoolSpec) DeepCopyInto(out *DOKubernetesNodePoolSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesNodePoolSpec.
+func (in *DOKubernetesNodePoolSpec) DeepCopy() *DOKubernetesNodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DOKubernetesNodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DOKubernetesNodePoolStatus) DeepCopyInto(out *DOKubernetesNodePoolStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DOKubernetesNodePoolStatus.
+func (in *DOKubernetesNodePoolStatus) DeepCopy() *DOKubernetesNodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DOKubernetesNodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesClusterAutoscalerConfiguration) DeepCopyInto(out *KubernetesClusterAutoscalerConfiguration) {
+	*out = *in
+	if in.ScaleDownUtilizationThreshold != nil {
+		in, out := &in.ScaleDownUtilizationThreshold, &out.ScaleDownUtilizationThreshold
+		*out = new(float64)
+		**out = **in
+	}
+	if in.ScaleDownUnneededTime != nil {
+		in, out := &in.ScaleDownUnneededTime, &out.ScaleDownUnneededTime
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesClusterAutoscalerConfiguration.
+func (in *KubernetesClusterAutoscalerConfiguration) DeepCopy() *KubernetesClusterAutoscalerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesClusterAutoscalerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesClusterMaintenancePolicy) DeepCopyInto(out *KubernetesClusterMaintenancePolicy) {
 	*out = *in
@@ -375,6 +564,41 @@ func (in *KubernetesNode) DeepCopy() *KubernetesNode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesNodeHealth) DeepCopyInto(out *KubernetesNodeHealth) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]KubernetesNodeHealthStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesNodeHealth.
+func (in *KubernetesNodeHealth) DeepCopy() *KubernetesNodeHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesNodeHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesNodeHealthStatus) DeepCopyInto(out *KubernetesNodeHealthStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesNodeHealthStatus.
+func (in *KubernetesNodeHealthStatus) DeepCopy() *KubernetesNodeHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesNodeHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesNodePool) DeepCopyInto(out *KubernetesNodePool) {
 	*out = *in
@@ -474,6 +698,149 @@ func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollection) DeepCopyInto(out *RegistryGarbageCollection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollection.
+func (in *RegistryGarbageCollection) DeepCopy() *RegistryGarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegistryGarbageCollection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollectionList) DeepCopyInto(out *RegistryGarbageCollectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RegistryGarbageCollection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollectionList.
+func (in *RegistryGarbageCollectionList) DeepCopy() *RegistryGarbageCollectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegistryGarbageCollectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollectionObservation) DeepCopyInto(out *RegistryGarbageCollectionObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollectionObservation.
+func (in *RegistryGarbageCollectionObservation) DeepCopy() *RegistryGarbageCollectionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollectionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollectionParameters) DeepCopyInto(out *RegistryGarbageCollectionParameters) {
+	*out = *in
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(string)
+		**out = **in
+	}
+	if in.RegistryRef != nil {
+		in, out := &in.RegistryRef, &out.RegistryRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.RegistrySelector != nil {
+		in, out := &in.RegistrySelector, &out.RegistrySelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollectionParameters.
+func (in *RegistryGarbageCollectionParameters) DeepCopy() *RegistryGarbageCollectionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollectionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollectionSpec) DeepCopyInto(out *RegistryGarbageCollectionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollectionSpec.
+func (in *RegistryGarbageCollectionSpec) DeepCopy() *RegistryGarbageCollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryGarbageCollectionStatus) DeepCopyInto(out *RegistryGarbageCollectionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryGarbageCollectionStatus.
+func (in *RegistryGarbageCollectionStatus) DeepCopy() *RegistryGarbageCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryGarbageCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subscription) DeepCopyInto(out *Subscription) {
 	*out = *in
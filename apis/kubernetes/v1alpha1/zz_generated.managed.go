@@ -130,3 +130,115 @@ func (mg *DOKubernetesCluster) SetProviderReference(r *xpv1.Reference) {
 func (mg *DOKubernetesCluster) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
 	mg.Spec.WriteConnectionSecretToReference = r
 }
+
+// GetCondition of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this DOKubernetesNodePool.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *DOKubernetesNodePool) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this DOKubernetesNodePool.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *DOKubernetesNodePool) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this DOKubernetesNodePool.
+func (mg *DOKubernetesNodePool) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetCondition of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this RegistryGarbageCollection.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *RegistryGarbageCollection) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this RegistryGarbageCollection.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *RegistryGarbageCollection) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this RegistryGarbageCollection.
+func (mg *RegistryGarbageCollection) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
@@ -36,3 +36,21 @@ func (l *DOKubernetesClusterList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this DOKubernetesNodePoolList.
+func (l *DOKubernetesNodePoolList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this RegistryGarbageCollectionList.
+func (l *RegistryGarbageCollectionList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
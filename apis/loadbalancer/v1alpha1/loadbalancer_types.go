@@ -50,6 +50,12 @@ type LBParameters struct {
 	// +kubebuilder:validation:Maximum=65535
 	Port int `json:"port,omitempty"`
 
+	// ForwardingRules: The rules that forward traffic from the LB to its
+	// backend Droplets. If omitted, TCP traffic to port is forwarded to the
+	// same port. Changes are applied in place.
+	// +optional
+	ForwardingRules []LBForwardingRule `json:"forwardingRules,omitempty"`
+
 	// An object specifying health check settings for the Load Balancer. If omitted, default values will be provided.
 	// +optional
 	HealthCheck DOLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
@@ -58,14 +64,197 @@ type LBParameters struct {
 	// is created. Tag names can either be existing or new tags.
 	// +optional
 	// +immutable
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.Tag
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.TagName()
+	// +crossplane:generate:reference:refFieldName=TagRefs
+	// +crossplane:generate:reference:selectorFieldName=TagSelector
 	Tags []string `json:"tags,omitempty"`
 
+	// TagRefs reference the Tags to apply to the LB. The names of the
+	// referenced Tags are resolved into tags while tags is empty.
+	// +optional
+	// +immutable
+	TagRefs []xpv1.Reference `json:"tagRefs,omitempty"`
+
+	// TagSelector selects references to the Tags to apply to the LB.
+	// +optional
+	// +immutable
+	TagSelector *xpv1.Selector `json:"tagSelector,omitempty"`
+
 	// VPCUUID: A string specifying the UUID of the VPC to which the LB
 	// will be assigned. If excluded, beginning on April 7th, 2020, the LB
-	// will be assigned to your account's default VPC for the region.
+	// will be assigned to your account's default VPC for the region. The VPC
+	// must be in the region of the LB.
 	// +optional
 	// +immutable
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1.VPC
+	// +crossplane:generate:reference:refFieldName=VPCRef
+	// +crossplane:generate:reference:selectorFieldName=VPCSelector
 	VPCUUID *string `json:"vpc_uuid,omitempty"`
+
+	// VPCRef references the VPC to which the LB will be assigned.
+	// +optional
+	// +immutable
+	VPCRef *xpv1.Reference `json:"vpcRef,omitempty"`
+
+	// VPCSelector selects a reference to the VPC to which the LB will be
+	// assigned.
+	// +optional
+	// +immutable
+	VPCSelector *xpv1.Selector `json:"vpcSelector,omitempty"`
+
+	// DropletIDs: The IDs of the Droplets assigned to the LB. Only one of
+	// dropletIds and tag may be set.
+	// +optional
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// DropletRefs reference the Droplets assigned to the LB, in addition to
+	// the Droplets in dropletIds. They are resolved on every reconcile, so the
+	// LB follows a referenced Droplet that is recreated with a new ID. Prefer
+	// tag if Droplets are recreated often: tag-based membership follows them
+	// without waiting for the LB to be reconciled. Only one of dropletRefs
+	// and tag may be set.
+	// +optional
+	DropletRefs []xpv1.Reference `json:"dropletRefs,omitempty"`
+
+	// Tag: The name of a Droplet tag. Droplets with this tag are assigned to
+	// the LB, including Droplets that are tagged after it was created. Only
+	// one of dropletIds and tag may be set.
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// DisableLetsEncryptDNSRecords: A boolean value indicating whether to
+	// disable automatic DNS record creation for Let's Encrypt certificates
+	// that are added to the LB.
+	// +optional
+	DisableLetsEncryptDNSRecords *bool `json:"disableLetsEncryptDnsRecords,omitempty"`
+
+	// Firewall: An object specifying the sources allowed or denied to
+	// connect to the LB. Only one of allow and deny may be set.
+	// +optional
+	Firewall *LBFirewall `json:"firewall,omitempty"`
+
+	// Pools: Named groups of backend Droplets, e.g. the tiers of an
+	// application, each with the forwarding rules that serve it. A LB has a
+	// single set of backend Droplets and a single health check, so the
+	// Droplets of all pools are assigned to the LB and every forwarding rule
+	// reaches all of them. Pools may only select Droplets by the same tag,
+	// and may only define the same health check.
+	// +optional
+	Pools []LBPool `json:"pools,omitempty"`
+
+	// ProjectID: The ID of the project the LB is assigned to. It is
+	// assigned to the default project of the account if no project is set,
+	// and moved when the project changes.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1.Project
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	ProjectID *string `json:"projectId,omitempty"`
+
+	// ProjectRef references the Project the LB is assigned to.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects a reference to the Project the LB is
+	// assigned to.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+}
+
+// LBPool define a named group of Droplets assigned to a DigitalOcean
+// loadbalancer.
+type LBPool struct {
+	// Name of the pool. It must be unique within the LB.
+	Name string `json:"name"`
+
+	// DropletIDs: The IDs of the Droplets in the pool.
+	// +optional
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// DropletRefs reference the Droplets in the pool, in addition to the
+	// Droplets in dropletIds.
+	// +optional
+	DropletRefs []xpv1.Reference `json:"dropletRefs,omitempty"`
+
+	// Tag: The name of a Droplet tag. Droplets with this tag are in the pool.
+	// Only one of dropletIds or dropletRefs and tag may be set.
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// ForwardingRules: The rules that forward traffic from the LB to the
+	// pool.
+	// +optional
+	ForwardingRules []LBForwardingRule `json:"forwardingRules,omitempty"`
+
+	// HealthCheck of the pool. It must match the health check of the LB and
+	// of every other pool that defines one.
+	// +optional
+	HealthCheck *DOLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// LBForwardingRule define a DigitalOcean loadbalancers forwarding rule.
+type LBForwardingRule struct {
+	// EntryProtocol: The protocol used for traffic to the LB. The https,
+	// http2 and http3 protocols require a certificate, or TLS passthrough
+	// for https and http2.
+	// +kubebuilder:validation:Enum=http;https;http2;http3;tcp;udp
+	EntryProtocol string `json:"entryProtocol"`
+
+	// EntryPort: The port on which the LB instance will listen.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	EntryPort int `json:"entryPort"`
+
+	// TargetProtocol: The protocol used for traffic from the LB to the
+	// backend Droplets. HTTP entry protocols can only forward to http, https
+	// or http2; tcp and udp can only forward to the same protocol.
+	// +kubebuilder:validation:Enum=http;https;http2;tcp;udp
+	TargetProtocol string `json:"targetProtocol"`
+
+	// TargetPort: The port on the backend Droplets to which the LB will send
+	// traffic.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	TargetPort int `json:"targetPort"`
+
+	// CertificateID: The ID of the TLS certificate used for SSL termination.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1.Certificate
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1.CertificateID()
+	// +crossplane:generate:reference:refFieldName=CertificateRef
+	// +crossplane:generate:reference:selectorFieldName=CertificateSelector
+	CertificateID string `json:"certificateId,omitempty"`
+
+	// CertificateRef references the Certificate used for SSL termination.
+	// The ID of the Certificate is resolved on every reconcile, so the rule
+	// follows a Let's Encrypt certificate that is renewed with a new ID.
+	// +optional
+	CertificateRef *xpv1.Reference `json:"certificateRef,omitempty"`
+
+	// CertificateSelector selects a reference to the Certificate used for
+	// SSL termination.
+	// +optional
+	CertificateSelector *xpv1.Selector `json:"certificateSelector,omitempty"`
+
+	// TLSPassthrough: A boolean indicating whether TLS traffic is passed
+	// through to the backend Droplets unterminated. It requires https or
+	// http2 as both the entry and target protocol.
+	// +optional
+	TLSPassthrough bool `json:"tlsPassthrough,omitempty"`
+}
+
+// LBFirewall define the DigitalOcean loadbalancers firewall configurations.
+type LBFirewall struct {
+	// Allow: A list of sources allowed to connect to the LB, e.g.
+	// "ip:1.2.3.4" or "cidr:2.3.0.0/16".
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny: A list of sources denied to connect to the LB, e.g.
+	// "ip:1.2.3.4" or "cidr:2.3.0.0/16".
+	// +optional
+	Deny []string `json:"deny,omitempty"`
 }
 
 // DOLoadBalancerHealthCheck define the DigitalOcean loadbalancers health check configurations.
@@ -107,6 +296,9 @@ type LBObservation struct {
 	// IP for the resource.
 	IP int `json:"ip,omitempty"`
 
+	// DropletIDs are the IDs of the Droplets assigned to the LB.
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
 	// A Status string indicating the state of the LB instance.
 	//
 	// Possible values:
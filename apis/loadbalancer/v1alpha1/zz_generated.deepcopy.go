@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -67,6 +68,56 @@ func (in *LB) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LBFirewall) DeepCopyInto(out *LBFirewall) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBFirewall.
+func (in *LBFirewall) DeepCopy() *LBFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(LBFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LBForwardingRule) DeepCopyInto(out *LBForwardingRule) {
+	*out = *in
+	if in.CertificateRef != nil {
+		in, out := &in.CertificateRef, &out.CertificateRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.CertificateSelector != nil {
+		in, out := &in.CertificateSelector, &out.CertificateSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBForwardingRule.
+func (in *LBForwardingRule) DeepCopy() *LBForwardingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LBForwardingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LBList) DeepCopyInto(out *LBList) {
 	*out = *in
@@ -102,6 +153,11 @@ func (in *LBList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LBObservation) DeepCopyInto(out *LBObservation) {
 	*out = *in
+	if in.DropletIDs != nil {
+		in, out := &in.DropletIDs, &out.DropletIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBObservation.
@@ -117,17 +173,91 @@ func (in *LBObservation) DeepCopy() *LBObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LBParameters) DeepCopyInto(out *LBParameters) {
 	*out = *in
+	if in.ForwardingRules != nil {
+		in, out := &in.ForwardingRules, &out.ForwardingRules
+		*out = make([]LBForwardingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.HealthCheck = in.HealthCheck
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TagRefs != nil {
+		in, out := &in.TagRefs, &out.TagRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagSelector != nil {
+		in, out := &in.TagSelector, &out.TagSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VPCUUID != nil {
 		in, out := &in.VPCUUID, &out.VPCUUID
 		*out = new(string)
 		**out = **in
 	}
+	if in.VPCRef != nil {
+		in, out := &in.VPCRef, &out.VPCRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.VPCSelector != nil {
+		in, out := &in.VPCSelector, &out.VPCSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DropletIDs != nil {
+		in, out := &in.DropletIDs, &out.DropletIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropletRefs != nil {
+		in, out := &in.DropletRefs, &out.DropletRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(string)
+		**out = **in
+	}
+	if in.DisableLetsEncryptDNSRecords != nil {
+		in, out := &in.DisableLetsEncryptDNSRecords, &out.DisableLetsEncryptDNSRecords
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(LBFirewall)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]LBPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProjectID != nil {
+		in, out := &in.ProjectID, &out.ProjectID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBParameters.
@@ -140,6 +270,48 @@ func (in *LBParameters) DeepCopy() *LBParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LBPool) DeepCopyInto(out *LBPool) {
+	*out = *in
+	if in.DropletIDs != nil {
+		in, out := &in.DropletIDs, &out.DropletIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropletRefs != nil {
+		in, out := &in.DropletRefs, &out.DropletRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForwardingRules != nil {
+		in, out := &in.ForwardingRules, &out.ForwardingRules
+		*out = make([]LBForwardingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(DOLoadBalancerHealthCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBPool.
+func (in *LBPool) DeepCopy() *LBPool {
+	if in == nil {
+		return nil
+	}
+	out := new(LBPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LBSpec) DeepCopyInto(out *LBSpec) {
 	*out = *in
@@ -161,7 +333,7 @@ func (in *LBSpec) DeepCopy() *LBSpec {
 func (in *LBStatus) DeepCopyInto(out *LBStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBStatus.
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ProjectParameters define the desired state of a DigitalOcean project. The
+// project is named like the managed resource.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Projects
+type ProjectParameters struct {
+	// Purpose: The purpose of the project, e.g. "Web Application".
+	Purpose string `json:"purpose"`
+
+	// Description: A free-form text field to describe the project.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Environment: The environment of the resources of the project.
+	// +kubebuilder:validation:Enum=Development;Staging;Production
+	// +optional
+	Environment *string `json:"environment,omitempty"`
+}
+
+// A ProjectObservation reflects the observed state of a DigitalOcean
+// project.
+type ProjectObservation struct {
+	// ID of the project.
+	ID string `json:"id,omitempty"`
+
+	// Name of the project.
+	Name string `json:"name,omitempty"`
+
+	// OwnerUUID is the UUID of the team that owns the project.
+	OwnerUUID string `json:"ownerUuid,omitempty"`
+
+	// IsDefault is true if resources are assigned to the project unless
+	// they declare another one.
+	IsDefault bool `json:"isDefault,omitempty"`
+
+	// CreatedAt is the time the project was created at.
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// A ProjectSpec defines the desired state of a Project.
+type ProjectSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectParameters `json:"forProvider"`
+}
+
+// A ProjectStatus represents the observed state of a Project.
+type ProjectStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ProjectObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Project is a managed resource that represents a DigitalOcean project.
+// Droplets, Volumes, LoadBalancers and database clusters that reference a
+// Project are assigned to it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="DEFAULT",type="boolean",JSONPath=".status.atProvider.isDefault",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Project struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectSpec   `json:"spec"`
+	Status ProjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectList contains a list of Projects.
+type ProjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Project `json:"items"`
+}
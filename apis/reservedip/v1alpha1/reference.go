@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	computev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	projectv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1"
+)
+
+// ResolveReferences of this ReservedIP. The references cannot be generated
+// because the ID of a Droplet is an integer, so the project reference is
+// resolved here too.
+func (mg *ReservedIP) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var current string
+	if mg.Spec.ForProvider.DropletID != nil {
+		current = strconv.Itoa(*mg.Spec.ForProvider.DropletID)
+	}
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: current,
+		Extract:      computev1alpha1.DropletID(),
+		Reference:    mg.Spec.ForProvider.DropletRef,
+		Selector:     mg.Spec.ForProvider.DropletSelector,
+		To: reference.To{
+			List:    &computev1alpha1.DropletList{},
+			Managed: &computev1alpha1.Droplet{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.DropletID")
+	}
+	if rsp.ResolvedValue != "" {
+		id, err := strconv.Atoi(rsp.ResolvedValue)
+		if err != nil {
+			return errors.Wrap(err, "mg.Spec.ForProvider.DropletID")
+		}
+		mg.Spec.ForProvider.DropletID = &id
+	}
+	mg.Spec.ForProvider.DropletRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.ProjectID),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.ProjectRef,
+		Selector:     mg.Spec.ForProvider.ProjectSelector,
+		To: reference.To{
+			List:    &projectv1alpha1.ProjectList{},
+			Managed: &projectv1alpha1.Project{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.ProjectID")
+	}
+	mg.Spec.ForProvider.ProjectID = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.ProjectRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ReservedIPParameters define the desired state of a DigitalOcean reserved
+// IP. A reserved IP is reserved to a region, or to the region of the Droplet
+// it is assigned to when it is created.
+type ReservedIPParameters struct {
+	// Region: The slug identifier for the region the reserved IP is
+	// reserved to. Required unless dropletId is set, in which case it
+	// defaults to the region of the Droplet. The Droplet must be in this
+	// region if both are set.
+	// +optional
+	// +immutable
+	Region *string `json:"region,omitempty"`
+
+	// DropletID: The ID of the Droplet the reserved IP is assigned to. The
+	// reserved IP is unassigned if it is not set.
+	// +optional
+	DropletID *int `json:"dropletId,omitempty"`
+
+	// DropletRef references a Droplet to retrieve its ID. It is resolved
+	// into dropletId while dropletId is not set.
+	// +optional
+	DropletRef *xpv1.Reference `json:"dropletRef,omitempty"`
+
+	// DropletSelector selects a reference to a Droplet to retrieve its ID.
+	// +optional
+	DropletSelector *xpv1.Selector `json:"dropletSelector,omitempty"`
+
+	// UnassignOnDelete: A boolean indicating whether the reserved IP is
+	// unassigned from its Droplet before it is released. A reserved IP
+	// that is still assigned to a Droplet is not released unless this is
+	// set. Defaults to false.
+	// +optional
+	UnassignOnDelete *bool `json:"unassignOnDelete,omitempty"`
+
+	// ProjectID: The ID of the project the reserved IP is assigned to. It
+	// is assigned to the default project of the account if no project is
+	// set, and moved when the project changes.
+	// +optional
+	ProjectID *string `json:"projectId,omitempty"`
+
+	// ProjectRef references the Project the reserved IP is assigned to. It
+	// is resolved into projectId while projectId is not set.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects a reference to the Project the reserved IP
+	// is assigned to.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+
+	// Tags: A flat array of tag names as strings to be applied to the
+	// reserved IP. Tags that are added to the reserved IP by other means
+	// are removed.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// A ReservedIPObservation reflects the observed state of a DigitalOcean
+// reserved IP.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Reserved-IPs
+type ReservedIPObservation struct {
+	// The reserved IP address.
+	IP string `json:"ip,omitempty"`
+
+	// The slug identifier for the region the reserved IP is reserved to.
+	Region string `json:"region,omitempty"`
+
+	// The ID of the Droplet the reserved IP is assigned to, if any.
+	DropletID int `json:"dropletId,omitempty"`
+
+	// A boolean indicating whether the reserved IP is locked by an action
+	// that is in progress, e.g. while it is being assigned.
+	Locked bool `json:"locked,omitempty"`
+
+	// The ID of the project the reserved IP belongs to.
+	ProjectID string `json:"projectId,omitempty"`
+
+	// The tags of the reserved IP.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// A ReservedIPSpec defines the desired state of a ReservedIP.
+type ReservedIPSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ReservedIPParameters `json:"forProvider,omitempty"`
+}
+
+// A ReservedIPStatus represents the observed state of a ReservedIP.
+type ReservedIPStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ReservedIPObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ReservedIP is a managed resource that represents a DigitalOcean reserved
+// IP, formerly known as a floating IP. Its external-name is the reserved IP
+// address.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.atProvider.ip"
+// +kubebuilder:printcolumn:name="DROPLET",type="integer",JSONPath=".status.atProvider.dropletId"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type ReservedIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservedIPSpec   `json:"spec"`
+	Status ReservedIPStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReservedIPList contains a list of ReservedIPs.
+type ReservedIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReservedIP `json:"items"`
+}
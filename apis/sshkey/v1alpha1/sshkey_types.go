@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SSHKeyParameters define the desired state of a DigitalOcean SSH key. The
+// key is named after the managed resource. Exactly one of publicKey and
+// publicKeySecretRef must be set.
+type SSHKeyParameters struct {
+	// PublicKey: The public key in OpenSSH authorized_keys format, e.g.
+	// "ssh-ed25519 AAAA... user@host".
+	// +optional
+	// +immutable
+	PublicKey *string `json:"publicKey,omitempty"`
+
+	// PublicKeySecretRef references the key of a Secret that holds the
+	// public key in OpenSSH authorized_keys format, e.g. one that is
+	// managed by other tooling.
+	// +optional
+	// +immutable
+	PublicKeySecretRef *xpv1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+}
+
+// An SSHKeyObservation reflects the observed state of a DigitalOcean SSH key.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/SSH-Keys
+type SSHKeyObservation struct {
+	// ID of the SSH key.
+	ID int `json:"id,omitempty"`
+
+	// Name of the SSH key.
+	Name string `json:"name,omitempty"`
+
+	// The MD5 fingerprint of the SSH key. Droplets reference SSH keys by
+	// their ID or fingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// An SSHKeySpec defines the desired state of an SSHKey.
+type SSHKeySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SSHKeyParameters `json:"forProvider"`
+}
+
+// An SSHKeyStatus represents the observed state of an SSHKey.
+type SSHKeyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SSHKeyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An SSHKey is a managed resource that represents a DigitalOcean SSH key. Its
+// external-name is the ID of the SSH key.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="FINGERPRINT",type="string",JSONPath=".status.atProvider.fingerprint"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type SSHKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSHKeySpec   `json:"spec"`
+	Status SSHKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSHKeyList contains a list of SSHKeys.
+type SSHKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHKey `json:"items"`
+}
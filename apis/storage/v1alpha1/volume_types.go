@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Filesystems a Volume can be formatted with when it is created.
+const (
+	FilesystemExt4 = "ext4"
+	FilesystemXFS  = "xfs"
+)
+
+// VolumeParameters define the desired state of a DigitalOcean block storage
+// volume. The volume is named like the managed resource.
+// Most fields map directly to a Volume:
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Block-Storage
+type VolumeParameters struct {
+	// Region: The slug identifier for the region the volume is created in.
+	// A volume can only be attached to Droplets in the same region.
+	// +immutable
+	Region string `json:"region"`
+
+	// SizeGigabytes: The size of the volume in GiB. A volume can be grown
+	// but not shrunk.
+	// +kubebuilder:validation:Minimum=1
+	SizeGigabytes int64 `json:"sizeGigabytes"`
+
+	// Description: An optional free-form text field to describe the volume.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// SnapshotID: The ID of the snapshot the volume is created from.
+	// +optional
+	// +immutable
+	SnapshotID *string `json:"snapshotId,omitempty"`
+
+	// FilesystemType: The filesystem the volume is formatted with when it is
+	// created. The volume is not formatted if it is not set.
+	// +kubebuilder:validation:Enum=ext4;xfs
+	// +optional
+	// +immutable
+	FilesystemType *string `json:"filesystemType,omitempty"`
+
+	// FilesystemLabel: The label of the filesystem the volume is formatted
+	// with. It requires filesystemType, and is limited to 16 characters for
+	// ext4 and 12 characters for xfs.
+	// +kubebuilder:validation:MaxLength=16
+	// +optional
+	// +immutable
+	FilesystemLabel *string `json:"filesystemLabel,omitempty"`
+
+	// ProjectID: The ID of the project the volume is assigned to. It is
+	// assigned to the default project of the account if no project is set,
+	// and moved when the project changes.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1.Project
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	ProjectID *string `json:"projectId,omitempty"`
+
+	// ProjectRef references the Project the volume is assigned to.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects a reference to the Project the volume is
+	// assigned to.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+
+	// Tags: A flat array of tag names as strings to apply to the volume.
+	// Tag names can either be existing or new tags. Tags that are added are
+	// applied to the volume, but tags it carries that are not declared are
+	// only removed if manageTags is true.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.Tag
+	// +crossplane:generate:reference:extractor=github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1.TagName()
+	// +crossplane:generate:reference:refFieldName=TagRefs
+	// +crossplane:generate:reference:selectorFieldName=TagSelector
+	Tags []string `json:"tags,omitempty"`
+
+	// TagRefs reference the Tags to apply to the volume. The names of the
+	// referenced Tags are resolved into tags while tags is empty.
+	// +optional
+	TagRefs []xpv1.Reference `json:"tagRefs,omitempty"`
+
+	// TagSelector selects references to the Tags to apply to the volume.
+	// +optional
+	TagSelector *xpv1.Selector `json:"tagSelector,omitempty"`
+
+	// ManageTags: A boolean indicating whether the tags of the volume are
+	// managed exclusively by this Volume. If true, tags the volume carries
+	// that are not declared are removed from it, e.g. when they are removed
+	// from tags. Otherwise tags applied by something else, such as a cost
+	// allocation tool, are left in place.
+	// +optional
+	ManageTags *bool `json:"manageTags,omitempty"`
+}
+
+// A VolumeObservation reflects the observed state of a DigitalOcean block
+// storage volume.
+type VolumeObservation struct {
+	// ID of the volume.
+	ID string `json:"id,omitempty"`
+
+	// Name of the volume.
+	Name string `json:"name,omitempty"`
+
+	// Region the volume is in.
+	Region string `json:"region,omitempty"`
+
+	// SizeGigabytes is the size of the volume in GiB.
+	SizeGigabytes int64 `json:"sizeGigabytes,omitempty"`
+
+	// DropletIDs are the IDs of the Droplets the volume is attached to.
+	DropletIDs []int `json:"dropletIds,omitempty"`
+
+	// FilesystemType is the filesystem the volume is formatted with.
+	FilesystemType string `json:"filesystemType,omitempty"`
+
+	// FilesystemLabel is the label of the filesystem of the volume.
+	FilesystemLabel string `json:"filesystemLabel,omitempty"`
+
+	// Tags are the tags the volume carries.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// A VolumeSpec defines the desired state of a Volume.
+type VolumeSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       VolumeParameters `json:"forProvider"`
+}
+
+// A VolumeStatus represents the observed state of a Volume.
+type VolumeStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          VolumeObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Volume is a managed resource that represents a DigitalOcean block storage
+// volume. Its external-name is the ID of the volume. Set it to the name of an
+// existing volume in the region of the Volume to import that volume.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".status.atProvider.region"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.sizeGigabytes"
+// +kubebuilder:printcolumn:name="FILESYSTEM",type="string",JSONPath=".status.atProvider.filesystemType",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Volume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeSpec   `json:"spec"`
+	Status VolumeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeList contains a list of Volumes.
+type VolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Volume `json:"items"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TagParameters define the desired state of a DigitalOcean tag. A tag has no
+// settings of its own: its name is the external-name of the Tag, which
+// defaults to the name of the managed resource. Set the external-name to use
+// a name that is not a valid Kubernetes name, e.g. "team:platform".
+type TagParameters struct {
+	// AdoptExisting: A boolean indicating whether a tag with the same name
+	// that already exists, e.g. because it was created outside of
+	// Crossplane, is adopted rather than failing to create the Tag. The
+	// adopted tag is deleted with the Tag unless its deletion policy is
+	// Orphan. Defaults to false.
+	// +optional
+	AdoptExisting *bool `json:"adoptExisting,omitempty"`
+}
+
+// A TagObservation reflects the observed state of a DigitalOcean tag.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/Tags
+type TagObservation struct {
+	// The name of the tag.
+	Name string `json:"name,omitempty"`
+
+	// The number of resources the tag is applied to.
+	ResourceCount int `json:"resourceCount,omitempty"`
+
+	// The URI of the resource the tag was most recently applied to.
+	LastTaggedURI string `json:"lastTaggedUri,omitempty"`
+}
+
+// A TagSpec defines the desired state of a Tag.
+type TagSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TagParameters `json:"forProvider,omitempty"`
+}
+
+// A TagStatus represents the observed state of a Tag.
+type TagStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TagObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Tag is a managed resource that represents a DigitalOcean tag. Resources
+// that reference a Tag are only tagged once the tag exists.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TAG",type="string",JSONPath=".status.atProvider.name"
+// +kubebuilder:printcolumn:name="RESOURCES",type="integer",JSONPath=".status.atProvider.resourceCount",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type Tag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TagSpec   `json:"spec"`
+	Status TagStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TagList contains a list of Tags.
+type TagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tag `json:"items"`
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// An Action is an asynchronous action DigitalOcean performs on a resource,
+// e.g. a resize or a rebuild.
+type Action struct {
+	// ID of the action, as shown in the DigitalOcean console.
+	ID int `json:"id"`
+
+	// Type of the action, e.g. "resize" or "rebuild".
+	Type string `json:"type"`
+
+	// Status of the action, e.g. "in-progress".
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// StartedAt is the time the action started, in RFC3339 text format.
+	// +optional
+	StartedAt string `json:"startedAt,omitempty"`
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -27,6 +28,17 @@ type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 
+	// TeamID is the UUID of the DigitalOcean team the credentials must belong
+	// to. Managed resources using this ProviderConfig fail to connect once
+	// the credentials were found to belong to another team.
+	// +optional
+	TeamID string `json:"teamId,omitempty"`
+
+	// Endpoint is the base URL the DigitalOcean API is reached at, e.g. an
+	// egress proxy in front of it. Defaults to https://api.digitalocean.com/.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
 	// Add any other fields here for information that is specific to configuring
 	// a provider, such as authentication details.
 }
@@ -43,6 +55,78 @@ type ProviderCredentials struct {
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// LastCheckTime is the last time the credentials of this ProviderConfig
+	// were checked against the DigitalOcean API.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// LastCheckError is the error returned by the last check of the
+	// credentials of this ProviderConfig. It is empty if the check succeeded.
+	// +optional
+	LastCheckError string `json:"lastCheckError,omitempty"`
+
+	// Account is the email address of the DigitalOcean account the
+	// credentials of this ProviderConfig belong to.
+	// +optional
+	Account string `json:"account,omitempty"`
+
+	// Team is the name of the DigitalOcean team the credentials of this
+	// ProviderConfig belong to.
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// TeamID is the UUID of the DigitalOcean team the credentials of this
+	// ProviderConfig belong to.
+	// +optional
+	TeamID string `json:"teamId,omitempty"`
+}
+
+// TypeWriteAccess indicates whether the credentials of a ProviderConfig can
+// create and modify resources.
+const TypeWriteAccess xpv1.ConditionType = "WriteAccess"
+
+// Reasons a ProviderConfig does or does not have write access.
+const (
+	ReasonWriteAccessAvailable xpv1.ConditionReason = "WriteAccessAvailable"
+	ReasonReadOnly             xpv1.ConditionReason = "ReadOnly"
+	ReasonWriteAccessUnknown   xpv1.ConditionReason = "WriteAccessUnknown"
+)
+
+// WriteAccessAvailable returns a condition that indicates the credentials of
+// a ProviderConfig can create and modify resources.
+func WriteAccessAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWriteAccess,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWriteAccessAvailable,
+	}
+}
+
+// ReadOnly returns a condition that indicates the credentials of a
+// ProviderConfig can only read resources, for the supplied reason.
+func ReadOnly(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWriteAccess,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReadOnly,
+		Message:            msg,
+	}
+}
+
+// WriteAccessUnknown returns a condition that indicates whether the
+// credentials of a ProviderConfig can create and modify resources could not
+// be determined, for the supplied reason.
+func WriteAccessUnknown(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWriteAccess,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWriteAccessUnknown,
+		Message:            msg,
+	}
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A ConnectionRotationPolicy controls when the connection details of a managed
+// resource are written to its connection secret. Every kind that publishes
+// connection details supports it, i.e. Droplets, Database Clusters and their
+// connection pools, Kubernetes Clusters and Function namespaces. Container
+// Registries publish no connection details.
+// +kubebuilder:validation:Enum=Always;OnChange
+type ConnectionRotationPolicy string
+
+// Connection rotation policies.
+const (
+	// RotationPolicyAlways writes the connection details every time the
+	// managed resource is observed.
+	RotationPolicyAlways ConnectionRotationPolicy = "Always"
+
+	// RotationPolicyOnChange only writes the connection details if they
+	// differ from the ones already in the connection secret, so that
+	// workloads watching the secret are not redeployed needlessly.
+	RotationPolicyOnChange ConnectionRotationPolicy = "OnChange"
+)
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// VPCParameters define the desired state of a DigitalOcean VPC. A VPC is
+// observe-only: the VPC with the UUID in the external-name annotation is
+// observed. A VPC whose name is in the annotation instead, e.g. the default
+// VPC of a region, is looked up in its region and the annotation is set to
+// its UUID.
+type VPCParameters struct {
+	// Region: The slug of the region of the VPC. It is required to look up
+	// a VPC by name, since VPCs in different regions may share a name.
+	// +optional
+	// +immutable
+	Region *string `json:"region,omitempty"`
+}
+
+// A VPCMember is a resource inside a VPC.
+type VPCMember struct {
+	// URN of the resource, e.g. "do:droplet:13457723".
+	URN string `json:"urn"`
+
+	// Type of the resource, e.g. "droplet", "kubernetes" or "loadbalancer".
+	Type string `json:"type,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name,omitempty"`
+
+	// CreatedAt is the time the resource was created, in RFC3339 text
+	// format.
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// A VPCNATGateway is a NAT gateway that routes the egress traffic of a VPC.
+type VPCNATGateway struct {
+	// ID of the NAT gateway.
+	ID string `json:"id"`
+
+	// Name of the NAT gateway.
+	Name string `json:"name,omitempty"`
+
+	// State of the NAT gateway, e.g. "ACTIVE".
+	State string `json:"state,omitempty"`
+
+	// GatewayIP is the private address of the NAT gateway inside the VPC.
+	GatewayIP string `json:"gatewayIp,omitempty"`
+
+	// DefaultGateway is true if the NAT gateway is the default route of the
+	// VPC.
+	DefaultGateway bool `json:"defaultGateway,omitempty"`
+
+	// EgressIPs are the public addresses the egress traffic of the VPC
+	// leaves the NAT gateway from.
+	EgressIPs []string `json:"egressIps,omitempty"`
+}
+
+// A VPCObservation reflects the observed state of a DigitalOcean VPC.
+// https://docs.digitalocean.com/reference/api/api-reference/#tag/VPCs
+type VPCObservation struct {
+	// The unique universal identifier of the VPC.
+	ID string `json:"id,omitempty"`
+
+	// The uniform resource name of the VPC.
+	URN string `json:"urn,omitempty"`
+
+	// The name of the VPC.
+	Name string `json:"name,omitempty"`
+
+	// The description of the VPC.
+	Description string `json:"description,omitempty"`
+
+	// The unique slug identifier of the region the VPC is in.
+	Region string `json:"region,omitempty"`
+
+	// The range of private IP addresses of the VPC in CIDR notation.
+	IPRange string `json:"ipRange,omitempty"`
+
+	// Default is true if the VPC is the default VPC of its region.
+	Default bool `json:"default,omitempty"`
+
+	// CreationTimestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// Members are the resources inside the VPC.
+	Members []VPCMember `json:"members,omitempty"`
+
+	// NATGateways are the NAT gateways attached to the VPC. They are empty
+	// if NAT gateways are not available to the account.
+	NATGateways []VPCNATGateway `json:"natGateways,omitempty"`
+}
+
+// A VPCSpec defines the desired state of a VPC.
+type VPCSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       VPCParameters `json:"forProvider,omitempty"`
+}
+
+// A VPCStatus represents the observed state of a VPC.
+type VPCStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          VPCObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VPC is an observe-only managed resource that represents an existing
+// DigitalOcean VPC and the resources inside it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".status.atProvider.region",priority=1
+// +kubebuilder:printcolumn:name="IP-RANGE",type="string",JSONPath=".status.atProvider.ipRange",priority=1
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,do}
+type VPC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VPCSpec   `json:"spec"`
+	Status VPCStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VPCList contains a list of VPCs.
+type VPCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPC `json:"items"`
+}
@@ -17,25 +17,47 @@ limitations under the License.
 package main
 
 import (
+	"crypto/tls"
 	"os"
 	"path/filepath"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/config"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
 func main() {
 	var (
 		app            = kingpin.New(filepath.Base(os.Args[0]), "DigitalOcean support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+
+		apiTimeout             = app.Flag("api-timeout", "Timeout of requests to the DigitalOcean API. Zero means no timeout.").Default("0s").Duration()
+		apiMaxIdleConns        = app.Flag("api-max-idle-conns", "Maximum number of idle connections to the DigitalOcean API. Zero means no limit.").Default("100").Int()
+		apiMaxIdleConnsPerHost = app.Flag("api-max-idle-conns-per-host", "Maximum number of idle connections per DigitalOcean API host.").Default("10").Int()
+		apiTLSHandshake        = app.Flag("api-tls-handshake-timeout", "Timeout of TLS handshakes with the DigitalOcean API.").Default("10s").Duration()
+		apiTLSMinVersion       = app.Flag("api-tls-min-version", "Minimum TLS version used to talk to the DigitalOcean API.").Default("1.2").Enum("1.2", "1.3")
+		apiDebug               = app.Flag("debug-api", "Log every request to the DigitalOcean API at debug level. Requires --debug.").Bool()
+		apiCheckInterval       = app.Flag("api-check-interval", "Interval at which the credentials of every ProviderConfig are checked against the DigitalOcean API.").Default("5m").Duration()
+		deleteTimeout          = app.Flag("delete-timeout", "Time after which managed resources whose external resource cannot be deleted are marked as DeleteTimedOut. Zero means no timeout.").Default("0s").Duration()
+		healthProbeAddress     = app.Flag("health-probe-bind-address", "The address the health and readiness probes bind to.").Default(":8081").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -48,7 +70,7 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "api-timeout", apiTimeout.String())
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
@@ -57,10 +79,30 @@ func main() {
 		LeaderElection:   *leaderElection,
 		LeaderElectionID: "crossplane-leader-election-provider-digitalocean",
 		SyncPeriod:       syncPeriod,
+
+		HealthProbeBindAddress: *healthProbeAddress,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
+	o := do.ClientOptions{
+		Timeout:             *apiTimeout,
+		MaxIdleConns:        *apiMaxIdleConns,
+		MaxIdleConnsPerHost: *apiMaxIdleConnsPerHost,
+		TLSHandshakeTimeout: *apiTLSHandshake,
+		TLSMinVersion:       tlsVersions[*apiTLSMinVersion],
+	}
+	if *apiDebug {
+		o.DebugLogger = log.WithValues("component", "api")
+	}
+	cc := do.NewClientCache(o)
+
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add DigitalOcean APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup DigitalOcean controllers")
+	kingpin.FatalIfError(metrics.Register(ctrlmetrics.Registry), "Cannot register metrics")
+	kingpin.FatalIfError(controller.Setup(mgr, log, cc, deletion.Options{Timeout: *deleteTimeout}), "Cannot setup DigitalOcean controllers")
+
+	hc := config.NewHealthChecker(mgr.GetClient(), cc, *apiCheckInterval, log.WithValues("runnable", "health-checker"))
+	kingpin.FatalIfError(mgr.Add(hc), "Cannot add ProviderConfig health checker")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add health check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("api", hc.Check), "Cannot add readiness check")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
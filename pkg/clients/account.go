@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+)
+
+const accountPath = "v2/account"
+
+// AccountStatusActive is the status of an account that is allowed to create
+// and modify resources. DigitalOcean reports 'warning' and 'locked' for
+// accounts that may only read them.
+const AccountStatusActive = "active"
+
+// Account is a DigitalOcean account. It extends godo.Account with the team
+// the token belongs to, which the vendored godo does not expose yet.
+type Account struct {
+	godo.Account
+	Team *Team `json:"team,omitempty"`
+}
+
+// Team is the DigitalOcean team an account token belongs to.
+type Team struct {
+	UUID string `json:"uuid,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type accountRoot struct {
+	Account *Account `json:"account"`
+}
+
+// GetAccount gets the account the supplied client authenticates as.
+func GetAccount(ctx context.Context, c *godo.Client) (*Account, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, accountPath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &accountRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Account, resp, nil
+}
+
+// ProbeWriteAccess returns false if the token of the supplied client may only
+// read resources. The API does not report the scopes of a token, so it probes
+// them by requesting to create a tag without a name. The API forbids the
+// request for a read-only token and otherwise rejects it as invalid, so no tag
+// is ever created.
+func ProbeWriteAccess(ctx context.Context, c *godo.Client) (bool, error) {
+	_, resp, err := c.Tags.Create(ctx, &godo.TagCreateRequest{})
+	if err == nil {
+		return true, nil
+	}
+	if resp == nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		return false, nil
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+const errGetAction = "cannot get action"
+
+// actionErrored is the status of an action that failed. godo only defines the
+// in-progress and completed statuses.
+const actionErrored = "errored"
+
+// Polling intervals of WaitForAction. The interval doubles after every poll
+// until it reaches the maximum.
+var (
+	actionPollInterval    = 1 * time.Second
+	actionPollMaxInterval = 16 * time.Second
+)
+
+// An ActionError is returned by WaitForAction when an action errored.
+type ActionError struct {
+	ID     int
+	Type   string
+	Region string
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("%s action %d in region %s errored", e.Type, e.ID, e.Region)
+}
+
+// IsActionError returns true if the supplied error is an ActionError.
+func IsActionError(err error) bool {
+	var e *ActionError
+	return errors.As(err, &e)
+}
+
+// WaitForAction polls the action with the supplied ID until it is completed
+// or errored, backing off between polls. It returns an ActionError if the
+// action errored, and the context's error if it is done first.
+func WaitForAction(ctx context.Context, s godo.ActionsService, id int) (*godo.Action, error) {
+	interval := actionPollInterval
+	for {
+		a, _, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetAction)
+		}
+		switch a.Status {
+		case godo.ActionCompleted:
+			return a, nil
+		case actionErrored:
+			return a, &ActionError{ID: a.ID, Type: a.Type, Region: a.RegionSlug}
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return a, ctx.Err()
+		case <-t.C:
+		}
+		if interval *= 2; interval > actionPollMaxInterval {
+			interval = actionPollMaxInterval
+		}
+	}
+}
+
+// TrackAction records the supplied action in the supplied status field, so
+// that it is reported until ObserveAction or WaitForTrackedAction find it is
+// no longer in progress.
+func TrackAction(field **v1alpha1.Action, a *godo.Action) {
+	if a == nil {
+		return
+	}
+	*field = &v1alpha1.Action{ID: a.ID, Type: a.Type, Status: a.Status}
+	if a.StartedAt != nil {
+		(*field).StartedAt = a.StartedAt.Time.Format(time.RFC3339)
+	}
+}
+
+// ObserveAction gets the action recorded in the supplied status field once,
+// without waiting for it. The field is updated while the action is in
+// progress and cleared once it is completed or errored. It returns an
+// ActionError if the action errored.
+func ObserveAction(ctx context.Context, s godo.ActionsService, field **v1alpha1.Action) error {
+	if *field == nil {
+		return nil
+	}
+	a, _, err := s.Get(ctx, (*field).ID)
+	if err != nil {
+		return errors.Wrap(err, errGetAction)
+	}
+	switch a.Status {
+	case godo.ActionCompleted:
+		*field = nil
+	case actionErrored:
+		*field = nil
+		return &ActionError{ID: a.ID, Type: a.Type, Region: a.RegionSlug}
+	default:
+		TrackAction(field, a)
+	}
+	return nil
+}
+
+// WaitForTrackedAction waits for the action recorded in the supplied status
+// field like WaitForAction, and clears the field once the action is completed
+// or errored. The field is left as is if the context is done first, so that
+// the action can be observed later.
+func WaitForTrackedAction(ctx context.Context, s godo.ActionsService, field **v1alpha1.Action) error {
+	if *field == nil {
+		return nil
+	}
+	_, err := WaitForAction(ctx, s, (*field).ID)
+	if err == nil || IsActionError(err) {
+		*field = nil
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+// fakeActions returns the supplied statuses in order, repeating the last one.
+type fakeActions struct {
+	godo.ActionsService
+	statuses []string
+	calls    int
+}
+
+func (f *fakeActions) Get(_ context.Context, id int) (*godo.Action, *godo.Response, error) {
+	i := f.calls
+	if i >= len(f.statuses) {
+		i = len(f.statuses) - 1
+	}
+	f.calls++
+	return &godo.Action{ID: id, Type: "resize", RegionSlug: "nyc1", Status: f.statuses[i]}, nil, nil
+}
+
+func TestWaitForAction(t *testing.T) {
+	actionPollInterval, actionPollMaxInterval = time.Millisecond, 2*time.Millisecond
+
+	type want struct {
+		calls int
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		statuses []string
+		timeout  time.Duration
+		want     want
+	}{
+		"Completed": {
+			reason:   "An in-progress action should be polled until it is completed.",
+			statuses: []string{godo.ActionInProgress, godo.ActionInProgress, godo.ActionCompleted},
+			want:     want{calls: 3},
+		},
+		"Errored": {
+			reason:   "An errored action should be returned as an ActionError.",
+			statuses: []string{godo.ActionInProgress, actionErrored},
+			want:     want{calls: 2, err: &ActionError{ID: 42, Type: "resize", Region: "nyc1"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &fakeActions{statuses: tc.statuses}
+			_, err := WaitForAction(context.Background(), s, 42)
+			if diff := cmp.Diff(tc.want, want{calls: s.calls, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWaitForAction(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWaitForActionDeadline(t *testing.T) {
+	actionPollInterval, actionPollMaxInterval = time.Millisecond, 2*time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := WaitForAction(ctx, &fakeActions{statuses: []string{godo.ActionInProgress}}, 42)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForAction(...): want context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestObserveAction(t *testing.T) {
+	s := &fakeActions{statuses: []string{godo.ActionInProgress, godo.ActionInProgress, godo.ActionCompleted}}
+
+	var field *v1alpha1.Action
+	TrackAction(&field, &godo.Action{ID: 42, Type: "resize", Status: godo.ActionInProgress})
+	want := &v1alpha1.Action{ID: 42, Type: "resize", Status: godo.ActionInProgress}
+	if diff := cmp.Diff(want, field); diff != "" {
+		t.Errorf("TrackAction(...): -want, +got:\n%s", diff)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := ObserveAction(context.Background(), s, &field); err != nil {
+			t.Fatalf("ObserveAction(...): %v", err)
+		}
+		if diff := cmp.Diff(want, field); diff != "" {
+			t.Errorf("ObserveAction(...): want an action in progress to stay recorded: -want, +got:\n%s", diff)
+		}
+	}
+
+	if err := ObserveAction(context.Background(), s, &field); err != nil {
+		t.Fatalf("ObserveAction(...): %v", err)
+	}
+	if field != nil {
+		t.Errorf("ObserveAction(...): want a completed action to be cleared, got %+v", field)
+	}
+}
+
+func TestWaitForTrackedAction(t *testing.T) {
+	actionPollInterval, actionPollMaxInterval = time.Millisecond, 2*time.Millisecond
+
+	type want struct {
+		field *v1alpha1.Action
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		statuses []string
+		timeout  time.Duration
+		want     want
+	}{
+		"Completed": {
+			reason:   "A completed action should be cleared.",
+			statuses: []string{godo.ActionInProgress, godo.ActionCompleted},
+		},
+		"Errored": {
+			reason:   "An errored action should be cleared and returned as an ActionError.",
+			statuses: []string{godo.ActionInProgress, actionErrored},
+			want:     want{err: &ActionError{ID: 42, Type: "resize", Region: "nyc1"}},
+		},
+		"Deadline": {
+			reason:   "An action that is still in progress when the context is done should stay recorded.",
+			statuses: []string{godo.ActionInProgress},
+			timeout:  20 * time.Millisecond,
+			want: want{
+				field: &v1alpha1.Action{ID: 42, Type: "resize", Status: godo.ActionInProgress},
+				err:   context.DeadlineExceeded,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			var field *v1alpha1.Action
+			TrackAction(&field, &godo.Action{ID: 42, Type: "resize", Status: godo.ActionInProgress})
+			err := WaitForTrackedAction(ctx, &fakeActions{statuses: tc.statuses}, &field)
+			if diff := cmp.Diff(tc.want, want{field: field, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWaitForTrackedAction(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errAdoptFind      = "cannot find existing resource to adopt"
+	errAdoptAmbiguous = "cannot adopt existing resource: %d resources are named %q"
+	errAdoptUpdate    = "cannot persist the external-name of the adopted resource"
+)
+
+// AnnotationAdoptExisting enables adoption of an existing external resource
+// that was not created by Crossplane when it is set to "true". The external
+// resource named like the external-name of the managed resource is adopted
+// instead of creating a duplicate, e.g. when migrating resources created by
+// Terraform or by hand.
+const AnnotationAdoptExisting = "crossplane.io/adopt-existing"
+
+// ShouldAdopt returns true if adoption of an existing external resource is
+// enabled for the supplied object by AnnotationAdoptExisting.
+func ShouldAdopt(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationAdoptExisting] == "true"
+}
+
+// A FindByName returns the IDs of the external resources with the supplied
+// name.
+type FindByName func(ctx context.Context, name string) ([]string, error)
+
+// AdoptExisting adopts the external resource named like the external-name of
+// the supplied managed resource if adoption is enabled for it. The
+// external-name is set to the ID of the adopted resource and persisted, so
+// that the adopted resource is observed and late initialized like any other.
+// It returns the ID of the adopted resource, or an empty string if none was
+// adopted. A name shared by several external resources is ambiguous and is
+// never adopted.
+func AdoptExisting(ctx context.Context, kube client.Client, mg resource.Managed, find FindByName) (string, error) {
+	if !ShouldAdopt(mg) {
+		return "", nil
+	}
+	return AdoptByName(ctx, kube, mg, find)
+}
+
+// AdoptByName adopts the external resource named like the external-name of
+// the supplied managed resource like AdoptExisting, whether or not adoption is
+// enabled for it. It suits observe-only resources, which cannot duplicate the
+// resource they would otherwise adopt.
+func AdoptByName(ctx context.Context, kube client.Client, mg resource.Managed, find FindByName) (string, error) {
+	name := meta.GetExternalName(mg)
+	if name == "" {
+		return "", nil
+	}
+	ids, err := find(ctx, name)
+	if err != nil {
+		return "", errors.Wrap(err, errAdoptFind)
+	}
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", errors.Errorf(errAdoptAmbiguous, len(ids), name)
+	}
+	meta.SetExternalName(mg, ids[0])
+	if err := kube.Update(ctx, mg); err != nil {
+		return "", errors.Wrap(err, errAdoptUpdate)
+	}
+	return ids[0], nil
+}
+
+// IsUUID returns true if the supplied string has the form of a UUID, e.g.
+// "5a4981aa-9653-4bd1-bef5-d6bff52042e4".
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app contains helpers to manage DigitalOcean App Platform apps.
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/app/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	appsPath = "v2/apps"
+
+	// MaxDeployments is the number of recent deployments that are reported
+	// in the status of an App.
+	MaxDeployments = 5
+
+	errNoSpec     = "spec.forProvider.spec is required"
+	errDecodeSpec = "cannot decode spec.forProvider.spec"
+)
+
+// AnnotationDeploymentGeneration is set on an App managed resource to the
+// deployment generation that was last deployed, so that a deployment is only
+// triggered once per generation.
+const AnnotationDeploymentGeneration = "app.do.crossplane.io/deployment-generation"
+
+type rollbackRequest struct {
+	DeploymentID string `json:"deployment_id"`
+}
+
+// Rollback rolls the app with the supplied ID back to the deployment with the
+// supplied ID. The vendored godo cannot roll back apps yet.
+func Rollback(ctx context.Context, c *godo.Client, id, deploymentID string) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, appsPath+"/"+id+"/rollback", &rollbackRequest{DeploymentID: deploymentID})
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// RevertRollback reverts the rollback of the app with the supplied ID.
+func RevertRollback(ctx context.Context, c *godo.Client, id string) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, appsPath+"/"+id+"/rollback/revert", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// GenerateAppSpec returns the app spec of the supplied parameters. The app is
+// named after the supplied name unless the spec names it.
+func GenerateAppSpec(name string, p v1alpha1.AppParameters) (*godo.AppSpec, error) {
+	if len(p.Spec.Raw) == 0 {
+		return nil, errors.New(errNoSpec)
+	}
+	spec := &godo.AppSpec{}
+	if err := json.Unmarshal(p.Spec.Raw, spec); err != nil {
+		return nil, errors.Wrap(err, errDecodeSpec)
+	}
+	if spec.Name == "" {
+		spec.Name = name
+	}
+	return spec, nil
+}
+
+// GenerateObservation returns the observation of the supplied app and its
+// supplied deployments, of which the most recent are reported.
+func GenerateObservation(app godo.App, deployments []*godo.Deployment) v1alpha1.AppObservation {
+	o := v1alpha1.AppObservation{
+		ID:             app.ID,
+		DefaultIngress: app.DefaultIngress,
+		LiveURL:        app.LiveURL,
+	}
+	if app.ActiveDeployment != nil {
+		o.ActiveDeploymentID = app.ActiveDeployment.ID
+	}
+	if app.InProgressDeployment != nil {
+		o.InProgressDeploymentID = app.InProgressDeployment.ID
+	}
+	if app.PinnedDeployment != nil {
+		o.PinnedDeploymentID = app.PinnedDeployment.ID
+	}
+	for _, d := range deployments {
+		if len(o.Deployments) == MaxDeployments {
+			break
+		}
+		if d == nil {
+			continue
+		}
+		o.Deployments = append(o.Deployments, v1alpha1.AppDeployment{
+			ID:        d.ID,
+			Phase:     string(d.Phase),
+			Cause:     d.Cause,
+			CreatedAt: do.Timestamp(d.CreatedAt),
+		})
+	}
+	return o
+}
+
+// SpecUpToDate returns true if every field the supplied parameters set in
+// their app spec has the same value in the supplied observed app spec. Fields
+// that are not set are left to App Platform, whose defaults are not drift.
+func SpecUpToDate(p v1alpha1.AppParameters, observed *godo.AppSpec) (bool, error) {
+	var desired interface{}
+	if err := json.Unmarshal(p.Spec.Raw, &desired); err != nil {
+		return false, errors.Wrap(err, errDecodeSpec)
+	}
+	raw, err := json.Marshal(observed)
+	if err != nil {
+		return false, err
+	}
+	var actual interface{}
+	if err := json.Unmarshal(raw, &actual); err != nil {
+		return false, err
+	}
+	return contains(actual, desired), nil
+}
+
+// contains returns true if the supplied decoded JSON value a contains all of
+// the supplied decoded JSON value b. Objects contain the fields of b, arrays
+// contain each element of b at the same index.
+func contains(a, b interface{}) bool {
+	switch b := b.(type) {
+	case map[string]interface{}:
+		a, ok := a.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range b {
+			if !contains(a[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := a.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range b {
+			if !contains(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// NeedsDeployment returns true if the deployment generation of the supplied
+// parameters was increased past the supplied generation that was last
+// deployed.
+func NeedsDeployment(p v1alpha1.AppParameters, deployed int64) bool {
+	return do.Int64Value(p.DeploymentGeneration) > deployed
+}
+
+// NeedsRollback returns true if the app of the supplied observation is not
+// pinned to the deployment the supplied parameters roll back to, including
+// when it should no longer be pinned.
+func NeedsRollback(p v1alpha1.AppParameters, o v1alpha1.AppObservation) bool {
+	return do.StringValue(p.RollbackToDeploymentID) != o.PinnedDeploymentID
+}
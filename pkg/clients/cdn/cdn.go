@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cdn contains helpers to manage DigitalOcean CDN endpoints.
+package cdn
+
+import (
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/cdn/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// DefaultTTL is the time in seconds a CDN endpoint caches content for unless
+// it declares another.
+const DefaultTTL = 3600
+
+// GenerateCDN returns a request that creates a CDN endpoint with the supplied
+// parameters.
+func GenerateCDN(p v1alpha1.CDNParameters) *godo.CDNCreateRequest {
+	ttl := DefaultTTL
+	if p.TTL != nil {
+		ttl = *p.TTL
+	}
+	return &godo.CDNCreateRequest{
+		Origin:        p.Origin,
+		TTL:           uint32(ttl),
+		CustomDomain:  do.StringValue(p.CustomDomain),
+		CertificateID: do.StringValue(p.CertificateID),
+	}
+}
+
+// GenerateObservation returns the observation of the supplied CDN endpoint.
+func GenerateObservation(observed godo.CDN) v1alpha1.CDNObservation {
+	return v1alpha1.CDNObservation{
+		ID:                observed.ID,
+		Endpoint:          observed.Endpoint,
+		CertificateID:     observed.CertificateID,
+		CreationTimestamp: do.Timestamp(observed.CreatedAt),
+	}
+}
+
+// LateInitialize fills the empty fields of the supplied parameters with the
+// values of the supplied CDN endpoint.
+func LateInitialize(p *v1alpha1.CDNParameters, observed godo.CDN) {
+	p.TTL = do.LateInitializeInt(p.TTL, int(observed.TTL))
+}
+
+// TTLUpToDate returns true if the supplied CDN endpoint caches content for
+// the TTL of the supplied parameters, or if they declare none.
+func TTLUpToDate(p v1alpha1.CDNParameters, observed godo.CDN) bool {
+	return p.TTL == nil || uint32(*p.TTL) == observed.TTL
+}
+
+// CustomDomainUpToDate returns true if the supplied CDN endpoint serves the
+// custom domain of the supplied parameters with their certificate.
+func CustomDomainUpToDate(p v1alpha1.CDNParameters, observed godo.CDN) bool {
+	return do.StringValue(p.CustomDomain) == observed.CustomDomain && do.StringValue(p.CertificateID) == observed.CertificateID
+}
+
+// ImmutableFields returns the immutable fields of the supplied parameters and
+// whether they match the supplied CDN endpoint.
+func ImmutableFields(p v1alpha1.CDNParameters, observed godo.CDN) do.ImmutableFields {
+	return do.ImmutableFields{"origin": p.Origin == observed.Origin}
+}
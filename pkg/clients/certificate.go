@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeCertificateRotated indicates whether a resource was last updated to use
+// the new ID of a referenced Certificate, e.g. because Let's Encrypt renewed
+// it.
+const TypeCertificateRotated xpv1.ConditionType = "CertificateRotated"
+
+// ReasonCertificateRotated is the reason a resource switched to the new ID of
+// a referenced Certificate.
+const ReasonCertificateRotated xpv1.ConditionReason = "CertificateRotated"
+
+// CertificateRotation describes the rotation of the supplied target, e.g. a
+// forwarding rule, from one ID of the named Certificate to another.
+func CertificateRotation(target, certificate, from, to string) string {
+	return fmt.Sprintf("%s: Certificate %q rotated from %s to %s", target, certificate, from, to)
+}
+
+// CertificateRotatedCondition returns a condition that notes the supplied
+// certificate rotations were applied. It is kept until the next rotation, so
+// that the last one stays visible.
+func CertificateRotatedCondition(rotations []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCertificateRotated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCertificateRotated,
+		Message:            strings.Join(rotations, ", "),
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"golang.org/x/oauth2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	headerRequestID          = "X-Request-Id"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+)
+
+// ClientOptions configure the HTTP client used to talk to the DigitalOcean
+// API.
+type ClientOptions struct {
+	// Timeout of a single request to the DigitalOcean API. Zero means no
+	// timeout.
+	Timeout time.Duration
+
+	// MaxIdleConns is the maximum number of idle connections kept open
+	// across all hosts. Zero means no limit.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept
+	// open per host. Zero means http.DefaultMaxIdleConnsPerHost.
+	MaxIdleConnsPerHost int
+
+	// TLSHandshakeTimeout is the maximum amount of time to wait for a TLS
+	// handshake. Zero means no timeout.
+	TLSHandshakeTimeout time.Duration
+
+	// TLSMinVersion is the minimum TLS version accepted, e.g. tls.VersionTLS12.
+	// Zero means the crypto/tls default.
+	TLSMinVersion uint16
+
+	// DebugLogger logs every request to the DigitalOcean API at debug level
+	// if it is not nil.
+	DebugLogger logging.Logger
+}
+
+// NewTransport returns an HTTP transport configured by the supplied options.
+func NewTransport(o ClientOptions) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          o.MaxIdleConns,
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   o.TLSHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{MinVersion: o.TLSMinVersion}, // nolint:gosec
+	}
+}
+
+// A ClientCache hands out godo clients that share a single tuned transport.
+// Clients are cached per token, team and endpoint so that every managed
+// resource authenticating with the same credentials reuses the same client,
+// while ProviderConfigs scoped to different teams or reaching the API through
+// different endpoints never share one. The client of a ProviderConfig whose
+// credentials changed, e.g. because the token in its Secret was rotated, is
+// evicted once a client for the new credentials is requested.
+type ClientCache struct {
+	options   ClientOptions
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	clients map[cacheKey]*godo.Client
+
+	// used maps the name of each ProviderConfig to the key of the client it
+	// last requested.
+	used map[string]cacheKey
+}
+
+type cacheKey struct {
+	token    string
+	team     string
+	endpoint string
+}
+
+// NewClientCache returns a ClientCache whose clients are configured by the
+// supplied options.
+func NewClientCache(o ClientOptions) *ClientCache {
+	var t http.RoundTripper = NewTransport(o)
+	if o.DebugLogger != nil {
+		t = &debugTransport{base: t, log: o.DebugLogger}
+	}
+	return &ClientCache{
+		options:   o,
+		transport: t,
+		clients:   map[cacheKey]*godo.Client{},
+		used:      map[string]cacheKey{},
+	}
+}
+
+// GetFor returns the godo client for the supplied authentication information,
+// creating it if necessary.
+func (c *ClientCache) GetFor(a AuthInfo) *godo.Client {
+	token := strings.TrimSpace(a.Token)
+	key := cacheKey{token: token, team: a.Team}
+	if a.Endpoint != nil {
+		key.endpoint = a.Endpoint.String()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if a.ProviderConfig != "" {
+		previous, ok := c.used[a.ProviderConfig]
+		c.used[a.ProviderConfig] = key
+		if ok && previous != key {
+			c.evict(previous)
+		}
+	}
+
+	if client, ok := c.clients[key]; ok {
+		return client
+	}
+	client := godo.NewClient(&http.Client{
+		Timeout: c.options.Timeout,
+		Transport: &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			Base:   c.transport,
+		},
+	})
+	if a.Endpoint != nil {
+		u := *a.Endpoint
+		client.BaseURL = &u
+	}
+	c.clients[key] = client
+	return client
+}
+
+// evict removes the client with the supplied key from the cache, unless
+// another ProviderConfig still uses it. It must be called with the lock held.
+func (c *ClientCache) evict(key cacheKey) {
+	for _, k := range c.used {
+		if k == key {
+			return
+		}
+	}
+	delete(c.clients, key)
+}
+
+// debugTransport logs the method, path, status code, request ID and remaining
+// rate limit of every request. It never logs headers or bodies, so the token
+// and any secrets in payloads are not exposed.
+type debugTransport struct {
+	base http.RoundTripper
+	log  logging.Logger
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.log.Debug("DigitalOcean API request failed", "method", req.Method, "path", req.URL.Path, "error", err)
+		return resp, err
+	}
+	t.log.Debug("DigitalOcean API request",
+		"method", req.Method,
+		"path", req.URL.Path,
+		"status", resp.StatusCode,
+		"request-id", resp.Header.Get(headerRequestID),
+		"rate-limit-remaining", resp.Header.Get(headerRateLimitRemaining))
+	return resp, nil
+}
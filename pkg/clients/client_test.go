@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestClientCacheGet(t *testing.T) {
+	type want struct {
+		auth    string
+		timeout bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		options ClientOptions
+		delay   time.Duration
+		want    want
+	}{
+		"TimeoutApplied": {
+			reason:  "Requests exceeding the configured timeout should fail.",
+			options: ClientOptions{Timeout: 10 * time.Millisecond},
+			delay:   200 * time.Millisecond,
+			want:    want{auth: "Bearer token", timeout: true},
+		},
+		"WithinTimeout": {
+			reason:  "Requests completing within the configured timeout should succeed.",
+			options: ClientOptions{Timeout: 5 * time.Second},
+			want:    want{auth: "Bearer token"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				time.Sleep(tc.delay)
+				_, _ = w.Write([]byte(`{"account":{}}`))
+			}))
+
+			cc := NewClientCache(tc.options)
+			c := cc.GetFor(AuthInfo{Token: " token\n"})
+			if c != cc.GetFor(AuthInfo{Token: "token"}) {
+				t.Errorf("\n%s\ncc.GetFor(...): expected the cached client to be reused", tc.reason)
+			}
+			c.BaseURL, _ = url.Parse(srv.URL)
+
+			_, _, err := c.Account.Get(context.Background())
+			var uerr *url.Error
+			timeout := errors.As(err, &uerr) && uerr.Timeout()
+			srv.Close()
+			if diff := cmp.Diff(tc.want, want{auth: auth, timeout: timeout}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nc.Account.Get(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClientCacheTeams(t *testing.T) {
+	cc := NewClientCache(ClientOptions{})
+
+	a := cc.GetFor(AuthInfo{Token: "token", Team: "team-a"})
+	b := cc.GetFor(AuthInfo{Token: "token", Team: "team-b"})
+	if a == b {
+		t.Error("cc.GetFor(...): want ProviderConfigs with the same token but different teams not to share a client")
+	}
+	if a != cc.GetFor(AuthInfo{Token: "token", Team: "team-a"}) {
+		t.Error("cc.GetFor(...): want the cached client of a team to be reused")
+	}
+	if cc.GetFor(AuthInfo{Token: "token"}) == a {
+		t.Error("cc.GetFor(...): want a client that is not scoped to a team not to share the client of a team")
+	}
+}
+
+func TestClientCacheTokenRotation(t *testing.T) {
+	cc := NewClientCache(ClientOptions{})
+
+	old := cc.GetFor(AuthInfo{Token: "old", ProviderConfig: "default"})
+	shared := cc.GetFor(AuthInfo{Token: "shared", ProviderConfig: "default"})
+	if shared == old {
+		t.Error("cc.GetFor(...): want a rotated token to get a new client")
+	}
+	if _, ok := cc.clients[cacheKey{token: "old"}]; ok {
+		t.Error("cc.GetFor(...): want the client of the previous token to be evicted")
+	}
+
+	// Another ProviderConfig still uses the shared token after the default
+	// ProviderConfig rotates it again.
+	if cc.GetFor(AuthInfo{Token: "shared", ProviderConfig: "other"}) != shared {
+		t.Error("cc.GetFor(...): want ProviderConfigs with the same token to share a client")
+	}
+	cc.GetFor(AuthInfo{Token: "new", ProviderConfig: "default"})
+	if _, ok := cc.clients[cacheKey{token: "shared"}]; !ok {
+		t.Error("cc.GetFor(...): want a client that is still used by another ProviderConfig not to be evicted")
+	}
+}
+
+// recordingLogger records the messages logged at debug level.
+type recordingLogger struct {
+	logging.Logger
+	messages *[]string
+}
+
+func (l recordingLogger) Debug(msg string, _ ...interface{}) {
+	*l.messages = append(*l.messages, msg)
+}
+
+func TestClientCacheDebugLogging(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		debug  bool
+		want   []string
+	}{
+		"Disabled": {
+			reason: "Nothing should be logged when API debug logging is disabled.",
+		},
+		"Enabled": {
+			reason: "Every request should be logged when API debug logging is enabled.",
+			debug:  true,
+			want:   []string{"DigitalOcean API request"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(headerRequestID, "request-id")
+				_, _ = w.Write([]byte(`{"account":{}}`))
+			}))
+			defer srv.Close()
+
+			var got []string
+			o := ClientOptions{}
+			l := recordingLogger{Logger: logging.NewNopLogger(), messages: &got}
+			if tc.debug {
+				o.DebugLogger = l
+			}
+			c := NewClientCache(o).GetFor(AuthInfo{Token: "token"})
+			c.BaseURL, _ = url.Parse(srv.URL)
+
+			if _, _, err := c.Account.Get(context.Background()); err != nil {
+				t.Fatalf("\n%s\nc.Account.Get(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.Account.Get(...): -want messages, +got messages:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// Types of the conditions that report whether the attachments declared by a
+// Droplet are in place.
+const (
+	TypeVolumesAttached xpv1.ConditionType = "VolumesAttached"
+	TypeVPCAssigned     xpv1.ConditionType = "VPCAssigned"
+)
+
+// Reasons an attachment is or is not in place.
+const (
+	ReasonAttached xpv1.ConditionReason = "Attached"
+	ReasonPending  xpv1.ConditionReason = "Pending"
+)
+
+const msgVolumesMissing = "volumes not attached yet: "
+
+// AttachmentConditions returns a condition for each kind of attachment the
+// supplied DropletParameters declare, i.e. volumes and a VPC, that reports
+// whether the supplied DropletObservation confirms it is in place. The
+// condition of the volumes lists those that are not attached yet.
+func AttachmentConditions(p v1alpha1.DropletParameters, o v1alpha1.DropletObservation) []xpv1.Condition {
+	var c []xpv1.Condition
+	if len(p.Volumes) > 0 {
+		missing := MissingVolumes(p, o)
+		vc := attachmentCondition(TypeVolumesAttached, len(missing) == 0)
+		if len(missing) > 0 {
+			vc = vc.WithMessage(msgVolumesMissing + strings.Join(missing, ", "))
+		}
+		c = append(c, vc)
+	}
+	if vpc := do.StringValue(p.VPCUUID); vpc != "" {
+		c = append(c, attachmentCondition(TypeVPCAssigned, o.VPCUUID == vpc))
+	}
+	return c
+}
+
+// MissingVolumes returns the volumes the supplied DropletParameters declare
+// that the supplied DropletObservation does not report as attached, in the
+// order they are declared.
+func MissingVolumes(p v1alpha1.DropletParameters, o v1alpha1.DropletObservation) []string {
+	var missing []string
+	for _, v := range p.Volumes {
+		if !contains(o.VolumeIDs, v) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// PendingAttachments returns the types of the supplied attachment conditions
+// that report an attachment that is not in place yet.
+func PendingAttachments(c []xpv1.Condition) []string {
+	var pending []string
+	for _, cond := range c {
+		if cond.Status != corev1.ConditionTrue {
+			pending = append(pending, string(cond.Type))
+		}
+	}
+	return pending
+}
+
+func attachmentCondition(t xpv1.ConditionType, attached bool) xpv1.Condition {
+	if !attached {
+		return xpv1.Condition{
+			Type:               t,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonPending,
+		}
+	}
+	return xpv1.Condition{
+		Type:               t,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAttached,
+	}
+}
@@ -17,24 +17,291 @@ limitations under the License.
 package compute
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+const dropletsPath = "v2/droplets"
+
+type dropletsRoot struct {
+	Droplets []godo.Droplet `json:"droplets"`
+}
+
+// ListDropletsByName lists the Droplets with the supplied name. The vendored
+// godo cannot filter Droplets by name yet.
+func ListDropletsByName(ctx context.Context, c *godo.Client, name string) ([]godo.Droplet, *godo.Response, error) {
+	path := dropletsPath + "?" + url.Values{"name": {name}, "per_page": {"200"}}.Encode()
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &dropletsRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Droplets, resp, nil
+}
+
+// ClosestSizes returns up to n of the supplied size slugs that are closest
+// to the supplied slug, ordered by their edit distance to it.
+func ClosestSizes(slug string, sizes []string, n int) []string {
+	distance := make(map[string]int, len(sizes))
+	closest := make([]string, 0, len(sizes))
+	for _, s := range sizes {
+		distance[s] = editDistance(slug, s)
+		closest = append(closest, s)
+	}
+	sort.SliceStable(closest, func(i, j int) bool {
+		if distance[closest[i]] != distance[closest[j]] {
+			return distance[closest[i]] < distance[closest[j]]
+		}
+		return closest[i] < closest[j]
+	})
+	if len(closest) > n {
+		closest = closest[:n]
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between the supplied
+// strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func minInt(v ...int) int {
+	m := v[0]
+	for _, e := range v[1:] {
+		if e < m {
+			m = e
+		}
+	}
+	return m
+}
+
+// dedupeTagPrefix is the prefix of the tag that identifies the managed
+// resource a Droplet was created for.
+const dedupeTagPrefix = "crossplane:"
+
+// DedupeTag returns the tag that is applied to a Droplet created for the
+// managed resource with the supplied UID. It lets the controller find a
+// Droplet whose creation succeeded but whose external-name was never
+// persisted, rather than creating a duplicate.
+func DedupeTag(uid string) string {
+	return dedupeTagPrefix + uid
+}
+
+// AnnotationPoweredOffForResize is set on a Droplet managed resource while
+// the Droplet is powered off to be resized, so that it is powered on again
+// once the resize is complete.
+const AnnotationPoweredOffForResize = "compute.do.crossplane.io/powered-off-for-resize"
+
+// networkPublic is the type of the public networks of a Droplet.
+const networkPublic = "public"
+
+// Features of a Droplet, as reported by the API.
+const (
+	FeatureBackups           = "backups"
+	FeatureIPv6              = "ipv6"
+	FeatureMonitoring        = "monitoring"
+	FeaturePrivateNetworking = "private_networking"
+)
+
+// FeaturesToUpdate returns the features of a Droplet with the supplied
+// features that must be enabled or disabled to match the supplied
+// DropletParameters. Only backups can be enabled and disabled; IPv6 can only
+// be enabled. Monitoring and private networking cannot be changed after the
+// Droplet is created and are reported by ImmutableFields instead. Features
+// whose parameter is unset are not managed.
+func FeaturesToUpdate(p v1alpha1.DropletParameters, features []string) []string {
+	var update []string
+	if p.Backups != nil && *p.Backups != contains(features, FeatureBackups) {
+		update = append(update, FeatureBackups)
+	}
+	if do.BoolValue(p.IPv6) && !contains(features, FeatureIPv6) {
+		update = append(update, FeatureIPv6)
+	}
+	return update
+}
+
+const (
+	errReverseDNS           = "reverse DNS name %q is not a fully qualified domain name"
+	errPrivateNetworkingVPC = "private networking cannot be disabled for a Droplet in a VPC"
+	errRebuildRegion        = "cannot rebuild Droplet from image %q: it is not available in region %q"
+	errRebuildDisk          = "cannot rebuild Droplet from image %q: it needs a disk of at least %d GB, but the Droplet has %d GB"
+	errMissingFields        = "missing required fields: %s"
+)
+
+// ValidateRequired returns an error naming the fields of the supplied
+// DropletParameters that must be set to create a Droplet but are empty.
+func ValidateRequired(p v1alpha1.DropletParameters) error {
+	var missing []string
+	for _, f := range []struct {
+		path  string
+		value string
+	}{
+		{path: "spec.forProvider.region", value: p.Region},
+		{path: "spec.forProvider.size", value: p.Size},
+		{path: "spec.forProvider.image", value: p.Image},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			missing = append(missing, f.path)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf(errMissingFields, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ValidatePrivateNetworking returns an error if private networking is
+// disabled while a VPC is specified in the supplied DropletParameters.
+func ValidatePrivateNetworking(p v1alpha1.DropletParameters) error {
+	if do.StringValue(p.VPCUUID) != "" && p.PrivateNetworking != nil && !*p.PrivateNetworking {
+		return errors.New(errPrivateNetworkingVPC)
+	}
+	return nil
+}
+
+// privateNetworking returns whether private networking is requested by the
+// supplied DropletParameters. It defaults to true if a VPC is specified.
+func privateNetworking(p v1alpha1.DropletParameters) bool {
+	if p.PrivateNetworking == nil {
+		return do.StringValue(p.VPCUUID) != ""
+	}
+	return *p.PrivateNetworking
+}
+
+// ValidateReverseDNS returns an error if the reverse DNS name of the supplied
+// DropletParameters is set but not a fully qualified domain name.
+func ValidateReverseDNS(p v1alpha1.DropletParameters) error {
+	if p.ReverseDNS == nil {
+		return nil
+	}
+	if name := *p.ReverseDNS; !isFQDN(name) {
+		return errors.Errorf(errReverseDNS, name)
+	}
+	return nil
+}
+
+// GenerateObservation returns the DropletObservation of the supplied Droplet.
+// The latest backup and the action in progress are not part of the Droplet
+// and are left unset.
+func GenerateObservation(observed godo.Droplet) v1alpha1.DropletObservation {
+	o := v1alpha1.DropletObservation{
+		CreationTimestamp: observed.Created,
+		ID:                observed.ID,
+		Size:              observed.SizeSlug,
+		Region:            do.RegionSlug(observed.Region),
+		Disk:              observed.Disk,
+		Features:          observed.Features,
+		ReverseDNS:        ReverseDNS(observed),
+		Locked:            observed.Locked,
+		VolumeIDs:         observed.VolumeIDs,
+		VPCUUID:           observed.VPCUUID,
+		Status:            observed.Status,
+	}
+	if observed.Image != nil {
+		o.ImageID = observed.Image.ID
+		o.ImageSlug = observed.Image.Slug
+		o.ImageType = observed.Image.Type
+	}
+	if observed.Kernel != nil {
+		o.KernelID = observed.Kernel.ID
+		o.KernelName = observed.Kernel.Name
+		o.KernelVersion = observed.Kernel.Version
+	}
+	if observed.Networks == nil {
+		return o
+	}
+	for _, n := range observed.Networks.V4 {
+		if n.Type != networkPublic {
+			continue
+		}
+		o.PublicIPv4, o.PublicIPv4Gateway = n.IPAddress, n.Gateway
+		if ones, _ := net.IPMask(net.ParseIP(n.Netmask).To4()).Size(); ones > 0 {
+			o.PublicIPv4CIDR = fmt.Sprintf("%s/%d", n.IPAddress, ones)
+		}
+		break
+	}
+	for _, n := range observed.Networks.V6 {
+		if n.Type != networkPublic {
+			continue
+		}
+		o.PublicIPv6, o.PublicIPv6Gateway = n.IPAddress, n.Gateway
+		if n.Netmask > 0 {
+			o.PublicIPv6CIDR = fmt.Sprintf("%s/%d", n.IPAddress, n.Netmask)
+		}
+		break
+	}
+	return o
+}
+
+// AddressesAssigned returns true if the supplied DropletObservation reports
+// an address of every IP family requested by the supplied DropletParameters.
+// IPv6 addresses may be assigned some time after IPv4 addresses.
+func AddressesAssigned(p v1alpha1.DropletParameters, o v1alpha1.DropletObservation) bool {
+	return o.PublicIPv4 != "" && (!do.BoolValue(p.IPv6) || o.PublicIPv6 != "")
+}
+
+// ReverseDNS returns the domain name the PTR records of the supplied Droplet
+// point to, which is its name if it is a fully qualified domain name.
+func ReverseDNS(observed godo.Droplet) string {
+	if !isFQDN(observed.Name) {
+		return ""
+	}
+	return observed.Name
+}
+
+func isFQDN(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	return strings.Contains(name, ".") && len(validation.IsDNS1123Subdomain(name)) == 0
+}
+
 // GenerateDroplet generates *godo.DropletCreateRequest instance from DropletParameters.
 func GenerateDroplet(name string, in v1alpha1.DropletParameters, create *godo.DropletCreateRequest) {
 	create.Name = name
+	if in.ReverseDNS != nil {
+		create.Name = *in.ReverseDNS
+	}
 	create.Region = in.Region
 	create.Size = in.Size
 	create.Image = generateImage(in.Image)
 	create.SSHKeys = generateSSHKeys(in.SSHKeys)
 	create.Backups = do.BoolValue(in.Backups)
 	create.IPv6 = do.BoolValue(in.IPv6)
-	create.PrivateNetworking = do.BoolValue(in.PrivateNetworking)
+	create.PrivateNetworking = privateNetworking(in)
 	create.Monitoring = do.BoolValue(in.Monitoring)
 	create.Volumes = generateVolumes(in.Volumes)
 	create.Tags = in.Tags
@@ -42,6 +309,31 @@ func GenerateDroplet(name string, in v1alpha1.DropletParameters, create *godo.Dr
 	create.WithDropletAgent = in.WithDropletAgent
 }
 
+// Regions returns the regions to deploy the Droplet described by the supplied
+// DropletParameters in, in order of preference.
+func Regions(in v1alpha1.DropletParameters) []string {
+	return append([]string{in.Region}, in.RegionFallback...)
+}
+
+// RegionFeatures returns the features a region must support to deploy the
+// Droplet described by the supplied DropletParameters in.
+func RegionFeatures(in v1alpha1.DropletParameters) []string {
+	var f []string
+	if do.BoolValue(in.Backups) {
+		f = append(f, do.RegionFeatureBackups)
+	}
+	if do.BoolValue(in.IPv6) {
+		f = append(f, do.RegionFeatureIPv6)
+	}
+	if do.StringValue(in.UserData) != "" {
+		f = append(f, do.RegionFeatureMetadata)
+	}
+	if len(in.Volumes) > 0 {
+		f = append(f, do.RegionFeatureStorage)
+	}
+	return f
+}
+
 func generateImage(param string) godo.DropletCreateImage {
 	image := godo.DropletCreateImage{}
 	if imageID, err := strconv.Atoi(param); err == nil {
@@ -52,11 +344,16 @@ func generateImage(param string) godo.DropletCreateImage {
 	return image
 }
 
+// generateSSHKeys returns the SSH keys of a create request. Keys consisting
+// only of digits are sent as numeric IDs, and all other keys as fingerprints.
+// An ID of zero is sent as a fingerprint because godo would otherwise send it
+// as an empty fingerprint.
 func generateSSHKeys(param []string) []godo.DropletCreateSSHKey {
 	keys := make([]godo.DropletCreateSSHKey, len(param))
 	for i, k := range param {
-		if id, err := strconv.Atoi(k); err == nil {
-			keys[i] = godo.DropletCreateSSHKey{ID: id}
+		k = strings.TrimSpace(k)
+		if id, err := strconv.ParseUint(k, 10, 31); err == nil && id > 0 {
+			keys[i] = godo.DropletCreateSSHKey{ID: int(id)}
 		} else {
 			keys[i] = godo.DropletCreateSSHKey{Fingerprint: k}
 		}
@@ -79,7 +376,143 @@ func generateVolumes(param []string) []godo.DropletCreateVolume {
 // supplied DropletParameters that are set (i.e. non-zero) on the supplied
 // Droplet.
 func LateInitializeSpec(p *v1alpha1.DropletParameters, observed godo.Droplet) {
+	p.Image = lateInitializeImage(p.Image, observed.Image)
 	p.Volumes = do.LateInitializeStringSlice(p.Volumes, observed.VolumeIDs)
-	p.Tags = do.LateInitializeStringSlice(p.Tags, observed.Tags)
+	p.Tags = do.LateInitializeStringSlice(p.Tags, withoutDedupeTag(observed.Tags))
 	p.VPCUUID = do.LateInitializeString(p.VPCUUID, observed.VPCUUID)
+	p.PrivateNetworking = do.LateInitializeBool(p.PrivateNetworking, contains(observed.Features, FeaturePrivateNetworking))
+}
+
+// ImmutableFields returns whether the desired values of the immutable fields
+// of the supplied DropletParameters match the supplied Droplet. The region
+// matches if the Droplet was deployed in any of the preferred or fallback
+// regions. Private networking is only reported if it is desired, because
+// Droplets in a VPC have it enabled regardless of the parameter.
+func ImmutableFields(p v1alpha1.DropletParameters, observed godo.Droplet) do.ImmutableFields {
+	f := do.ImmutableFields{}
+	if observed.Region != nil {
+		f["region"] = contains(Regions(p), observed.Region.Slug)
+	}
+	// The image of a Droplet that is rebuilt is determined by RebuildFrom.
+	if observed.Image != nil && p.RebuildFrom == nil {
+		f["image"] = imageMatches(p.Image, observed.Image.ID, observed.Image.Slug)
+	}
+	if p.VPCUUID != nil && observed.VPCUUID != "" {
+		f["vpcUuid"] = *p.VPCUUID == observed.VPCUUID
+	}
+
+	// IPv6 cannot be disabled, and monitoring and private networking cannot
+	// be changed at all once the Droplet exists.
+	if p.IPv6 != nil && !*p.IPv6 {
+		f["ipv6"] = !contains(observed.Features, FeatureIPv6)
+	}
+	if p.Monitoring != nil {
+		f["monitoring"] = *p.Monitoring == contains(observed.Features, FeatureMonitoring)
+	}
+	if do.BoolValue(p.PrivateNetworking) {
+		f["privateNetworking"] = contains(observed.Features, FeaturePrivateNetworking)
+	}
+	return f
+}
+
+// NeedsRebuild returns true if the Droplet with the supplied observation does
+// not run the image the supplied DropletParameters rebuild it from.
+func NeedsRebuild(p v1alpha1.DropletParameters, o v1alpha1.DropletObservation) bool {
+	if p.RebuildFrom == nil || (o.ImageID == 0 && o.ImageSlug == "") {
+		return false
+	}
+	// A Droplet that runs a snapshot or backup has no slug either, and
+	// must still be rebuilt from a public image.
+	return !isImage(*p.RebuildFrom, o.ImageID, o.ImageSlug)
+}
+
+// ValidateRebuildImage returns an error if the Droplet with the supplied
+// observation cannot be rebuilt from the supplied image, because the image is
+// not available in the Droplet's region or needs a larger disk.
+func ValidateRebuildImage(image godo.Image, o v1alpha1.DropletObservation) error {
+	if len(image.Regions) > 0 && o.Region != "" && !contains(image.Regions, o.Region) {
+		return errors.Errorf(errRebuildRegion, image.Name, o.Region)
+	}
+	if o.Disk > 0 && image.MinDiskSize > o.Disk {
+		return errors.Errorf(errRebuildDisk, image.Name, image.MinDiskSize, o.Disk)
+	}
+	return nil
+}
+
+// imageMatches returns true if the supplied image parameter, an ID or slug,
+// may identify the image with the supplied ID and slug. DigitalOcean stops
+// reporting the slug of a public image once it is retired, so whether an image
+// identified by slug changed is unknown if there is no slug, and it matches.
+func imageMatches(param string, id int, slug string) bool {
+	if slug == "" && !isImageID(param) {
+		return true
+	}
+	return isImage(param, id, slug)
+}
+
+// isImage returns true if the supplied image parameter, an ID or slug,
+// identifies the image with the supplied ID and slug.
+func isImage(param string, id int, slug string) bool {
+	return (slug != "" && param == slug) || param == strconv.Itoa(id)
+}
+
+// isImageID returns true if the supplied image parameter is an image ID
+// rather than a slug.
+func isImageID(param string) bool {
+	_, err := strconv.Atoi(param)
+	return err == nil
+}
+
+// LatestBackup returns the most recently created of the supplied backups, or
+// nil if there are none. Backups whose creation time cannot be parsed are
+// never the most recent.
+func LatestBackup(backups []godo.Image) *godo.Image {
+	var latest *godo.Image
+	var created time.Time
+	for i := range backups {
+		t, err := time.Parse(time.RFC3339, backups[i].Created)
+		if err != nil {
+			continue
+		}
+		if latest == nil || t.After(created) {
+			latest, created = &backups[i], t
+		}
+	}
+	return latest
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// lateInitializeImage returns the supplied image unless it is unset. The API
+// reports the numeric ID of an image even when it was selected by slug, so a
+// set image is never overwritten to keep the representation chosen by the
+// user.
+func lateInitializeImage(in string, observed *godo.Image) string {
+	if in != "" || observed == nil {
+		return in
+	}
+	if observed.Slug != "" {
+		return observed.Slug
+	}
+	if observed.ID != 0 {
+		return strconv.Itoa(observed.ID)
+	}
+	return in
+}
+
+func withoutDedupeTag(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if !strings.HasPrefix(t, dedupeTagPrefix) {
+			out = append(out, t)
+		}
+	}
+	return out
 }
@@ -0,0 +1,621 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+)
+
+func TestGenerateObservation(t *testing.T) {
+	observed := godo.Droplet{
+		ID:        1234,
+		Name:      "web.example.com",
+		Created:   "2021-06-01T12:00:00Z",
+		SizeSlug:  "s-1vcpu-1gb",
+		Disk:      25,
+		Region:    &godo.Region{Slug: "nyc3"},
+		Image:     &godo.Image{ID: 5678, Slug: "ubuntu-20-04-x64", Type: "base"},
+		Features:  []string{FeatureBackups},
+		Status:    v1alpha1.StatusActive,
+		Locked:    true,
+		VolumeIDs: []string{"volume-id"},
+		VPCUUID:   "vpc-uuid",
+		Networks: &godo.Networks{
+			V4: []godo.NetworkV4{
+				{IPAddress: "10.10.0.2", Netmask: "255.255.0.0", Type: "private"},
+				{IPAddress: "203.0.113.10", Netmask: "255.255.240.0", Gateway: "203.0.113.1", Type: "public"},
+			},
+			V6: []godo.NetworkV6{{IPAddress: "2001:db8::10", Netmask: 64, Gateway: "2001:db8::1", Type: "public"}},
+		},
+	}
+	want := v1alpha1.DropletObservation{
+		CreationTimestamp: "2021-06-01T12:00:00Z",
+		ID:                1234,
+		Size:              "s-1vcpu-1gb",
+		Disk:              25,
+		Region:            "nyc3",
+		ImageID:           5678,
+		ImageSlug:         "ubuntu-20-04-x64",
+		ImageType:         "base",
+		Features:          []string{FeatureBackups},
+		ReverseDNS:        "web.example.com",
+		Locked:            true,
+		VolumeIDs:         []string{"volume-id"},
+		VPCUUID:           "vpc-uuid",
+		PublicIPv4:        "203.0.113.10",
+		PublicIPv4Gateway: "203.0.113.1",
+		PublicIPv4CIDR:    "203.0.113.10/20",
+		PublicIPv6:        "2001:db8::10",
+		PublicIPv6Gateway: "2001:db8::1",
+		PublicIPv6CIDR:    "2001:db8::10/64",
+		Status:            v1alpha1.StatusActive,
+	}
+
+	got := GenerateObservation(observed)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateObservationImage(t *testing.T) {
+	type want struct {
+		id   int
+		slug string
+		typ  string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *godo.Image
+		want     want
+	}{
+		"PublicImage": {
+			reason:   "A Droplet created from a public image should report its ID, slug and type.",
+			observed: &godo.Image{ID: 112929454, Slug: "ubuntu-20-04-x64", Type: "base"},
+			want:     want{id: 112929454, slug: "ubuntu-20-04-x64", typ: "base"},
+		},
+		"Backup": {
+			reason:   "A Droplet created from a backup should report its ID and type, but no slug.",
+			observed: &godo.Image{ID: 5678, Type: "backup"},
+			want:     want{id: 5678, typ: "backup"},
+		},
+		"Unknown": {
+			reason: "A Droplet whose image is not reported should report no image.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := GenerateObservation(godo.Droplet{Image: tc.observed})
+			got := want{id: o.ImageID, slug: o.ImageSlug, typ: o.ImageType}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservationKernel(t *testing.T) {
+	type want struct {
+		id      int
+		name    string
+		version string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *godo.Kernel
+		want     want
+	}{
+		"Managed": {
+			reason:   "A Droplet booting a kernel managed by DigitalOcean should report it.",
+			observed: &godo.Kernel{ID: 7515, Name: "DigitalOcean GrubLoader v0.2 (20160714)", Version: "2016.07.13"},
+			want:     want{id: 7515, name: "DigitalOcean GrubLoader v0.2 (20160714)", version: "2016.07.13"},
+		},
+		"Internal": {
+			reason: "A Droplet booting the kernel of its image should report no kernel.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := GenerateObservation(godo.Droplet{Kernel: tc.observed})
+			got := want{id: o.KernelID, name: o.KernelName, version: o.KernelVersion}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpecImage(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		image    string
+		observed *godo.Image
+		want     string
+	}{
+		"SlugPreserved": {
+			reason:   "A slug should not be overwritten by the numeric ID reported by the API.",
+			image:    "ubuntu-20-04-x64",
+			observed: &godo.Image{ID: 112929454},
+			want:     "ubuntu-20-04-x64",
+		},
+		"IDPreserved": {
+			reason:   "A numeric ID should not be overwritten by the slug reported by the API.",
+			image:    "112929454",
+			observed: &godo.Image{ID: 112929454, Slug: "ubuntu-20-04-x64"},
+			want:     "112929454",
+		},
+		"UnsetFromSlug": {
+			reason:   "An unset image should be late initialized from the observed slug.",
+			observed: &godo.Image{ID: 112929454, Slug: "ubuntu-20-04-x64"},
+			want:     "ubuntu-20-04-x64",
+		},
+		"UnsetFromID": {
+			reason:   "An unset image should be late initialized from the observed ID if there is no slug.",
+			observed: &godo.Image{ID: 112929454},
+			want:     "112929454",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.DropletParameters{Image: tc.image}
+			LateInitializeSpec(p, godo.Droplet{Image: tc.observed})
+			if diff := cmp.Diff(tc.want, p.Image); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestImmutableFields(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		p        v1alpha1.DropletParameters
+		observed godo.Droplet
+		want     []string
+	}{
+		"Unchanged": {
+			reason: "No fields should be reported as changed if the spec matches the Droplet.",
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Image: "ubuntu-20-04-x64"},
+			observed: godo.Droplet{
+				Region: &godo.Region{Slug: "nyc1"},
+				Image:  &godo.Image{ID: 112929454, Slug: "ubuntu-20-04-x64"},
+			},
+		},
+		"FallbackRegion": {
+			reason: "A Droplet deployed in a fallback region should not be reported as changed.",
+			p:      v1alpha1.DropletParameters{Region: "nyc1", RegionFallback: []string{"nyc3"}, Image: "112929454"},
+			observed: godo.Droplet{
+				Region: &godo.Region{Slug: "nyc3"},
+				Image:  &godo.Image{ID: 112929454},
+			},
+		},
+		"RetiredImage": {
+			reason: "A Droplet whose public image was retired, and is no longer reported with a slug, should not be reported as changed.",
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Image: "ubuntu-18-04-x64"},
+			observed: godo.Droplet{
+				Region: &godo.Region{Slug: "nyc1"},
+				Image:  &godo.Image{ID: 72067660, Type: "base"},
+			},
+		},
+		"ChangedImageID": {
+			reason: "A Droplet that does not run the image with the desired ID should be reported as changed.",
+			p:      v1alpha1.DropletParameters{Region: "nyc1", Image: "112929454"},
+			observed: godo.Droplet{
+				Region: &godo.Region{Slug: "nyc1"},
+				Image:  &godo.Image{ID: 72067660},
+			},
+			want: []string{"image"},
+		},
+		"Changed": {
+			reason: "Changed region, image and VPC should be reported.",
+			p:      v1alpha1.DropletParameters{Region: "sfo3", Image: "debian-11-x64", VPCUUID: stringPtr("vpc-b")},
+			observed: godo.Droplet{
+				Region:  &godo.Region{Slug: "nyc1"},
+				Image:   &godo.Image{ID: 112929454, Slug: "ubuntu-20-04-x64"},
+				VPCUUID: "vpc-a",
+			},
+			want: []string{"image", "region", "vpcUuid"},
+		},
+		"OneWayFeatures": {
+			reason: "Disabling IPv6 and changing monitoring cannot be done in place and should be reported.",
+			p:      v1alpha1.DropletParameters{IPv6: boolPtr(false), Monitoring: boolPtr(true)},
+			observed: godo.Droplet{
+				Features: []string{"ipv6"},
+			},
+			want: []string{"ipv6", "monitoring"},
+		},
+		"PrivateNetworkingNotDesired": {
+			reason: "Private networking enabled on a Droplet in a VPC should not be reported if it is not desired.",
+			p:      v1alpha1.DropletParameters{PrivateNetworking: boolPtr(false)},
+			observed: godo.Droplet{
+				Features: []string{"private_networking"},
+			},
+		},
+		"PrivateNetworkingMissing": {
+			reason: "Private networking that is desired but not enabled should be reported.",
+			p:      v1alpha1.DropletParameters{PrivateNetworking: boolPtr(true)},
+			want:   []string{"privateNetworking"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableFields(tc.p, tc.observed).Changed()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nImmutableFields(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFeaturesToUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		p        v1alpha1.DropletParameters
+		features []string
+		want     []string
+	}{
+		"Unmanaged": {
+			reason:   "Features whose parameter is unset should not be updated.",
+			features: []string{"backups", "ipv6"},
+		},
+		"EnableBackups": {
+			reason: "Backups should be enabled if desired and not enabled.",
+			p:      v1alpha1.DropletParameters{Backups: boolPtr(true)},
+			want:   []string{"backups"},
+		},
+		"DisableBackups": {
+			reason:   "Backups should be disabled if not desired and enabled.",
+			p:        v1alpha1.DropletParameters{Backups: boolPtr(false)},
+			features: []string{"backups"},
+			want:     []string{"backups"},
+		},
+		"EnableIPv6": {
+			reason: "IPv6 should be enabled if desired and not enabled.",
+			p:      v1alpha1.DropletParameters{IPv6: boolPtr(true)},
+			want:   []string{"ipv6"},
+		},
+		"DisableIPv6": {
+			reason:   "IPv6 cannot be disabled and should not be updated.",
+			p:        v1alpha1.DropletParameters{IPv6: boolPtr(false)},
+			features: []string{"ipv6"},
+		},
+		"Monitoring": {
+			reason: "Monitoring cannot be changed in place and should not be updated.",
+			p:      v1alpha1.DropletParameters{Monitoring: boolPtr(true)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FeaturesToUpdate(tc.p, tc.features)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFeaturesToUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReverseDNS(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		name   string
+		valid  bool
+		want   string
+	}{
+		"FQDN": {
+			reason: "A fully qualified domain name should be valid and reported as reverse DNS.",
+			name:   "web.example.com",
+			valid:  true,
+			want:   "web.example.com",
+		},
+		"TrailingDot": {
+			reason: "A fully qualified domain name with a trailing dot should be valid.",
+			name:   "web.example.com.",
+			valid:  true,
+			want:   "web.example.com.",
+		},
+		"Hostname": {
+			reason: "A name without a domain should be invalid and not reported as reverse DNS.",
+			name:   "web",
+		},
+		"InvalidLabel": {
+			reason: "A name with an invalid label should be invalid.",
+			name:   "-web.example.com",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateReverseDNS(v1alpha1.DropletParameters{ReverseDNS: &tc.name})
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("\n%s\nValidateReverseDNS(...): -want valid, +got valid:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, ReverseDNS(godo.Droplet{Name: tc.name})); diff != "" {
+				t.Errorf("\n%s\nReverseDNS(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestPrivateNetworking(t *testing.T) {
+	type want struct {
+		requested bool
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.DropletParameters
+		want   want
+	}{
+		"DefaultWithoutVPC": {
+			reason: "Private networking should not be requested by default without a VPC.",
+		},
+		"DefaultWithVPC": {
+			reason: "Private networking should be requested by default when a VPC is specified.",
+			p:      v1alpha1.DropletParameters{VPCUUID: stringPtr("vpc-uuid")},
+			want:   want{requested: true},
+		},
+		"Explicit": {
+			reason: "Explicitly enabled private networking should be requested.",
+			p:      v1alpha1.DropletParameters{PrivateNetworking: boolPtr(true)},
+			want:   want{requested: true},
+		},
+		"DisabledWithVPC": {
+			reason: "Disabling private networking while a VPC is specified should be rejected.",
+			p:      v1alpha1.DropletParameters{VPCUUID: stringPtr("vpc-uuid"), PrivateNetworking: boolPtr(false)},
+			want:   want{err: errors.New(errPrivateNetworkingVPC)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			create := &godo.DropletCreateRequest{}
+			GenerateDroplet("example", tc.p, create)
+			got := want{requested: create.PrivateNetworking, err: ValidatePrivateNetworking(tc.p)}
+			if diff := cmp.Diff(tc.want.requested, got.requested); diff != "" {
+				t.Errorf("\n%s\nGenerateDroplet(...): -want private networking, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, got.err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidatePrivateNetworking(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	valid := v1alpha1.DropletParameters{Region: "nyc3", Size: "s-1vcpu-1gb", Image: "ubuntu-20-04-x64"}
+
+	cases := map[string]struct {
+		reason string
+		p      func(p *v1alpha1.DropletParameters)
+		want   error
+	}{
+		"Valid": {
+			reason: "A Droplet with a region, size and image should be valid.",
+			p:      func(_ *v1alpha1.DropletParameters) {},
+		},
+		"MissingRegion": {
+			reason: "A Droplet without a region should be rejected.",
+			p:      func(p *v1alpha1.DropletParameters) { p.Region = "" },
+			want:   errors.Errorf(errMissingFields, "spec.forProvider.region"),
+		},
+		"MissingSize": {
+			reason: "A Droplet without a size should be rejected.",
+			p:      func(p *v1alpha1.DropletParameters) { p.Size = " " },
+			want:   errors.Errorf(errMissingFields, "spec.forProvider.size"),
+		},
+		"MissingImage": {
+			reason: "A Droplet without an image should be rejected.",
+			p:      func(p *v1alpha1.DropletParameters) { p.Image = "" },
+			want:   errors.Errorf(errMissingFields, "spec.forProvider.image"),
+		},
+		"MissingAll": {
+			reason: "Every missing field should be named.",
+			p:      func(p *v1alpha1.DropletParameters) { *p = v1alpha1.DropletParameters{} },
+			want:   errors.Errorf(errMissingFields, "spec.forProvider.region, spec.forProvider.size, spec.forProvider.image"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := valid
+			tc.p(&p)
+			if diff := cmp.Diff(tc.want, ValidateRequired(p), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateRequired(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpecPrivateNetworking(t *testing.T) {
+	p := &v1alpha1.DropletParameters{}
+	LateInitializeSpec(p, godo.Droplet{Features: []string{FeaturePrivateNetworking}})
+	if diff := cmp.Diff(boolPtr(true), p.PrivateNetworking); diff != "" {
+		t.Errorf("LateInitializeSpec(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestNeedsRebuild(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		rebuild  *string
+		observed v1alpha1.DropletObservation
+		want     bool
+	}{
+		"Unset": {
+			reason:   "A Droplet that is not rebuilt from an image should never need a rebuild.",
+			observed: v1alpha1.DropletObservation{ImageID: 1111},
+		},
+		"NotObserved": {
+			reason:  "A Droplet whose image was not observed yet should not need a rebuild.",
+			rebuild: stringPtr("5678"),
+		},
+		"DifferentImage": {
+			reason:   "A Droplet that runs another image should need a rebuild.",
+			rebuild:  stringPtr("5678"),
+			observed: v1alpha1.DropletObservation{ImageID: 1111, ImageSlug: "ubuntu-20-04-x64"},
+			want:     true,
+		},
+		"SameID": {
+			reason:   "A Droplet that runs the image with the desired ID should not need a rebuild.",
+			rebuild:  stringPtr("5678"),
+			observed: v1alpha1.DropletObservation{ImageID: 5678},
+		},
+		"SameSlug": {
+			reason:   "A Droplet that runs the image with the desired slug should not need a rebuild.",
+			rebuild:  stringPtr("ubuntu-22-04-x64"),
+			observed: v1alpha1.DropletObservation{ImageID: 1111, ImageSlug: "ubuntu-22-04-x64"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NeedsRebuild(v1alpha1.DropletParameters{RebuildFrom: tc.rebuild}, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNeedsRebuild(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateDropletSSHKeys(t *testing.T) {
+	const fingerprint = "3b:16:bf:e4:8b:00:8b:b8:59:8c:a9:d3:f0:19:45:fa"
+
+	cases := map[string]struct {
+		reason string
+		keys   []string
+		want   string
+	}{
+		"IDsAndFingerprints": {
+			reason: "Numeric keys should be sent as IDs and other keys as fingerprints.",
+			keys:   []string{"512189", fingerprint},
+			want:   `[512189,"` + fingerprint + `"]`,
+		},
+		"NotAnID": {
+			reason: "Keys that are not positive integers should be sent as fingerprints.",
+			keys:   []string{"0", "+512189", " 512189 "},
+			want:   `["0","+512189",512189]`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			create := &godo.DropletCreateRequest{}
+			GenerateDroplet("example", v1alpha1.DropletParameters{SSHKeys: tc.keys}, create)
+			got, err := json.Marshal(create.SSHKeys)
+			if err != nil {
+				t.Fatalf("\n%s\njson.Marshal(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nGenerateDroplet(...): -want ssh_keys, +got ssh_keys:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateDropletIPv6(t *testing.T) {
+	create := &godo.DropletCreateRequest{}
+	GenerateDroplet("example", v1alpha1.DropletParameters{IPv6: boolPtr(true)}, create)
+	if !create.IPv6 {
+		t.Error("GenerateDroplet(...): want IPv6 to be requested on creation")
+	}
+}
+
+func TestAddressesAssigned(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.DropletParameters
+		o      v1alpha1.DropletObservation
+		want   bool
+	}{
+		"IPv4": {
+			reason: "A Droplet without IPv6 should only need an IPv4 address.",
+			o:      v1alpha1.DropletObservation{PublicIPv4: "203.0.113.10"},
+			want:   true,
+		},
+		"IPv6Pending": {
+			reason: "A Droplet with IPv6 enabled should need an IPv6 address too.",
+			p:      v1alpha1.DropletParameters{IPv6: boolPtr(true)},
+			o:      v1alpha1.DropletObservation{PublicIPv4: "203.0.113.10"},
+		},
+		"DualStack": {
+			reason: "A Droplet with IPv6 enabled should have its addresses assigned once both are reported.",
+			p:      v1alpha1.DropletParameters{IPv6: boolPtr(true)},
+			o:      v1alpha1.DropletObservation{PublicIPv4: "203.0.113.10", PublicIPv6: "2001:db8::10"},
+			want:   true,
+		},
+		"None": {
+			reason: "A Droplet without addresses should not have its addresses assigned.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := AddressesAssigned(tc.p, tc.o); got != tc.want {
+				t.Errorf("\n%s\nAddressesAssigned(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestClosestSizes(t *testing.T) {
+	sizes := []string{"s-1vcpu-1gb", "s-1vcpu-2gb", "s-2vcpu-4gb", "c-2", "g-2vcpu-8gb"}
+
+	cases := map[string]struct {
+		reason string
+		slug   string
+		want   []string
+	}{
+		"Typo": {
+			reason: "A slug with a typo should be closest to the size it was meant to be.",
+			slug:   "s-1vpcu-1gb",
+			want:   []string{"s-1vcpu-1gb", "s-1vcpu-2gb", "s-2vcpu-4gb"},
+		},
+		"OtherCount": {
+			reason: "A slug of an unknown vCPU count should be closest to the size of the same class.",
+			slug:   "c-4",
+			want:   []string{"c-2", "s-2vcpu-4gb", "g-2vcpu-8gb"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ClosestSizes(tc.slug, sizes, 3)); diff != "" {
+				t.Errorf("\n%s\nClosestSizes(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// MaxMultiCreate is the number of Droplets that can be created at once.
+const MaxMultiCreate = 10
+
+// ListDropletsByTag lists all Droplets with the supplied tag.
+func ListDropletsByTag(ctx context.Context, c *godo.Client, tag string) ([]godo.Droplet, error) {
+	var all []godo.Droplet
+	opts := &godo.ListOptions{PerPage: 200}
+	for {
+		droplets, resp, err := c.Droplets.ListByTag(ctx, tag, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, droplets...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = page + 1
+	}
+}
+
+// GroupMembers splits the supplied Droplets with the tag of a group into the
+// Droplets the group created, which carry the supplied dedupe tag of the
+// group, and foreign Droplets. Both are sorted by ID, i.e. by age.
+func GroupMembers(droplets []godo.Droplet, dedupeTag string) (owned, foreign []godo.Droplet) {
+	for _, d := range droplets {
+		if contains(d.Tags, dedupeTag) {
+			owned = append(owned, d)
+		} else {
+			foreign = append(foreign, d)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool { return owned[i].ID < owned[j].ID })
+	sort.Slice(foreign, func(i, j int) bool { return foreign[i].ID < foreign[j].ID })
+	return owned, foreign
+}
+
+// GenerateGroupMembers returns a request that creates n Droplets of the group
+// with the supplied name and parameters, at most MaxMultiCreate. The Droplets
+// are indexed from the supplied index and tagged with the tag of the group
+// and the supplied dedupe tag.
+func GenerateGroupMembers(name string, from, n int, p v1alpha1.DropletGroupParameters, dedupeTag string) *godo.DropletMultiCreateRequest {
+	if n > MaxMultiCreate {
+		n = MaxMultiCreate
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", name, from+i)
+	}
+	return &godo.DropletMultiCreateRequest{
+		Names:    names,
+		Region:   p.Region,
+		Size:     p.Size,
+		Image:    generateImage(p.Image),
+		SSHKeys:  generateSSHKeys(p.SSHKeys),
+		Tags:     append([]string{p.Tag, dedupeTag}, p.Tags...),
+		VPCUUID:  do.StringValue(p.VPCUUID),
+		UserData: do.StringValue(p.UserData),
+	}
+}
+
+// GenerateGroupObservation returns the observation of a group that manages
+// the supplied members, and whose tag is also applied to the supplied foreign
+// Droplets.
+func GenerateGroupObservation(members, foreign []godo.Droplet) v1alpha1.DropletGroupObservation {
+	o := v1alpha1.DropletGroupObservation{}
+	for _, d := range members {
+		o.MemberIDs = append(o.MemberIDs, d.ID)
+		if d.Status == v1alpha1.StatusActive {
+			o.ActiveCount++
+		}
+	}
+	for _, d := range foreign {
+		o.ForeignIDs = append(o.ForeignIDs, d.ID)
+	}
+	return o
+}
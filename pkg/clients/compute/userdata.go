@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	// MaxUserDataSize is the maximum size of the user data of a Droplet in
+	// bytes.
+	MaxUserDataSize = 64 << 10
+
+	// userDataCompressThreshold is the size above which user data is
+	// compressed if compression is enabled.
+	userDataCompressThreshold = MaxUserDataSize * 3 / 4
+
+	// userDataScriptPath is where compressed user data is installed.
+	// cloud-init runs the scripts in this directory once per instance.
+	userDataScriptPath = "/var/lib/cloud/scripts/per-instance/crossplane-user-data"
+
+	errUserDataSize      = "user data is %d bytes, which exceeds the limit of %d bytes"
+	errUserDataNotScript = "only user data that is a script starting with '#!' can be compressed"
+	errCompressUserData  = "cannot compress user data"
+)
+
+// UserData returns the user data to create the Droplet described by the
+// supplied DropletParameters with. If compression is enabled a script larger
+// than 48 KiB is gzip-compressed and wrapped in a cloud-config file that
+// installs it. An error is returned if the user data exceeds MaxUserDataSize,
+// which the API would otherwise reject without saying why.
+func UserData(p v1alpha1.DropletParameters) (string, error) {
+	data := do.StringValue(p.UserData)
+	if do.BoolValue(p.CompressUserData) && len(data) > userDataCompressThreshold {
+		if !strings.HasPrefix(data, "#!") {
+			return "", errors.New(errUserDataNotScript)
+		}
+		var err error
+		if data, err = compressUserData(data); err != nil {
+			return "", errors.Wrap(err, errCompressUserData)
+		}
+	}
+	if len(data) > MaxUserDataSize {
+		return "", errors.Errorf(errUserDataSize, len(data), MaxUserDataSize)
+	}
+	return data, nil
+}
+
+func compressUserData(script string) (string, error) {
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err := w.Write([]byte(script)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`#cloud-config
+write_files:
+- path: %s
+  permissions: '0755'
+  encoding: gz+b64
+  content: %s
+`, userDataScriptPath, base64.StdEncoding.EncodeToString(b.Bytes())), nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+)
+
+// randomScript returns a script of the supplied size that does not compress.
+func randomScript(size int) string {
+	b := make([]byte, size)
+	r := rand.New(rand.NewSource(1)) // nolint:gosec
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return "#!/bin/sh\n" + string(b)
+}
+
+// decompressUserData returns the script installed by compressed user data.
+func decompressUserData(t *testing.T, data string) string {
+	t.Helper()
+	i := strings.Index(data, "content: ")
+	if i < 0 {
+		t.Fatalf("no content in compressed user data:\n%s", data)
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data[i+len("content: "):]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(script)
+}
+
+func TestUserData(t *testing.T) {
+	large := "#!/bin/sh\n" + strings.Repeat("echo hello\n", 5000)
+	cloudConfig := "#cloud-config\n" + strings.Repeat("# comment\n", 5000)
+	oversized := randomScript(MaxUserDataSize)
+	compressed, err := compressUserData(oversized)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		data       string
+		compressed bool
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		data     *string
+		compress *bool
+		want     want
+	}{
+		"Unset": {
+			reason: "Unset user data should be empty.",
+		},
+		"WithinLimit": {
+			reason: "User data within the limit should be sent as is.",
+			data:   stringPtr(large),
+			want:   want{data: large},
+		},
+		"Oversized": {
+			reason: "User data exceeding the limit should be rejected.",
+			data:   &oversized,
+			want:   want{err: errors.Errorf(errUserDataSize, len(oversized), MaxUserDataSize)},
+		},
+		"BelowCompressThreshold": {
+			reason:   "User data below the compression threshold should not be compressed.",
+			data:     stringPtr("#!/bin/sh\necho hello\n"),
+			compress: boolPtr(true),
+			want:     want{data: "#!/bin/sh\necho hello\n"},
+		},
+		"Compressed": {
+			reason:   "A large script should be compressed into a cloud-config file that installs it.",
+			data:     stringPtr(large),
+			compress: boolPtr(true),
+			want:     want{data: large, compressed: true},
+		},
+		"CompressedCloudConfig": {
+			reason:   "Large cloud-config user data cannot be compressed.",
+			data:     &cloudConfig,
+			compress: boolPtr(true),
+			want:     want{err: errors.New(errUserDataNotScript)},
+		},
+		"OversizedAfterCompression": {
+			reason:   "User data that still exceeds the limit after compression should be rejected.",
+			data:     &oversized,
+			compress: boolPtr(true),
+			want:     want{err: errors.Errorf(errUserDataSize, len(compressed), MaxUserDataSize)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := UserData(v1alpha1.DropletParameters{UserData: tc.data, CompressUserData: tc.compress})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUserData(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.compressed {
+				if !strings.HasPrefix(data, "#cloud-config\n") {
+					t.Errorf("\n%s\nUserData(...): want a cloud-config header, got:\n%.100s", tc.reason, data)
+				}
+				if len(data) > MaxUserDataSize {
+					t.Errorf("\n%s\nUserData(...): compressed user data is %d bytes", tc.reason, len(data))
+				}
+				data = decompressUserData(t, data)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nUserData(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	errBackupScheduleEngine = "scheduling backups is only supported for pg and mysql database clusters"
+	errBackupHour           = "backup hour must be between 0 and 23, got %d"
+	errBackupMinute         = "backup minute must be between 0 and 59, got %d"
+)
+
+// DatabaseConfig is the configuration of a PostgreSQL or MySQL Database
+// Cluster. It only carries the backup schedule, which the vendored godo does
+// not support yet.
+type DatabaseConfig struct {
+	BackupHour   *int `json:"backup_hour,omitempty"`
+	BackupMinute *int `json:"backup_minute,omitempty"`
+}
+
+type databaseConfigRoot struct {
+	Config *DatabaseConfig `json:"config"`
+}
+
+func databaseConfigPath(id string) string {
+	return databasesPath + "/" + id + "/config"
+}
+
+// SchedulesBackups returns true if the backups of database clusters of the
+// supplied engine can be scheduled.
+func SchedulesBackups(engine string) bool {
+	return engine == "pg" || engine == "mysql"
+}
+
+// ListsBackups returns true if the backups of database clusters of the
+// supplied engine can be listed.
+func ListsBackups(engine string) bool {
+	return SchedulesBackups(engine) || engine == "mongodb"
+}
+
+// GetDatabaseConfig gets the configuration of the Database Cluster with the
+// supplied ID.
+func GetDatabaseConfig(ctx context.Context, c *godo.Client, id string) (*DatabaseConfig, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, databaseConfigPath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &databaseConfigRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Config, resp, nil
+}
+
+// UpdateDatabaseConfig updates the supplied fields of the configuration of
+// the Database Cluster with the supplied ID.
+func UpdateDatabaseConfig(ctx context.Context, c *godo.Client, id string, config *DatabaseConfig) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPatch, databaseConfigPath(id), &databaseConfigRoot{Config: config})
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ValidateBackupSchedule returns an error if the backup schedule of the
+// supplied DODatabaseClusterParameters cannot be configured.
+func ValidateBackupSchedule(p v1alpha1.DODatabaseClusterParameters) error {
+	s := p.BackupSchedule
+	if s == nil {
+		return nil
+	}
+	if !SchedulesBackups(do.StringValue(p.Engine)) {
+		return errors.New(errBackupScheduleEngine)
+	}
+	if s.Hour < 0 || s.Hour > 23 {
+		return errors.Errorf(errBackupHour, s.Hour)
+	}
+	if m := do.IntValue(s.Minute); m < 0 || m > 59 {
+		return errors.Errorf(errBackupMinute, m)
+	}
+	return nil
+}
+
+// GenerateBackupSchedule generates the configuration that schedules backups
+// as specified by the supplied DODatabaseClusterParameters.
+func GenerateBackupSchedule(p v1alpha1.DODatabaseClusterParameters) *DatabaseConfig {
+	hour, minute := p.BackupSchedule.Hour, do.IntValue(p.BackupSchedule.Minute)
+	return &DatabaseConfig{BackupHour: &hour, BackupMinute: &minute}
+}
+
+// BackupScheduleUpToDate returns true if the backup schedule of the supplied
+// DODatabaseClusterParameters, if any, matches the observed one.
+func BackupScheduleUpToDate(p v1alpha1.DODatabaseClusterParameters, o *v1alpha1.DODatabaseClusterBackupsObservation) bool {
+	s := p.BackupSchedule
+	if s == nil {
+		return true
+	}
+	if o == nil || o.Hour == nil {
+		return false
+	}
+	return *o.Hour == s.Hour && do.IntValue(o.Minute) == do.IntValue(s.Minute)
+}
+
+// GenerateBackupsObservation returns the observation of the supplied backups
+// and configuration of a Database Cluster. The configuration is nil for
+// engines that do not support scheduling backups.
+func GenerateBackupsObservation(config *DatabaseConfig, backups []godo.DatabaseBackup) *v1alpha1.DODatabaseClusterBackupsObservation {
+	o := &v1alpha1.DODatabaseClusterBackupsObservation{Count: len(backups)}
+	if config != nil {
+		o.Hour, o.Minute = config.BackupHour, config.BackupMinute
+	}
+	var latest, oldest time.Time
+	for i, b := range backups {
+		if i == 0 || b.CreatedAt.After(latest) {
+			latest = b.CreatedAt
+		}
+		if i == 0 || b.CreatedAt.Before(oldest) {
+			oldest = b.CreatedAt
+		}
+	}
+	if len(backups) > 0 {
+		o.LatestCreatedAt = latest.Format(time.RFC3339)
+		o.OldestCreatedAt = oldest.Format(time.RFC3339)
+	}
+	return o
+}
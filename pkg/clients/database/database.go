@@ -14,14 +14,195 @@ limitations under the License.
 package database
 
 import (
+	"context"
+	"net/http"
+	"time"
+
 	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+const (
+	databasesPath = "v2/databases"
+
+	// StorageSizeIncrementMiB is the increment in which storage can be added
+	// to a database cluster.
+	StorageSizeIncrementMiB = 10240
+
+	errStorageSizeEngine    = "additional storage is only supported for pg and mysql database clusters"
+	errStorageSizeIncrement = "storage size must be a multiple of 10240 MiB"
+	errRestoreNoSource      = "name of the database cluster to restore from is required"
+	errRestoreNoBackups     = "database cluster %q has no backups to restore from"
+	errRestoreRetention     = "backup time %s is before the oldest backup of database cluster %q at %s"
+	errRestoreFuture        = "backup time %s is in the future"
+	errKafkaNodes           = "kafka database clusters need at least %d nodes"
+)
+
+// Engines of a database cluster that publish engine specific connection
+// details.
+const (
+	EngineKafka      = "kafka"
+	EngineOpenSearch = "opensearch"
+)
+
+// MinKafkaNodes is the minimum number of nodes of a Kafka database cluster.
+const MinKafkaNodes = 3
+
+// Database represents a DigitalOcean Database Cluster. It embeds
+// godo.Database and carries the fields that the DigitalOcean API returns and
+// the vendored godo does not expose yet.
+type Database struct {
+	godo.Database
+
+	StorageSizeMiB int64 `json:"storage_size_mib,omitempty"`
+
+	// SchemaRegistryConnection is the connection to the schema registry of
+	// a Kafka database cluster.
+	SchemaRegistryConnection *godo.DatabaseConnection `json:"schema_registry_connection,omitempty"`
+
+	// UIConnection is the connection to the dashboard of an OpenSearch
+	// database cluster.
+	UIConnection *godo.DatabaseConnection `json:"ui_connection,omitempty"`
+}
+
+type databaseRoot struct {
+	Database *Database `json:"database"`
+}
+
+// DatabaseResizeRequest represents a request to resize a Database Cluster. It
+// mirrors godo.DatabaseResizeRequest and adds the storage size.
+type DatabaseResizeRequest struct {
+	SizeSlug       string `json:"size,omitempty"`
+	NumNodes       int    `json:"num_nodes,omitempty"`
+	StorageSizeMiB int64  `json:"storage_size_mib,omitempty"`
+}
+
+// DatabaseCreateRequest represents a request to create a Database Cluster. It
+// extends godo.DatabaseCreateRequest with the storage size.
+type DatabaseCreateRequest struct {
+	godo.DatabaseCreateRequest
+
+	StorageSizeMiB int64 `json:"storage_size_mib,omitempty"`
+}
+
+// GetDatabase gets the Database Cluster with the supplied ID.
+func GetDatabase(ctx context.Context, c *godo.Client, id string) (*Database, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, databasesPath+"/"+id, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &databaseRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Database, resp, nil
+}
+
+// CreateDatabase creates a Database Cluster.
+func CreateDatabase(ctx context.Context, c *godo.Client, create *DatabaseCreateRequest) (*Database, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, databasesPath, create)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &databaseRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Database, resp, nil
+}
+
+// ResizeDatabase resizes the Database Cluster with the supplied ID.
+func ResizeDatabase(ctx context.Context, c *godo.Client, id string, resize *DatabaseResizeRequest) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPut, databasesPath+"/"+id+"/resize", resize)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ValidateStorageSize returns an error if the storage size of the supplied
+// DODatabaseClusterParameters cannot be requested from DigitalOcean.
+func ValidateStorageSize(p v1alpha1.DODatabaseClusterParameters) error {
+	if p.StorageSizeMiB == nil {
+		return nil
+	}
+	if !SupportsStorageSize(do.StringValue(p.Engine)) {
+		return errors.New(errStorageSizeEngine)
+	}
+	if *p.StorageSizeMiB%StorageSizeIncrementMiB != 0 {
+		return errors.New(errStorageSizeIncrement)
+	}
+	return nil
+}
+
+// SupportsStorageSize returns true if storage can be added to database
+// clusters of the supplied engine.
+func SupportsStorageSize(engine string) bool {
+	return engine == "pg" || engine == "mysql"
+}
+
+// ValidateEngine returns an error if the supplied DODatabaseClusterParameters
+// do not satisfy the requirements of their engine.
+func ValidateEngine(p v1alpha1.DODatabaseClusterParameters) error {
+	if do.StringValue(p.Engine) == EngineKafka && p.NumNodes < MinKafkaNodes {
+		return errors.Errorf(errKafkaNodes, MinKafkaNodes)
+	}
+	return nil
+}
+
+// FirewallRuleTypeKubernetes is the type of the trusted sources of a database
+// cluster that allow the nodes of a Kubernetes cluster to connect.
+const FirewallRuleTypeKubernetes = "k8s"
+
+// TrustedSourcesUpToDate returns true if the supplied trusted sources contain
+// the desired Kubernetes cluster, if any, and no longer contain the previously
+// trusted one if it changed.
+func TrustedSourcesUpToDate(rules []godo.DatabaseFirewallRule, previous, desired string) bool {
+	if desired != "" && !trustsKubernetesCluster(rules, desired) {
+		return false
+	}
+	return previous == "" || previous == desired || !trustsKubernetesCluster(rules, previous)
+}
+
+// GenerateTrustedSources returns the supplied trusted sources with the
+// previously trusted Kubernetes cluster replaced by the desired one. All other
+// trusted sources are kept.
+func GenerateTrustedSources(rules []godo.DatabaseFirewallRule, previous, desired string) []*godo.DatabaseFirewallRule {
+	out := make([]*godo.DatabaseFirewallRule, 0, len(rules)+1)
+	for _, r := range rules {
+		if r.Type == FirewallRuleTypeKubernetes && r.Value == previous && previous != desired {
+			continue
+		}
+		out = append(out, &godo.DatabaseFirewallRule{Type: r.Type, Value: r.Value})
+	}
+	if desired != "" && !trustsKubernetesCluster(rules, desired) {
+		out = append(out, &godo.DatabaseFirewallRule{Type: FirewallRuleTypeKubernetes, Value: desired})
+	}
+	return out
+}
+
+func trustsKubernetesCluster(rules []godo.DatabaseFirewallRule, id string) bool {
+	for _, r := range rules {
+		if r.Type == FirewallRuleTypeKubernetes && r.Value == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUpToDate returns true if the mutable fields of the supplied
+// DODatabaseClusterParameters match the observed Database Cluster.
+func IsUpToDate(p v1alpha1.DODatabaseClusterParameters, observed Database) bool {
+	return p.StorageSizeMiB == nil || *p.StorageSizeMiB == observed.StorageSizeMiB
+}
+
 // GenerateDatabase generates *godo.DatabaseRequest instance from LBParameters.
-func GenerateDatabase(name string, in v1alpha1.DODatabaseClusterParameters, create *godo.DatabaseCreateRequest) {
+func GenerateDatabase(name string, in v1alpha1.DODatabaseClusterParameters, create *DatabaseCreateRequest) {
 	create.Name = name
 	create.EngineSlug = do.StringValue(in.Engine)
 	create.Version = do.StringValue(in.Version)
@@ -30,14 +211,90 @@ func GenerateDatabase(name string, in v1alpha1.DODatabaseClusterParameters, crea
 	create.Region = in.Region
 	create.PrivateNetworkUUID = do.StringValue(in.PrivateNetworkUUID)
 	create.Tags = in.Tags
+	create.BackupRestore = generateBackupRestore(in.RestoreFrom)
+	create.StorageSizeMiB = do.Int64Value(in.StorageSizeMiB)
+}
+
+func generateBackupRestore(in *v1alpha1.DODatabaseClusterRestoreFrom) *godo.DatabaseBackupRestore {
+	if in == nil {
+		return nil
+	}
+	r := &godo.DatabaseBackupRestore{DatabaseName: do.StringValue(in.SourceClusterName)}
+	if in.BackupCreatedAt != nil {
+		r.BackupCreatedAt = in.BackupCreatedAt.UTC().Format(time.RFC3339)
+	}
+	return r
+}
+
+// ValidateRestoreFrom returns an error if the backup the supplied parameters
+// restore from is not within the retention window of the supplied backups of
+// the source database cluster, i.e. between its oldest backup and now.
+func ValidateRestoreFrom(in v1alpha1.DODatabaseClusterRestoreFrom, backups []godo.DatabaseBackup, now time.Time) error {
+	name := do.StringValue(in.SourceClusterName)
+	if len(backups) == 0 {
+		return errors.Errorf(errRestoreNoBackups, name)
+	}
+	if in.BackupCreatedAt == nil {
+		return nil
+	}
+	oldest := backups[0].CreatedAt
+	for _, b := range backups[1:] {
+		if b.CreatedAt.Before(oldest) {
+			oldest = b.CreatedAt
+		}
+	}
+	t := in.BackupCreatedAt.Time
+	if t.Before(oldest) {
+		return errors.Errorf(errRestoreRetention, t.UTC().Format(time.RFC3339), name, oldest.UTC().Format(time.RFC3339))
+	}
+	if t.After(now) {
+		return errors.Errorf(errRestoreFuture, t.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// SourceClusterName returns the name of the database cluster that the
+// supplied parameters restore from.
+func SourceClusterName(in v1alpha1.DODatabaseClusterRestoreFrom) (string, error) {
+	name := do.StringValue(in.SourceClusterName)
+	if name == "" {
+		return "", errors.New(errRestoreNoSource)
+	}
+	return name, nil
+}
+
+// NeedsPasswordRotation returns true if the password of the default user of a
+// Database Cluster with the supplied parameters should be rotated, because
+// its password rotation trigger changed since the password was last rotated.
+func NeedsPasswordRotation(p v1alpha1.DODatabaseClusterParameters, o v1alpha1.DODatabaseClusterObservation) bool {
+	trigger := do.StringValue(p.PasswordRotationTrigger)
+	return trigger != "" && trigger != o.PasswordRotationTrigger
+}
+
+// GenerateResetUserAuth returns the request that rotates the password of the
+// user with the supplied name of the supplied Database Cluster. MySQL users
+// keep their authentication plugin.
+func GenerateResetUserAuth(observed Database, user string) *godo.DatabaseResetUserAuthRequest {
+	reset := &godo.DatabaseResetUserAuthRequest{}
+	for _, u := range observed.Users {
+		if u.Name == user && u.MySQLSettings != nil {
+			reset.MySQLSettings = &godo.DatabaseMySQLUserSettings{AuthPlugin: u.MySQLSettings.AuthPlugin}
+		}
+	}
+	return reset
 }
 
 // LateInitializeSpec updates any unset (i.e. nil) optional fields of the
 // supplied LBParameters that are set (i.e. non-zero) on the supplied
 // LB.
-func LateInitializeSpec(p *v1alpha1.DODatabaseClusterParameters, observed godo.Database) {
+func LateInitializeSpec(p *v1alpha1.DODatabaseClusterParameters, observed Database) {
 	p.Version = do.LateInitializeString(p.Version, observed.EngineSlug)
 	p.PrivateNetworkUUID = do.LateInitializeString(p.PrivateNetworkUUID, observed.PrivateNetworkUUID)
+	// Clusters of other engines may report a storage size, but it cannot be
+	// changed.
+	if SupportsStorageSize(do.StringValue(p.Engine)) {
+		p.StorageSizeMiB = do.LateInitializeInt64(p.StorageSizeMiB, observed.StorageSizeMiB)
+	}
 
 	if len(p.Tags) == 0 && len(observed.Tags) != 0 {
 		p.Tags = make([]string, len(observed.Tags))
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	errMigrationHost     = "host of the database to migrate from is required"
+	errMigrationPort     = "port of the database to migrate from is required"
+	errMigrationDatabase = "name of the database to migrate from is required"
+	errMigrationUsername = "username of the database to migrate from is required"
+	errMigrationPassword = "secret containing the password of the database to migrate from is required"
+)
+
+// OnlineMigration is an online migration into a Database Cluster. The
+// vendored godo does not support online migrations yet.
+type OnlineMigration struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+// OnlineMigrationSource is the database an online migration migrates from.
+type OnlineMigrationSource struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	DBName   string `json:"dbname"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// OnlineMigrationRequest represents a request to start an online migration.
+type OnlineMigrationRequest struct {
+	Source     *OnlineMigrationSource `json:"source"`
+	DisableSSL bool                   `json:"disable_ssl,omitempty"`
+	IgnoreDBs  []string               `json:"ignore_dbs,omitempty"`
+}
+
+func onlineMigrationPath(id string) string {
+	return databasesPath + "/" + id + "/online-migration"
+}
+
+// GetOnlineMigration gets the online migration that was last started into the
+// Database Cluster with the supplied ID.
+func GetOnlineMigration(ctx context.Context, c *godo.Client, id string) (*OnlineMigration, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, onlineMigrationPath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &OnlineMigration{}
+	resp, err := c.Do(ctx, req, m)
+	if err != nil {
+		return nil, resp, err
+	}
+	return m, resp, nil
+}
+
+// StartOnlineMigration starts an online migration into the Database Cluster
+// with the supplied ID.
+func StartOnlineMigration(ctx context.Context, c *godo.Client, id string, start *OnlineMigrationRequest) (*OnlineMigration, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPut, onlineMigrationPath(id), start)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &OnlineMigration{}
+	resp, err := c.Do(ctx, req, m)
+	if err != nil {
+		return nil, resp, err
+	}
+	return m, resp, nil
+}
+
+// StopOnlineMigration stops the online migration with the supplied ID into
+// the Database Cluster with the supplied ID.
+func StopOnlineMigration(ctx context.Context, c *godo.Client, id, migrationID string) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodDelete, onlineMigrationPath(id)+"/"+migrationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ValidateMigration returns an error if a connection parameter of the
+// database to migrate from is missing.
+func ValidateMigration(m v1alpha1.DODatabaseClusterMigration) error {
+	switch {
+	case m.Host == "":
+		return errors.New(errMigrationHost)
+	case m.Port == 0:
+		return errors.New(errMigrationPort)
+	case m.Database == "":
+		return errors.New(errMigrationDatabase)
+	case m.Username == "":
+		return errors.New(errMigrationUsername)
+	case m.PasswordSecretRef.Name == "" || m.PasswordSecretRef.Key == "":
+		return errors.New(errMigrationPassword)
+	}
+	return nil
+}
+
+// GenerateOnlineMigration generates a request to start the supplied online
+// migration with the supplied password.
+func GenerateOnlineMigration(m v1alpha1.DODatabaseClusterMigration, password string) *OnlineMigrationRequest {
+	return &OnlineMigrationRequest{
+		Source: &OnlineMigrationSource{
+			Host:     m.Host,
+			Port:     m.Port,
+			DBName:   m.Database,
+			Username: m.Username,
+			Password: password,
+		},
+		DisableSSL: do.BoolValue(m.DisableSSL),
+		IgnoreDBs:  m.IgnoreDBs,
+	}
+}
+
+// MigrationInProgress returns true if the supplied online migration has been
+// started and has not stopped yet.
+func MigrationInProgress(m *v1alpha1.DODatabaseClusterMigrationObservation) bool {
+	if m == nil {
+		return false
+	}
+	switch m.Status {
+	case v1alpha1.MigrationStatusCanceled, v1alpha1.MigrationStatusError, v1alpha1.MigrationStatusDone:
+		return false
+	}
+	return true
+}
+
+// NeedsMigration returns true if the supplied online migration should be
+// started into a Database Cluster whose last online migration has the
+// supplied observation. A migration is only ever started once.
+func NeedsMigration(m *v1alpha1.DODatabaseClusterMigration, observed *v1alpha1.DODatabaseClusterMigrationObservation) bool {
+	return m != nil && observed == nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// PoolUpdateRequest represents a request to update a connection pool. The
+// vendored godo cannot update connection pools yet.
+type PoolUpdateRequest struct {
+	User     string `json:"user,omitempty"`
+	Size     int    `json:"size"`
+	Database string `json:"db"`
+	Mode     string `json:"mode"`
+}
+
+// UpdatePool updates the connection pool with the supplied name of the
+// database cluster with the supplied ID.
+func UpdatePool(ctx context.Context, c *godo.Client, id, name string, update *PoolUpdateRequest) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s/pools/%s", databasesPath, id, name), update)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// GeneratePool returns a request to create a connection pool with the
+// supplied name and parameters.
+func GeneratePool(name string, p v1alpha1.DatabaseConnectionPoolParameters) *godo.DatabaseCreatePoolRequest {
+	return &godo.DatabaseCreatePoolRequest{
+		Name:     name,
+		User:     do.StringValue(p.User),
+		Size:     p.Size,
+		Database: p.Database,
+		Mode:     p.Mode,
+	}
+}
+
+// GeneratePoolUpdate returns a request to update a connection pool to the
+// supplied parameters.
+func GeneratePoolUpdate(p v1alpha1.DatabaseConnectionPoolParameters) *PoolUpdateRequest {
+	return &PoolUpdateRequest{
+		User:     do.StringValue(p.User),
+		Size:     p.Size,
+		Database: p.Database,
+		Mode:     p.Mode,
+	}
+}
+
+// GeneratePoolObservation returns the observation of the supplied connection
+// pool.
+func GeneratePoolObservation(pool godo.DatabasePool) v1alpha1.DatabaseConnectionPoolObservation {
+	return v1alpha1.DatabaseConnectionPoolObservation{
+		Name:     pool.Name,
+		Mode:     pool.Mode,
+		Size:     pool.Size,
+		Database: pool.Database,
+		User:     pool.User,
+	}
+}
+
+// LateInitializePool fills the empty fields of the supplied parameters with
+// the values of the supplied connection pool.
+func LateInitializePool(p *v1alpha1.DatabaseConnectionPoolParameters, pool godo.DatabasePool) {
+	p.User = do.LateInitializeString(p.User, pool.User)
+}
+
+// PoolIsUpToDate returns true if the supplied connection pool matches the
+// supplied parameters.
+func PoolIsUpToDate(p v1alpha1.DatabaseConnectionPoolParameters, pool godo.DatabasePool) bool {
+	return p.Mode == pool.Mode && p.Size == pool.Size && p.Database == pool.Database &&
+		(p.User == nil || *p.User == pool.User)
+}
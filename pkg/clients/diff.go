@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/google/go-cmp/cmp"
+)
+
+// serverManagedFields are the names of the fields DigitalOcean assigns to the
+// resources it creates, which are never part of their desired state.
+var serverManagedFields = map[string]bool{
+	"ID":        true,
+	"URN":       true,
+	"Created":   true,
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"CreatedBy": true,
+}
+
+// IgnoreServerManaged returns an option that ignores the struct fields
+// DigitalOcean assigns to the resources it creates, such as IDs and
+// timestamps, wherever they are nested.
+func IgnoreServerManaged() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		sf, ok := p.Last().(cmp.StructField)
+		return ok && serverManagedFields[sf.Name()]
+	}, cmp.Ignore())
+}
+
+// NeedsUpdate returns true and a human readable diff if the supplied desired
+// and observed values differ. Fields matched by the supplied options, e.g.
+// IgnoreServerManaged, are not compared. In the diff, '-' lines are observed
+// and '+' lines are desired.
+func NeedsUpdate(desired, observed interface{}, opts ...cmp.Option) (bool, string) {
+	diff := cmp.Diff(observed, desired, opts...)
+	return diff != "", diff
+}
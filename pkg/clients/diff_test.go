@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type member struct {
+	ID        string
+	CreatedAt string
+	Name      string
+}
+
+type droplet struct {
+	ID      int
+	URN     string
+	Name    string
+	Size    string
+	Members []member
+}
+
+func TestNeedsUpdate(t *testing.T) {
+	type want struct {
+		update bool
+		diff   bool
+	}
+
+	observed := droplet{
+		ID:      1,
+		URN:     "do:resource:1",
+		Name:    "example",
+		Size:    "s-1vcpu-1gb",
+		Members: []member{{ID: "m-1", CreatedAt: "2020-01-01T00:00:00Z", Name: "member"}},
+	}
+
+	cases := map[string]struct {
+		reason  string
+		desired droplet
+		opts    []cmp.Option
+		want    want
+	}{
+		"ServerManagedFieldsIgnored": {
+			reason:  "Fields assigned by DigitalOcean should not be compared when they are ignored.",
+			desired: droplet{Name: "example", Size: "s-1vcpu-1gb", Members: []member{{Name: "member"}}},
+			opts:    []cmp.Option{IgnoreServerManaged()},
+			want:    want{},
+		},
+		"ServerManagedFieldsCompared": {
+			reason:  "Fields assigned by DigitalOcean should be compared unless they are ignored.",
+			desired: droplet{Name: "example", Size: "s-1vcpu-1gb", Members: []member{{Name: "member"}}},
+			want:    want{update: true, diff: true},
+		},
+		"DesiredFieldChanged": {
+			reason:  "A change to a desired field should require an update even when server managed fields are ignored.",
+			desired: droplet{Name: "example", Size: "s-2vcpu-2gb", Members: []member{{Name: "member"}}},
+			opts:    []cmp.Option{IgnoreServerManaged()},
+			want:    want{update: true, diff: true},
+		},
+		"NestedFieldChanged": {
+			reason:  "A change to a nested desired field should require an update even when server managed fields are ignored.",
+			desired: droplet{Name: "example", Size: "s-1vcpu-1gb", Members: []member{{Name: "other"}}},
+			opts:    []cmp.Option{IgnoreServerManaged()},
+			want:    want{update: true, diff: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			update, diff := NeedsUpdate(tc.desired, observed, tc.opts...)
+			got := want{update: update, diff: diff != ""}
+			if d := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); d != "" {
+				t.Errorf("\n%s\nNeedsUpdate(...): -want, +got:\n%s\n", tc.reason, d)
+			}
+		})
+	}
+}
@@ -19,34 +19,107 @@ package clients
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/digitalocean/godo"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 )
 
+const (
+	errTeamMismatch    = "credentials belong to team %q, not to team %q"
+	errParseEndpoint   = "cannot parse endpoint"
+	errInvalidEndpoint = "endpoint %q must be an absolute http or https URL"
+)
+
+// AuthInfo is the information a controller needs to connect to the
+// DigitalOcean API on behalf of a ProviderConfig.
+type AuthInfo struct {
+	// Token to authenticate with.
+	Token string
+
+	// Team is the UUID of the team the token is scoped to, if any.
+	Team string
+
+	// Endpoint is the base URL of the DigitalOcean API. It is nil if the
+	// default one is used.
+	Endpoint *url.URL
+
+	// ProviderConfig is the name of the ProviderConfig the information was
+	// read from. The client of a ProviderConfig whose token was rotated is
+	// evicted from the ClientCache once the new token is used.
+	ProviderConfig string
+}
+
 // GetAuthInfo returns the necessary authentication information that is necessary
 // to use when the controller connects to DigitalOcean API in order to reconcile
-// the managed resource.
-func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (token string, err error) {
+// the managed resource: the token, the team it is scoped to, if any, and the
+// endpoint to reach the API at. An error is returned if the token was found to
+// belong to another team.
+func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (AuthInfo, error) {
 	pc := &v1alpha1.ProviderConfig{}
 	t := resource.NewProviderConfigUsageTracker(c, &v1alpha1.ProviderConfigUsage{})
 	if err := t.Track(ctx, mg); err != nil {
-		return "", err
+		return AuthInfo{}, err
 	}
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
-		return "", err
+		return AuthInfo{}, err
+	}
+	if err := ValidateTeam(pc.Spec.TeamID, pc.Status.TeamID); err != nil {
+		return AuthInfo{}, err
+	}
+	endpoint, err := ParseEndpoint(pc.Spec.Endpoint)
+	if err != nil {
+		return AuthInfo{}, err
+	}
+	token, err := GetProviderConfigToken(ctx, c, pc)
+	return AuthInfo{Token: token, Team: pc.Spec.TeamID, Endpoint: endpoint, ProviderConfig: pc.GetName()}, err
+}
+
+// ParseEndpoint parses the supplied base URL of the DigitalOcean API. It
+// returns nil if the endpoint is empty, i.e. the default one is used.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	if endpoint == "" {
+		return nil, nil
 	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseEndpoint)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf(errInvalidEndpoint, endpoint)
+	}
+	// godo resolves the paths of its requests relative to the base URL.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// ValidateTeam returns an error if credentials that must belong to the
+// supplied team were found to belong to another one.
+func ValidateTeam(want, got string) error {
+	if want != "" && got != "" && want != got {
+		return errors.Errorf(errTeamMismatch, got, want)
+	}
+	return nil
+}
 
+// GetProviderConfigToken returns the DigitalOcean API token referenced by the
+// supplied ProviderConfig.
+func GetProviderConfigToken(ctx context.Context, c client.Client, pc *v1alpha1.ProviderConfig) (string, error) {
 	// NOTE(muvaf): When we implement the workload identity, we will only need to
 	// return a different type of option.ClientOption, which is WithTokenSource().
 	if s := pc.Spec.Credentials.Source; s != xpv1.CredentialsSourceSecret {
@@ -127,6 +200,14 @@ func LateInitializeInt64(i *int64, from int64) *int64 {
 	return &from
 }
 
+// LateInitializeInt implements late initialization for int type.
+func LateInitializeInt(i *int, from int) *int {
+	if i != nil || from == 0 {
+		return i
+	}
+	return &from
+}
+
 // LateInitializeBool implements late initialization for bool type.
 func LateInitializeBool(b *bool, from bool) *bool {
 	if b != nil || !from {
@@ -153,15 +234,100 @@ func LateInitializeStringMap(s map[string]string, from map[string]string) map[st
 	return from
 }
 
+// AnnotationNoLateInit disables late initialization of a managed resource's
+// spec when it is set to "true", for users who manage the full spec in Git.
+const AnnotationNoLateInit = "crossplane.io/no-late-init"
+
+// ShouldLateInitialize returns false if late initialization of the supplied
+// object's spec is disabled by AnnotationNoLateInit.
+func ShouldLateInitialize(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationNoLateInit] != "true"
+}
+
+// ExternalNameAsInt returns the external-name of the supplied object as the
+// numeric ID of its external resource, and whether it is one. Resources whose
+// IDs are numbers, e.g. Droplets, have no ID until they are created: their
+// external-name is empty or a name until then.
+func ExternalNameAsInt(o metav1.Object) (int, bool) {
+	name := meta.GetExternalName(o)
+	if name == "" || strings.Trim(name, "0123456789") != "" {
+		return 0, false
+	}
+	id, err := strconv.Atoi(name)
+	return id, err == nil && id > 0
+}
+
+// PatchSpec persists the changes made to the supplied managed resource since
+// the supplied original copy of it was taken, e.g. by late initialization of
+// its spec. Only the changed fields are sent, as a merge patch that does not
+// carry the resourceVersion, so that concurrent writers of other fields do
+// not cause it to conflict.
+func PatchSpec(ctx context.Context, kube client.Client, original, mg client.Object) error {
+	return kube.Patch(ctx, mg, client.MergeFrom(original))
+}
+
 // IgnoreNotFound checks for response of DigitalOcean GET API call
 // and the content of returned error to ignore it if the response
-// is a '404 not found' error otherwise bubble up the error.
+// is a '404 not found' error otherwise bubble up the error. The error is
+// inspected as well, so a nil response is handled like IgnoreNotFoundErr.
 func IgnoreNotFound(err error, response *godo.Response) error {
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return IgnoreNotFoundErr(err)
+}
+
+// IsNotFound checks the supplied error returned by a DigitalOcean API call
+// and returns true if it is a '404 not found' error. Unlike IgnoreNotFound it
+// does not need the response, which some calls do not return.
+func IsNotFound(err error) bool {
+	var e *godo.ErrorResponse
+	return errors.As(err, &e) && e.Response != nil && e.Response.StatusCode == http.StatusNotFound
+}
+
+// IgnoreNotFoundErr ignores the supplied error if it is a '404 not found'
+// error, otherwise it bubbles up the error.
+func IgnoreNotFoundErr(err error) error {
 	if err != nil && strings.Contains(err.Error(), "is invalid because cannot be less than 1") {
 		return nil
 	}
-	if response != nil && response.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// IsLocked checks the content of the supplied error returned by a mutating
+// DigitalOcean API call and returns true if it is a '422 unprocessable entity'
+// error caused by the resource being locked while an action is in progress.
+func IsLocked(err error) bool {
+	var e *godo.ErrorResponse
+	if !errors.As(err, &e) || e.Response == nil || e.Response.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "locked") || strings.Contains(msg, "pending event")
+}
+
+// IgnoreLocked ignores the supplied error if the resource it was returned for
+// is locked by an action that is in progress, otherwise it bubbles up the
+// error. Ignoring the error lets the managed resource reconciler requeue and
+// retry the call after a short wait rather than reporting a hard failure.
+func IgnoreLocked(err error) error {
+	if IsLocked(err) {
 		return nil
 	}
 	return err
 }
+
+// IsCapacityError checks the content of the supplied error returned by a
+// create call and returns true if it is a '422 unprocessable entity' error
+// caused by the requested region being out of capacity.
+func IsCapacityError(err error) bool {
+	var e *godo.ErrorResponse
+	if !errors.As(err, &e) || e.Response == nil || e.Response.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "capacity") || strings.Contains(msg, "currently unavailable") || strings.Contains(msg, "not available in")
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+func errorResponse(status int) error {
+	return &godo.ErrorResponse{Response: &http.Response{StatusCode: status, Request: &http.Request{}}, Message: http.StatusText(status)}
+}
+
+func TestIgnoreNotFound(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason   string
+		err      error
+		response *godo.Response
+		want     error
+	}{
+		"NotFoundResponse": {
+			reason:   "A 404 response should be ignored.",
+			err:      errBoom,
+			response: &godo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}},
+		},
+		"NotFoundErrorOnly": {
+			reason: "A 404 error should be ignored even without a response.",
+			err:    errors.Wrap(errorResponse(http.StatusNotFound), "cannot get"),
+		},
+		"OtherErrorOnly": {
+			reason: "Errors other than 404 should be returned when there is no response.",
+			err:    errorResponse(http.StatusInternalServerError),
+			want:   errorResponse(http.StatusInternalServerError),
+		},
+		"TransportError": {
+			reason: "Transport errors without a response should be returned.",
+			err:    errBoom,
+			want:   errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IgnoreNotFound(tc.err, tc.response)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nIgnoreNotFound(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.response == nil {
+				if diff := cmp.Diff(tc.want, IgnoreNotFoundErr(tc.err), test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\nIgnoreNotFoundErr(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAuthInfoEndpoint(t *testing.T) {
+	// Each server stands in for the egress proxy of one ProviderConfig and
+	// records the paths of the requests it serves.
+	served := map[string][]string{}
+	endpoints := map[string]string{}
+	for name, path := range map[string]string{"team-a": "", "team-b": "/digitalocean"} {
+		name := name
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served[name] = append(served[name], r.URL.Path)
+			_, _ = w.Write([]byte(`{"account":{}}`))
+		}))
+		t.Cleanup(srv.Close)
+		endpoints[name] = srv.URL + path
+	}
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ProviderConfig:
+				o.SetName(key.Name)
+				o.Spec.Endpoint = endpoints[key.Name]
+				o.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "do-creds", Namespace: "crossplane-system"},
+					Key:             "token",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"token": []byte("token")}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	cc := NewClientCache(ClientOptions{})
+	for _, name := range []string{"team-a", "team-b"} {
+		mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: name}}}
+		mg.SetUID(types.UID(name))
+
+		a, err := GetAuthInfo(context.Background(), kube, mg)
+		if err != nil {
+			t.Fatalf("GetAuthInfo(...): %s: %v", name, err)
+		}
+		if _, _, err := GetAccount(context.Background(), cc.GetFor(a)); err != nil {
+			t.Fatalf("GetAccount(...): %s: %v", name, err)
+		}
+	}
+
+	want := map[string][]string{"team-a": {"/v2/account"}, "team-b": {"/digitalocean/v2/account"}}
+	if diff := cmp.Diff(want, served); diff != "" {
+		t.Errorf("cc.GetFor(...): want the requests of each ProviderConfig to be sent to its own endpoint: -want, +got:\n%s", diff)
+	}
+}
+
+func TestExternalNameAsInt(t *testing.T) {
+	type want struct {
+		id int
+		ok bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   want
+	}{
+		"Numeric": {
+			reason: "A numeric external-name should be the ID of the external resource.",
+			name:   "3164444",
+			want:   want{id: 3164444, ok: true},
+		},
+		"Name": {
+			reason: "An external-name that is a name should not be an ID.",
+			name:   "web-1",
+		},
+		"Empty": {
+			reason: "An empty external-name should not be an ID.",
+		},
+		"Signed": {
+			reason: "A signed number should not be an ID.",
+			name:   "+1234",
+		},
+		"Zero": {
+			reason: "Zero should not be an ID.",
+			name:   "0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			meta.SetExternalName(mg, tc.name)
+			id, ok := ExternalNameAsInt(mg)
+			if diff := cmp.Diff(tc.want, want{id: id, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nExternalNameAsInt(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseEndpoint(t *testing.T) {
+	type want struct {
+		endpoint string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		endpoint string
+		want     want
+	}{
+		"Default": {
+			reason: "An empty endpoint should mean the default one.",
+		},
+		"Proxy": {
+			reason:   "The path of an endpoint should end with a slash so that API paths are resolved relative to it.",
+			endpoint: "https://proxy.example.com/digitalocean",
+			want:     want{endpoint: "https://proxy.example.com/digitalocean/"},
+		},
+		"NotAbsolute": {
+			reason:   "An endpoint without a scheme should be rejected.",
+			endpoint: "proxy.example.com",
+			want:     want{err: errors.Errorf(errInvalidEndpoint, "proxy.example.com")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, err := ParseEndpoint(tc.endpoint)
+			got := want{err: err}
+			if u != nil {
+				got.endpoint = u.String()
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParseEndpoint(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPatchSpecConcurrentWriter(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	pc := &v1alpha1.ProviderConfig{}
+	pc.SetName("default")
+	kube := kfake.NewClientBuilder().WithScheme(s).WithObjects(pc).Build()
+
+	// The controller observes the ProviderConfig, then another writer updates
+	// it before the controller persists the late initialized spec.
+	observed := &v1alpha1.ProviderConfig{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Name: "default"}, observed); err != nil {
+		t.Fatal(err)
+	}
+	other := observed.DeepCopy()
+	other.SetLabels(map[string]string{"owner": "other"})
+	if err := kube.Update(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+
+	original := observed.DeepCopy()
+	observed.Spec.TeamID = "team-uuid"
+	if err := kube.Update(context.Background(), observed.DeepCopy()); !kerrors.IsConflict(err) {
+		t.Fatalf("kube.Update(...): want a stale update to conflict, got %v", err)
+	}
+	if err := PatchSpec(context.Background(), kube, original, observed); err != nil {
+		t.Fatalf("PatchSpec(...): %v", err)
+	}
+
+	got := &v1alpha1.ProviderConfig{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Name: "default"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.TeamID != "team-uuid" || got.GetLabels()["owner"] != "other" {
+		t.Errorf("PatchSpec(...): want the late initialized spec and the concurrent write to be kept, got team %q and labels %v", got.Spec.TeamID, got.GetLabels())
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dns contains helpers to manage DigitalOcean DNS domains and
+// records.
+package dns
+
+import (
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/dns/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// MinTTL is the lowest TTL in seconds DigitalOcean accepts for a record.
+const MinTTL = 30
+
+const (
+	errTTLTooLow    = "spec.forProvider.ttl is %d seconds, but DigitalOcean requires a TTL of at least %d seconds"
+	errNotSupported = "spec.forProvider.%s is not supported by %s records"
+	errSRVRequires  = "spec.forProvider.%s is required by SRV records"
+)
+
+// ValidateRecord returns an error if the supplied parameters would be
+// rejected by the API, or would be silently ignored by it because the type
+// of the record does not support them.
+func ValidateRecord(p v1alpha1.RecordParameters) error {
+	if p.TTL != nil && *p.TTL < MinTTL {
+		return errors.Errorf(errTTLTooLow, *p.TTL, MinTTL)
+	}
+	fields := []struct {
+		name  string
+		set   bool
+		types []string
+	}{
+		{name: "priority", set: p.Priority != nil, types: []string{"MX", "SRV"}},
+		{name: "port", set: p.Port != nil, types: []string{"SRV"}},
+		{name: "weight", set: p.Weight != nil, types: []string{"SRV"}},
+		{name: "flags", set: p.Flags != nil, types: []string{"CAA"}},
+		{name: "tag", set: p.Tag != nil, types: []string{"CAA"}},
+	}
+	for _, f := range fields {
+		if f.set && !supports(f.types, p.Type) {
+			return errors.Errorf(errNotSupported, f.name, p.Type)
+		}
+		if !f.set && p.Type == "SRV" && supports(f.types, "SRV") {
+			return errors.Errorf(errSRVRequires, f.name)
+		}
+	}
+	return nil
+}
+
+func supports(types []string, t string) bool {
+	for _, s := range types {
+		if s == t {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateRecord returns a request that creates a record with the supplied
+// parameters.
+func GenerateRecord(p v1alpha1.RecordParameters) *godo.DomainRecordEditRequest {
+	return &godo.DomainRecordEditRequest{
+		Type:     p.Type,
+		Name:     p.Name,
+		Data:     p.Data,
+		TTL:      do.IntValue(p.TTL),
+		Priority: do.IntValue(p.Priority),
+		Port:     do.IntValue(p.Port),
+		Weight:   do.IntValue(p.Weight),
+		Flags:    do.IntValue(p.Flags),
+		Tag:      do.StringValue(p.Tag),
+	}
+}
+
+// GenerateRecordUpdate returns a request that brings the supplied record in
+// line with the supplied parameters. The optional fields that are not set
+// are sent as observed. The record must be the one whose ID is the
+// external-name of the Record: several records may share its name and type,
+// and only that one is owned by it.
+func GenerateRecordUpdate(p v1alpha1.RecordParameters, observed godo.DomainRecord) *godo.DomainRecordEditRequest {
+	update := asRequest(observed)
+	update.Name = p.Name
+	update.Data = p.Data
+	if p.TTL != nil {
+		update.TTL = *p.TTL
+	}
+	if p.Priority != nil {
+		update.Priority = *p.Priority
+	}
+	if p.Port != nil {
+		update.Port = *p.Port
+	}
+	if p.Weight != nil {
+		update.Weight = *p.Weight
+	}
+	if p.Flags != nil {
+		update.Flags = *p.Flags
+	}
+	if p.Tag != nil {
+		update.Tag = *p.Tag
+	}
+	return update
+}
+
+func asRequest(r godo.DomainRecord) *godo.DomainRecordEditRequest {
+	return &godo.DomainRecordEditRequest{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     r.Data,
+		TTL:      r.TTL,
+		Priority: r.Priority,
+		Port:     r.Port,
+		Weight:   r.Weight,
+		Flags:    r.Flags,
+		Tag:      r.Tag,
+	}
+}
+
+// LateInitializeRecord fills the unset optional fields of the supplied
+// parameters from the supplied record. A record created without a TTL gets
+// the TTL of its domain, which would otherwise be reported as a change.
+func LateInitializeRecord(p *v1alpha1.RecordParameters, observed godo.DomainRecord) {
+	p.TTL = do.LateInitializeInt(p.TTL, observed.TTL)
+}
+
+// RecordDiff returns a human readable diff between the supplied record and
+// the update that brings it in line with the supplied parameters, or an
+// empty string if it is up to date. Host names are compared regardless of a
+// trailing dot.
+func RecordDiff(p v1alpha1.RecordParameters, observed godo.DomainRecord) string {
+	desired := GenerateRecordUpdate(p, observed)
+	current := asRequest(observed)
+	if strings.TrimSuffix(desired.Data, ".") == strings.TrimSuffix(current.Data, ".") {
+		desired.Data = current.Data
+	}
+	_, diff := do.NeedsUpdate(desired, current)
+	return diff
+}
+
+// GenerateRecordObservation returns the observed state of the supplied
+// record in the supplied domain.
+func GenerateRecordObservation(domain string, observed godo.DomainRecord) v1alpha1.RecordObservation {
+	return v1alpha1.RecordObservation{
+		ID:       observed.ID,
+		Domain:   domain,
+		Type:     observed.Type,
+		Name:     observed.Name,
+		Data:     observed.Data,
+		TTL:      observed.TTL,
+		Priority: observed.Priority,
+		Port:     observed.Port,
+		Weight:   observed.Weight,
+	}
+}
+
+// RecordFQDN returns the fully qualified name of the record with the
+// supplied name relative to the supplied domain, which is how records are
+// filtered by name.
+func RecordFQDN(name, domain string) string {
+	if name == "@" || name == "" {
+		return domain
+	}
+	return name + "." + domain
+}
+
+// MatchRecords returns the supplied records that have the name and type of
+// the supplied parameters. Several records may share a name and type, e.g.
+// the A records of a round robin, in which case only those that also have
+// the data of the supplied parameters are returned.
+func MatchRecords(p v1alpha1.RecordParameters, records []godo.DomainRecord) []godo.DomainRecord {
+	var named, same []godo.DomainRecord
+	for _, r := range records {
+		if r.Name != p.Name || r.Type != p.Type {
+			continue
+		}
+		named = append(named, r)
+		if strings.TrimSuffix(r.Data, ".") == strings.TrimSuffix(p.Data, ".") {
+			same = append(same, r)
+		}
+	}
+	if len(named) > 1 {
+		return same
+	}
+	return named
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake serves a fake DigitalOcean API to godo clients in tests.
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+// NewClient returns a godo client whose requests are served by the supplied
+// handler until the test finishes. Every request is answered with '404 not
+// found' if the handler is nil.
+func NewClient(t *testing.T, h http.HandlerFunc) *godo.Client {
+	t.Helper()
+	if h == nil {
+		h = http.NotFound
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := godo.NewClient(nil)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = u
+	return c
+}
+
+// Routes serves the supplied handlers keyed by request method and path, e.g.
+// "GET /v2/droplets/1234", and fails the test on any other request.
+func Routes(t *testing.T, r map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		h, ok := r[req.Method+" "+req.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		h(w, req)
+	}
+}
+
+// Respond responds to every request with the supplied body encoded as JSON.
+func Respond(t *testing.T, body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Error(err)
+		}
+	}
+}
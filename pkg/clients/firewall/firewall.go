@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package firewall contains helpers to manage DigitalOcean Firewalls.
+package firewall
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/firewall/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	protocolTCP  = "tcp"
+	protocolUDP  = "udp"
+	protocolICMP = "icmp"
+	allPorts     = "all"
+
+	errOpenInbound = "spec.forProvider.denyAllInbound is set, but inbound rule %d allows %s traffic from any address"
+)
+
+// anywhere is the destination of the rules of egress templates.
+var anywhere = v1alpha1.FirewallRuleTarget{Addresses: []string{"0.0.0.0/0", "::/0"}}
+
+// egressTemplates are the outbound rules each egress template expands into.
+var egressTemplates = map[v1alpha1.EgressTemplate][]v1alpha1.FirewallOutboundRule{
+	v1alpha1.EgressAllowDNS: {
+		{Protocol: protocolUDP, PortRange: "53", Destinations: anywhere},
+		{Protocol: protocolTCP, PortRange: "53", Destinations: anywhere},
+	},
+	v1alpha1.EgressAllowHTTP:  {{Protocol: protocolTCP, PortRange: "80", Destinations: anywhere}},
+	v1alpha1.EgressAllowHTTPS: {{Protocol: protocolTCP, PortRange: "443", Destinations: anywhere}},
+	v1alpha1.EgressAllowNTP:   {{Protocol: protocolUDP, PortRange: "123", Destinations: anywhere}},
+	v1alpha1.EgressAllowICMP:  {{Protocol: protocolICMP, Destinations: anywhere}},
+	v1alpha1.EgressAllowAll: {
+		{Protocol: protocolTCP, PortRange: allPorts, Destinations: anywhere},
+		{Protocol: protocolUDP, PortRange: allPorts, Destinations: anywhere},
+		{Protocol: protocolICMP, Destinations: anywhere},
+	},
+}
+
+// egressTemplateOrder is the order egress templates are expanded in,
+// regardless of the order they are declared in.
+var egressTemplateOrder = []v1alpha1.EgressTemplate{
+	v1alpha1.EgressAllowAll,
+	v1alpha1.EgressAllowDNS,
+	v1alpha1.EgressAllowHTTP,
+	v1alpha1.EgressAllowHTTPS,
+	v1alpha1.EgressAllowNTP,
+	v1alpha1.EgressAllowICMP,
+}
+
+// ValidateFirewall returns an error if the supplied parameters contradict
+// their posture, i.e. if they deny all inbound traffic but an inbound rule
+// allows traffic from any address.
+func ValidateFirewall(p v1alpha1.FirewallParameters) error {
+	if !do.BoolValue(p.DenyAllInbound) {
+		return nil
+	}
+	for i, r := range p.InboundRules {
+		for _, a := range r.Sources.Addresses {
+			if a == "0.0.0.0/0" || a == "::/0" {
+				return errors.Errorf(errOpenInbound, i, r.Protocol)
+			}
+		}
+	}
+	return nil
+}
+
+// OutboundRules returns the outbound rules of the supplied parameters,
+// followed by the rules of their egress templates that are not declared
+// already. Templates are expanded in a fixed order, so that the rules do not
+// depend on the order the templates are declared in.
+func OutboundRules(p v1alpha1.FirewallParameters) []v1alpha1.FirewallOutboundRule {
+	declared := make(map[v1alpha1.EgressTemplate]bool, len(p.EgressTemplates))
+	for _, t := range p.EgressTemplates {
+		declared[t] = true
+	}
+	rules := append([]v1alpha1.FirewallOutboundRule{}, p.OutboundRules...)
+	seen := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		seen[outboundKey(r)] = true
+	}
+	for _, t := range egressTemplateOrder {
+		if !declared[t] {
+			continue
+		}
+		for _, r := range egressTemplates[t] {
+			if k := outboundKey(r); !seen[k] {
+				seen[k] = true
+				rules = append(rules, r)
+			}
+		}
+	}
+	return rules
+}
+
+// outboundKey returns a key that is equal for equivalent outbound rules.
+func outboundKey(r v1alpha1.FirewallOutboundRule) string {
+	return ruleKey(strings.ToLower(r.Protocol), ports(r.Protocol, r.PortRange), normalizeTarget(generateTarget(r.Destinations)))
+}
+
+// GenerateFirewall returns a request that creates or replaces a Firewall
+// with the supplied name and parameters, including the outbound rules of
+// their egress templates.
+func GenerateFirewall(name string, p v1alpha1.FirewallParameters) *godo.FirewallRequest {
+	req := &godo.FirewallRequest{
+		Name:       name,
+		DropletIDs: p.DropletIDs,
+		Tags:       p.Tags,
+	}
+	for _, r := range p.InboundRules {
+		req.InboundRules = append(req.InboundRules, godo.InboundRule{
+			Protocol:  r.Protocol,
+			PortRange: r.PortRange,
+			Sources:   (*godo.Sources)(generateTarget(r.Sources)),
+		})
+	}
+	for _, r := range OutboundRules(p) {
+		req.OutboundRules = append(req.OutboundRules, godo.OutboundRule{
+			Protocol:     r.Protocol,
+			PortRange:    r.PortRange,
+			Destinations: (*godo.Destinations)(generateTarget(r.Destinations)),
+		})
+	}
+	return req
+}
+
+func generateTarget(t v1alpha1.FirewallRuleTarget) *godo.Sources {
+	return &godo.Sources{
+		Addresses:        t.Addresses,
+		Tags:             t.Tags,
+		DropletIDs:       t.DropletIDs,
+		LoadBalancerUIDs: t.LoadBalancerUIDs,
+		KubernetesIDs:    t.KubernetesIDs,
+	}
+}
+
+// GenerateObservation returns the observation of the supplied Firewall.
+func GenerateObservation(observed godo.Firewall) v1alpha1.FirewallObservation {
+	o := v1alpha1.FirewallObservation{
+		ID:                observed.ID,
+		Name:              observed.Name,
+		Status:            observed.Status,
+		DropletIDs:        observed.DropletIDs,
+		CreationTimestamp: observed.Created,
+	}
+	for _, c := range observed.PendingChanges {
+		o.PendingChanges = append(o.PendingChanges, v1alpha1.FirewallPendingChange{
+			DropletID: c.DropletID,
+			Removing:  c.Removing,
+			Status:    c.Status,
+		})
+	}
+	return o
+}
+
+// PendingChangesMessage returns a message describing the supplied pending
+// changes of a Firewall, e.g. to explain why it failed.
+func PendingChangesMessage(changes []v1alpha1.FirewallPendingChange) string {
+	if len(changes) == 0 {
+		return "firewall failed to apply its rules"
+	}
+	msgs := make([]string, len(changes))
+	for i, c := range changes {
+		verb := "adding"
+		if c.Removing {
+			verb = "removing"
+		}
+		msgs[i] = fmt.Sprintf("%s Droplet %d: %s", verb, c.DropletID, c.Status)
+	}
+	return "firewall failed to apply pending changes: " + strings.Join(msgs, ", ")
+}
+
+// IsUpToDate returns true if the rules, Droplet IDs and tags of the supplied
+// Firewall match the supplied parameters.
+func IsUpToDate(p v1alpha1.FirewallParameters, observed godo.Firewall) bool {
+	return Diff(p, observed) == ""
+}
+
+// Diff returns a human readable diff between the supplied Firewall and the
+// update that brings it in line with the supplied parameters.
+func Diff(p v1alpha1.FirewallParameters, observed godo.Firewall) string {
+	current := &godo.FirewallRequest{
+		Name:          observed.Name,
+		InboundRules:  observed.InboundRules,
+		OutboundRules: observed.OutboundRules,
+		DropletIDs:    observed.DropletIDs,
+		Tags:          observed.Tags,
+	}
+	_, diff := do.NeedsUpdate(normalize(GenerateFirewall(observed.Name, p)), normalize(current), cmpopts.EquateEmpty())
+	return diff
+}
+
+// normalize returns a copy of the supplied request in which equivalent rules
+// are written the same way and all lists are sorted. The API reports all
+// ports of a rule as "0" and never reports the ports of an icmp rule.
+func normalize(in *godo.FirewallRequest) *godo.FirewallRequest {
+	out := &godo.FirewallRequest{
+		Name:       in.Name,
+		DropletIDs: sortedInts(in.DropletIDs),
+		Tags:       sortedStrings(in.Tags),
+	}
+	for _, r := range in.InboundRules {
+		out.InboundRules = append(out.InboundRules, godo.InboundRule{
+			Protocol:  strings.ToLower(r.Protocol),
+			PortRange: ports(r.Protocol, r.PortRange),
+			Sources:   normalizeTarget(r.Sources),
+		})
+	}
+	sort.Slice(out.InboundRules, func(i, j int) bool {
+		a, b := out.InboundRules[i], out.InboundRules[j]
+		return ruleKey(a.Protocol, a.PortRange, a.Sources) < ruleKey(b.Protocol, b.PortRange, b.Sources)
+	})
+	for _, r := range in.OutboundRules {
+		out.OutboundRules = append(out.OutboundRules, godo.OutboundRule{
+			Protocol:     strings.ToLower(r.Protocol),
+			PortRange:    ports(r.Protocol, r.PortRange),
+			Destinations: (*godo.Destinations)(normalizeTarget((*godo.Sources)(r.Destinations))),
+		})
+	}
+	sort.Slice(out.OutboundRules, func(i, j int) bool {
+		a, b := out.OutboundRules[i], out.OutboundRules[j]
+		return ruleKey(a.Protocol, a.PortRange, (*godo.Sources)(a.Destinations)) < ruleKey(b.Protocol, b.PortRange, (*godo.Sources)(b.Destinations))
+	})
+	return out
+}
+
+// ports returns the canonical form of the supplied ports of a rule.
+func ports(protocol, ports string) string {
+	if strings.EqualFold(protocol, protocolICMP) {
+		return ""
+	}
+	switch ports {
+	case "", "0", allPorts:
+		return allPorts
+	}
+	return ports
+}
+
+func normalizeTarget(t *godo.Sources) *godo.Sources {
+	if t == nil {
+		return &godo.Sources{}
+	}
+	return &godo.Sources{
+		Addresses:        sortedStrings(t.Addresses),
+		Tags:             sortedStrings(t.Tags),
+		DropletIDs:       sortedInts(t.DropletIDs),
+		LoadBalancerUIDs: sortedStrings(t.LoadBalancerUIDs),
+		KubernetesIDs:    sortedStrings(t.KubernetesIDs),
+	}
+}
+
+// ruleKey returns a key that orders normalized rules regardless of the order
+// they were written in.
+func ruleKey(protocol, ports string, t *godo.Sources) string {
+	return fmt.Sprintf("%s %s %v", protocol, ports, *t)
+}
+
+func sortedStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
+
+func sortedInts(in []int) []int {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]int(nil), in...)
+	sort.Ints(out)
+	return out
+}
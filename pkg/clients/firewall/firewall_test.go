@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/firewall/v1alpha1"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	web := v1alpha1.FirewallRuleTarget{Addresses: []string{"0.0.0.0/0", "::/0"}}
+	p := v1alpha1.FirewallParameters{
+		InboundRules: []v1alpha1.FirewallInboundRule{
+			{Protocol: "tcp", PortRange: "443", Sources: web},
+			{Protocol: "icmp", Sources: web},
+		},
+		OutboundRules: []v1alpha1.FirewallOutboundRule{
+			{Protocol: "tcp", PortRange: "all", Destinations: web},
+		},
+		DropletIDs: []int{2, 1},
+		Tags:       []string{"web"},
+	}
+	sources := &godo.Sources{Addresses: []string{"::/0", "0.0.0.0/0"}}
+
+	cases := map[string]struct {
+		reason   string
+		observed godo.Firewall
+		want     bool
+	}{
+		"Equivalent": {
+			reason: "A Firewall whose rules and lists are reported in another order and with the ports the API reports for all ports and icmp should be up to date.",
+			observed: godo.Firewall{
+				InboundRules: []godo.InboundRule{
+					{Protocol: "icmp", PortRange: "0", Sources: sources},
+					{Protocol: "tcp", PortRange: "443", Sources: sources},
+				},
+				OutboundRules: []godo.OutboundRule{
+					{Protocol: "tcp", PortRange: "0", Destinations: (*godo.Destinations)(sources)},
+				},
+				DropletIDs: []int{1, 2},
+				Tags:       []string{"web"},
+			},
+			want: true,
+		},
+		"ChangedPorts": {
+			reason: "A Firewall whose inbound rule allows other ports should not be up to date.",
+			observed: godo.Firewall{
+				InboundRules: []godo.InboundRule{
+					{Protocol: "icmp", Sources: sources},
+					{Protocol: "tcp", PortRange: "80", Sources: sources},
+				},
+				OutboundRules: []godo.OutboundRule{
+					{Protocol: "tcp", PortRange: "all", Destinations: (*godo.Destinations)(sources)},
+				},
+				DropletIDs: []int{1, 2},
+				Tags:       []string{"web"},
+			},
+			want: false,
+		},
+		"RemovedTag": {
+			reason: "A Firewall that is not applied to a desired tag should not be up to date.",
+			observed: godo.Firewall{
+				InboundRules: []godo.InboundRule{
+					{Protocol: "icmp", Sources: sources},
+					{Protocol: "tcp", PortRange: "443", Sources: sources},
+				},
+				OutboundRules: []godo.OutboundRule{
+					{Protocol: "tcp", PortRange: "all", Destinations: (*godo.Destinations)(sources)},
+				},
+				DropletIDs: []int{1, 2},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(p, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s\nDiff(...):\n%s", tc.reason, diff, Diff(p, tc.observed))
+			}
+		})
+	}
+}
+
+func TestGenerateFirewallEgressTemplates(t *testing.T) {
+	anywhere := &godo.Destinations{Addresses: []string{"0.0.0.0/0", "::/0"}}
+	office := v1alpha1.FirewallRuleTarget{Addresses: []string{"192.0.2.0/24"}}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.FirewallParameters
+		want   []godo.OutboundRule
+	}{
+		"AllowDNS": {
+			reason: "AllowDNS should allow udp and tcp to port 53.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowDNS}},
+			want: []godo.OutboundRule{
+				{Protocol: "udp", PortRange: "53", Destinations: anywhere},
+				{Protocol: "tcp", PortRange: "53", Destinations: anywhere},
+			},
+		},
+		"AllowHTTP": {
+			reason: "AllowHTTP should allow tcp to port 80.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowHTTP}},
+			want:   []godo.OutboundRule{{Protocol: "tcp", PortRange: "80", Destinations: anywhere}},
+		},
+		"AllowHTTPS": {
+			reason: "AllowHTTPS should allow tcp to port 443.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowHTTPS}},
+			want:   []godo.OutboundRule{{Protocol: "tcp", PortRange: "443", Destinations: anywhere}},
+		},
+		"AllowNTP": {
+			reason: "AllowNTP should allow udp to port 123.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowNTP}},
+			want:   []godo.OutboundRule{{Protocol: "udp", PortRange: "123", Destinations: anywhere}},
+		},
+		"AllowICMP": {
+			reason: "AllowICMP should allow icmp.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowICMP}},
+			want:   []godo.OutboundRule{{Protocol: "icmp", Destinations: anywhere}},
+		},
+		"AllowAll": {
+			reason: "AllowAll should allow all tcp, udp and icmp traffic.",
+			p:      v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowAll}},
+			want: []godo.OutboundRule{
+				{Protocol: "tcp", PortRange: "all", Destinations: anywhere},
+				{Protocol: "udp", PortRange: "all", Destinations: anywhere},
+				{Protocol: "icmp", Destinations: anywhere},
+			},
+		},
+		"FixedOrder": {
+			reason: "Templates should be expanded in the same order regardless of the order they are declared in.",
+			p: v1alpha1.FirewallParameters{EgressTemplates: []v1alpha1.EgressTemplate{
+				v1alpha1.EgressAllowHTTPS, v1alpha1.EgressAllowDNS, v1alpha1.EgressAllowHTTPS,
+			}},
+			want: []godo.OutboundRule{
+				{Protocol: "udp", PortRange: "53", Destinations: anywhere},
+				{Protocol: "tcp", PortRange: "53", Destinations: anywhere},
+				{Protocol: "tcp", PortRange: "443", Destinations: anywhere},
+			},
+		},
+		"Deduplicated": {
+			reason: "Template rules that are declared already, by a rule or another template, should not be added again.",
+			p: v1alpha1.FirewallParameters{
+				OutboundRules: []v1alpha1.FirewallOutboundRule{
+					{Protocol: "tcp", PortRange: "443", Destinations: v1alpha1.FirewallRuleTarget{Addresses: []string{"::/0", "0.0.0.0/0"}}},
+					{Protocol: "tcp", PortRange: "22", Destinations: office},
+				},
+				EgressTemplates: []v1alpha1.EgressTemplate{v1alpha1.EgressAllowHTTPS, v1alpha1.EgressAllowICMP, v1alpha1.EgressAllowAll},
+			},
+			want: []godo.OutboundRule{
+				{Protocol: "tcp", PortRange: "443", Destinations: &godo.Destinations{Addresses: []string{"::/0", "0.0.0.0/0"}}},
+				{Protocol: "tcp", PortRange: "22", Destinations: &godo.Destinations{Addresses: []string{"192.0.2.0/24"}}},
+				{Protocol: "tcp", PortRange: "all", Destinations: anywhere},
+				{Protocol: "udp", PortRange: "all", Destinations: anywhere},
+				{Protocol: "icmp", Destinations: anywhere},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateFirewall("example", tc.p).OutboundRules
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nGenerateFirewall(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateFirewall(t *testing.T) {
+	deny := true
+	office := v1alpha1.FirewallRuleTarget{Addresses: []string{"192.0.2.0/24"}}
+	internet := v1alpha1.FirewallRuleTarget{Addresses: []string{"192.0.2.0/24", "::/0"}}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.FirewallParameters
+		want   error
+	}{
+		"OpenWithoutPosture": {
+			reason: "An inbound rule may allow traffic from any address unless all other inbound traffic is denied.",
+			p:      v1alpha1.FirewallParameters{InboundRules: []v1alpha1.FirewallInboundRule{{Protocol: "tcp", PortRange: "22", Sources: internet}}},
+		},
+		"DenyAllInboundSpecificSources": {
+			reason: "Inbound rules that allow traffic from specific sources should be valid when all other inbound traffic is denied.",
+			p: v1alpha1.FirewallParameters{
+				DenyAllInbound: &deny,
+				InboundRules:   []v1alpha1.FirewallInboundRule{{Protocol: "tcp", PortRange: "22", Sources: office}},
+			},
+		},
+		"DenyAllInboundOpen": {
+			reason: "An inbound rule that allows traffic from any address should be rejected when all other inbound traffic is denied.",
+			p: v1alpha1.FirewallParameters{
+				DenyAllInbound: &deny,
+				InboundRules: []v1alpha1.FirewallInboundRule{
+					{Protocol: "tcp", PortRange: "22", Sources: office},
+					{Protocol: "tcp", PortRange: "80", Sources: internet},
+				},
+			},
+			want: errors.Errorf(errOpenInbound, 1, "tcp"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateFirewall(tc.p)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateFirewall(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
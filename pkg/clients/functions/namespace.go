@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/functions/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	namespacesPath = "v2/functions/namespaces"
+
+	errRegionRequired    = "region of the namespace is required"
+	errRegionUnsupported = "region %q does not support Functions, use one of %s"
+)
+
+// Regions are the slugs of the regions that support Functions. The API does
+// not report which regions support Functions.
+var Regions = []string{"ams3", "blr1", "fra1", "lon1", "nyc1", "sfo3", "sgp1", "syd1", "tor1"}
+
+// Namespace represents a DigitalOcean Functions namespace. The vendored godo
+// does not support Functions yet.
+type Namespace struct {
+	ID        string `json:"namespace,omitempty"`
+	APIHost   string `json:"api_host,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Region    string `json:"region,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// NamespaceCreateRequest represents a request to create a Functions
+// namespace.
+type NamespaceCreateRequest struct {
+	Region string `json:"region"`
+	Label  string `json:"label"`
+}
+
+type namespaceRoot struct {
+	Namespace *Namespace `json:"namespace"`
+}
+
+// GetNamespace gets the Functions namespace with the supplied ID.
+func GetNamespace(ctx context.Context, c *godo.Client, id string) (*Namespace, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, namespacesPath+"/"+id, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &namespaceRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Namespace, resp, nil
+}
+
+// CreateNamespace creates a Functions namespace.
+func CreateNamespace(ctx context.Context, c *godo.Client, create *NamespaceCreateRequest) (*Namespace, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, namespacesPath, create)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &namespaceRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Namespace, resp, nil
+}
+
+// DeleteNamespace deletes the Functions namespace with the supplied ID.
+func DeleteNamespace(ctx context.Context, c *godo.Client, id string) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodDelete, namespacesPath+"/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ValidateRegion returns an error if the region of the supplied
+// FunctionNamespaceParameters does not support Functions.
+func ValidateRegion(p v1alpha1.FunctionNamespaceParameters) error {
+	if p.Region == "" {
+		return errors.New(errRegionRequired)
+	}
+	for _, r := range Regions {
+		if r == p.Region {
+			return nil
+		}
+	}
+	return errors.Errorf(errRegionUnsupported, p.Region, strings.Join(Regions, ", "))
+}
+
+// GenerateNamespace generates a NamespaceCreateRequest from the supplied
+// FunctionNamespaceParameters. The namespace is labelled with the supplied
+// name unless it has a label.
+func GenerateNamespace(name string, p v1alpha1.FunctionNamespaceParameters, create *NamespaceCreateRequest) {
+	create.Region = p.Region
+	create.Label = name
+	if p.Label != nil {
+		create.Label = *p.Label
+	}
+}
+
+// LateInitializeSpec updates any unset (i.e. nil) optional fields of the
+// supplied FunctionNamespaceParameters that are set (i.e. non-zero) on the
+// supplied Namespace.
+func LateInitializeSpec(p *v1alpha1.FunctionNamespaceParameters, observed Namespace) {
+	p.Label = do.LateInitializeString(p.Label, observed.Label)
+}
+
+// GenerateObservation generates a FunctionNamespaceObservation from the
+// supplied Namespace.
+func GenerateObservation(observed Namespace) v1alpha1.FunctionNamespaceObservation {
+	return v1alpha1.FunctionNamespaceObservation{
+		ID:        observed.ID,
+		UUID:      observed.UUID,
+		APIHost:   observed.APIHost,
+		Label:     observed.Label,
+		Region:    observed.Region,
+		CreatedAt: observed.CreatedAt,
+		UpdatedAt: observed.UpdatedAt,
+	}
+}
+
+// ImmutableFields returns whether the desired value of each immutable field
+// of the supplied FunctionNamespaceParameters matches the supplied Namespace.
+func ImmutableFields(p v1alpha1.FunctionNamespaceParameters, observed Namespace) do.ImmutableFields {
+	return do.ImmutableFields{
+		"region": p.Region == observed.Region,
+		"label":  p.Label == nil || *p.Label == observed.Label,
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeImmutableFieldChanged indicates whether the desired value of a field
+// that cannot be changed once the external resource exists differs from its
+// observed value.
+const TypeImmutableFieldChanged xpv1.ConditionType = "ImmutableFieldChanged"
+
+// Reasons immutable fields did or did not change.
+const (
+	ReasonImmutableFieldChanged   xpv1.ConditionReason = "ImmutableFieldChanged"
+	ReasonImmutableFieldUnchanged xpv1.ConditionReason = "ImmutableFieldUnchanged"
+)
+
+// ImmutableFields maps the names of the immutable fields of a managed
+// resource to whether their desired value matches the observed value.
+type ImmutableFields map[string]bool
+
+// Changed returns the sorted names of the fields whose desired value does not
+// match the observed value.
+func (f ImmutableFields) Changed() []string {
+	var changed []string
+	for name, matches := range f {
+		if !matches {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ImmutableFieldCondition returns a condition that reports whether any of the
+// supplied immutable fields were changed. Changes to immutable fields are
+// never applied to the external resource, so this makes them visible instead
+// of silently ignoring them.
+func ImmutableFieldCondition(f ImmutableFields) xpv1.Condition {
+	changed := f.Changed()
+	if len(changed) == 0 {
+		return xpv1.Condition{
+			Type:               TypeImmutableFieldChanged,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonImmutableFieldUnchanged,
+		}
+	}
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldChanged,
+		Message:            "immutable fields cannot be changed and are ignored: " + strings.Join(changed, ", "),
+	}
+}
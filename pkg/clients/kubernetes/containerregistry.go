@@ -40,9 +40,9 @@ func GenerateContainerRegistryObservation(registry *godo.Registry, subscription
 	return v1alpha1.DOContainerRegistryObservation{
 		Name:                       registry.Name,
 		Region:                     registry.Region,
-		CreatedAt:                  registry.CreatedAt.String(),
+		CreatedAt:                  do.Timestamp(registry.CreatedAt),
 		StorageUsageBytes:          registry.StorageUsageBytes,
-		StorageUsageBytesUpdatedAt: registry.StorageUsageBytesUpdatedAt.String(),
+		StorageUsageBytesUpdatedAt: do.Timestamp(registry.StorageUsageBytesUpdatedAt),
 		Subscription: v1alpha1.Subscription{
 			Tier: v1alpha1.Tier{
 				Name:                   subscription.Tier.Name,
@@ -53,8 +53,8 @@ func GenerateContainerRegistryObservation(registry *godo.Registry, subscription
 				IncludedBandwidthBytes: subscription.Tier.IncludedBandwidthBytes,
 				MonthlyPriceInCents:    subscription.Tier.MonthlyPriceInCents,
 			},
-			CreatedAt: subscription.CreatedAt.String(),
-			UpdatedAt: subscription.UpdatedAt.String(),
+			CreatedAt: do.Timestamp(subscription.CreatedAt),
+			UpdatedAt: do.Timestamp(subscription.UpdatedAt),
 		},
 	}
 }
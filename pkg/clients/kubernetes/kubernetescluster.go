@@ -14,19 +14,110 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+const (
+	kubernetesClustersPath = "v2/kubernetes/clusters"
+
+	errScaleToZeroDefaultPool = "the default node pool %q cannot be auto-scaled to zero nodes"
+	errUtilizationThreshold   = "scale down utilization threshold %v must be between 0 and 1"
+	errUnneededTime           = "scale down unneeded time %q must be a duration that is not negative"
+)
+
+// DefaultKubeconfigExpiry is how long a kubeconfig is valid for if no expiry
+// is requested.
+const DefaultKubeconfigExpiry = 7 * 24 * time.Hour
+
+// KubernetesClusterUpdateRequest represents a request to update a Kubernetes
+// cluster. It mirrors godo.KubernetesClusterUpdateRequest, but also carries the
+// fields that the DigitalOcean API accepts and the vendored godo does not expose
+// yet. SurgeUpgrade is a pointer so that disabling it is sent to the API, and
+// Tags is a pointer so that removing all tags is.
+type KubernetesClusterUpdateRequest struct {
+	Name              string                            `json:"name,omitempty"`
+	Tags              *[]string                         `json:"tags,omitempty"`
+	MaintenancePolicy *godo.KubernetesMaintenancePolicy `json:"maintenance_policy,omitempty"`
+	AutoUpgrade       *bool                             `json:"auto_upgrade,omitempty"`
+	SurgeUpgrade      *bool                             `json:"surge_upgrade,omitempty"`
+	HA                *bool                             `json:"ha,omitempty"`
+
+	ClusterAutoscalerConfiguration *ClusterAutoscalerConfiguration `json:"cluster_autoscaler_configuration,omitempty"`
+}
+
+// ClusterAutoscalerConfiguration is the cluster-level configuration of the
+// cluster autoscaler of a Kubernetes cluster, which the vendored godo does not
+// expose yet.
+type ClusterAutoscalerConfiguration struct {
+	ScaleDownUtilizationThreshold *float64 `json:"scale_down_utilization_threshold,omitempty"`
+	ScaleDownUnneededTime         *string  `json:"scale_down_unneeded_time,omitempty"`
+}
+
+type clusterAutoscalerRoot struct {
+	KubernetesCluster struct {
+		ClusterAutoscalerConfiguration *ClusterAutoscalerConfiguration `json:"cluster_autoscaler_configuration"`
+	} `json:"kubernetes_cluster"`
+}
+
+// GetClusterAutoscalerConfiguration gets the cluster autoscaler configuration
+// of the Kubernetes cluster with the supplied ID. It is nil if the API does
+// not report one.
+func GetClusterAutoscalerConfiguration(ctx context.Context, c *godo.Client, id string) (*ClusterAutoscalerConfiguration, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, kubernetesClustersPath+"/"+id, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &clusterAutoscalerRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.KubernetesCluster.ClusterAutoscalerConfiguration, resp, nil
+}
+
+// GenerateClusterAutoscalerObservation generates the observed
+// KubernetesClusterAutoscalerConfiguration from the supplied
+// ClusterAutoscalerConfiguration.
+func GenerateClusterAutoscalerObservation(observed *ClusterAutoscalerConfiguration) *v1alpha1.KubernetesClusterAutoscalerConfiguration {
+	if observed == nil {
+		return nil
+	}
+	return &v1alpha1.KubernetesClusterAutoscalerConfiguration{
+		ScaleDownUtilizationThreshold: observed.ScaleDownUtilizationThreshold,
+		ScaleDownUnneededTime:         observed.ScaleDownUnneededTime,
+	}
+}
+
+// UpdateKubernetesCluster updates the Kubernetes cluster with the supplied ID.
+func UpdateKubernetesCluster(ctx context.Context, c *godo.Client, id string, update *KubernetesClusterUpdateRequest) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPut, kubernetesClustersPath+"/"+id, update)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
 // GenerateKubernetes generates *godo.KubernetesRequest instance from DOKubernetesClusterParameters.
 func GenerateKubernetes(name string, in v1alpha1.DOKubernetesClusterParameters, create *godo.KubernetesClusterCreateRequest) {
 	create.Name = name
 	create.VersionSlug = in.Version
 	create.RegionSlug = in.Region
 	create.VPCUUID = do.StringValue(in.VPCUUID)
-	create.Tags = in.Tags
+	create.Tags = clusterTags(in)
 	create.MaintenancePolicy = &godo.KubernetesMaintenancePolicy{
 		StartTime: in.MaintenancePolicy.StartTime,
 		Day:       getDayFromParam(in.MaintenancePolicy.Day),
@@ -37,25 +128,7 @@ func GenerateKubernetes(name string, in v1alpha1.DOKubernetesClusterParameters,
 
 	create.NodePools = make([]*godo.KubernetesNodePoolCreateRequest, len(in.NodePools))
 	for i, nodePool := range in.NodePools {
-		create.NodePools[i] = &godo.KubernetesNodePoolCreateRequest{
-			Size:      nodePool.Size,
-			Name:      nodePool.Name,
-			Count:     nodePool.Count,
-			Tags:      nodePool.Tags,
-			Labels:    nodePool.Labels,
-			AutoScale: nodePool.AutoScale,
-			MinNodes:  nodePool.MinNodes,
-			MaxNodes:  nodePool.MaxNodes,
-		}
-
-		create.NodePools[i].Taints = make([]godo.Taint, len(nodePool.Taints))
-		for taintIndex, taint := range nodePool.Taints {
-			create.NodePools[i].Taints[taintIndex] = godo.Taint{
-				Key:    taint.Key,
-				Value:  taint.Value,
-				Effect: taint.Effect,
-			}
-		}
+		create.NodePools[i] = generateNodePoolCreate(nodePool, NodePoolTags(in, nodePool))
 	}
 }
 
@@ -85,8 +158,339 @@ func getDayFromParam(day string) godo.KubernetesMaintenancePolicyDay {
 // Kubernetes Cluster.
 func LateInitializeSpec(p *v1alpha1.DOKubernetesClusterParameters, observed godo.KubernetesCluster) {
 	p.VPCUUID = do.LateInitializeString(p.VPCUUID, observed.VPCUUID)
-	p.Tags = do.LateInitializeStringSlice(p.Tags, observed.Tags)
+	if tags := withoutDefaultTags(observed.Tags); p.Tags == nil && len(tags) != 0 {
+		p.Tags = &tags
+	}
 	p.AutoUpgrade = do.LateInitializeBool(p.AutoUpgrade, observed.AutoUpgrade)
 	p.SurgeUpgrade = do.LateInitializeBool(p.SurgeUpgrade, observed.SurgeUpgrade)
 	p.HighlyAvailable = do.LateInitializeBool(p.HighlyAvailable, observed.HA)
 }
+
+// IsUpToDate returns true if the mutable fields of the supplied
+// DOKubernetesClusterParameters match the observed Kubernetes Cluster.
+func IsUpToDate(p v1alpha1.DOKubernetesClusterParameters, observed godo.KubernetesCluster) bool {
+	if p.SurgeUpgrade != nil && *p.SurgeUpgrade != observed.SurgeUpgrade {
+		return false
+	}
+	if p.HighlyAvailable != nil && *p.HighlyAvailable != observed.HA {
+		return false
+	}
+	if p.Tags != nil && !tagsEqual(*p.Tags, withoutDefaultTags(observed.Tags)) {
+		return false
+	}
+	return true
+}
+
+// GenerateKubernetesUpdate generates a KubernetesClusterUpdateRequest that
+// brings the observed Kubernetes Cluster in line with the supplied
+// DOKubernetesClusterParameters. A highly available control plane can only be
+// enabled, so HA is only sent when it is requested and not yet enabled.
+func GenerateKubernetesUpdate(p v1alpha1.DOKubernetesClusterParameters, observed v1alpha1.DOKubernetesClusterObservation) *KubernetesClusterUpdateRequest {
+	update := &KubernetesClusterUpdateRequest{
+		Name:         observed.Name,
+		Tags:         p.Tags,
+		SurgeUpgrade: p.SurgeUpgrade,
+	}
+	if do.BoolValue(p.HighlyAvailable) && !observed.HighlyAvailable {
+		update.HA = p.HighlyAvailable
+	}
+	if a := p.ClusterAutoscalerConfiguration; a != nil {
+		update.ClusterAutoscalerConfiguration = &ClusterAutoscalerConfiguration{
+			ScaleDownUtilizationThreshold: a.ScaleDownUtilizationThreshold,
+			ScaleDownUnneededTime:         canonicalDuration(a.ScaleDownUnneededTime),
+		}
+	}
+	return update
+}
+
+// IsClusterAutoscalerUpToDate returns true if the cluster autoscaler
+// configuration of the supplied DOKubernetesClusterParameters matches the
+// observed configuration. Fields that are not set in the parameters are not
+// compared.
+func IsClusterAutoscalerUpToDate(p v1alpha1.DOKubernetesClusterParameters, observed *v1alpha1.KubernetesClusterAutoscalerConfiguration) bool {
+	desired := p.ClusterAutoscalerConfiguration
+	if desired == nil {
+		return true
+	}
+	if observed == nil {
+		observed = &v1alpha1.KubernetesClusterAutoscalerConfiguration{}
+	}
+	if desired.ScaleDownUtilizationThreshold != nil && (observed.ScaleDownUtilizationThreshold == nil || *desired.ScaleDownUtilizationThreshold != *observed.ScaleDownUtilizationThreshold) {
+		return false
+	}
+	if desired.ScaleDownUnneededTime != nil && do.StringValue(canonicalDuration(desired.ScaleDownUnneededTime)) != do.StringValue(canonicalDuration(observed.ScaleDownUnneededTime)) {
+		return false
+	}
+	return true
+}
+
+// ValidateClusterAutoscaler returns an error if the cluster autoscaler
+// configuration of the supplied DOKubernetesClusterParameters is outside of
+// the ranges DigitalOcean accepts.
+func ValidateClusterAutoscaler(p v1alpha1.DOKubernetesClusterParameters) error {
+	a := p.ClusterAutoscalerConfiguration
+	if a == nil {
+		return nil
+	}
+	if t := a.ScaleDownUtilizationThreshold; t != nil && (*t < 0 || *t > 1) {
+		return errors.Errorf(errUtilizationThreshold, *t)
+	}
+	if u := a.ScaleDownUnneededTime; u != nil {
+		if d, err := time.ParseDuration(*u); err != nil || d < 0 {
+			return errors.Errorf(errUnneededTime, *u)
+		}
+	}
+	return nil
+}
+
+// canonicalDuration returns the supplied duration in the form the API reports
+// it, e.g. '1m0s' for '1m'. Durations that cannot be parsed are returned as is.
+func canonicalDuration(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return s
+	}
+	c := d.String()
+	return &c
+}
+
+// Diff returns a human readable diff between the supplied Kubernetes Cluster
+// and the update that brings it in line with the supplied
+// DOKubernetesClusterParameters. Fields that are not set in the parameters
+// are not compared.
+func Diff(p v1alpha1.DOKubernetesClusterParameters, observed godo.KubernetesCluster, o v1alpha1.DOKubernetesClusterObservation) string {
+	tags := withoutDefaultTags(observed.Tags)
+	current := &KubernetesClusterUpdateRequest{
+		Name:         observed.Name,
+		Tags:         &tags,
+		SurgeUpgrade: &observed.SurgeUpgrade,
+	}
+	desired := GenerateKubernetesUpdate(p, o)
+	if desired.Tags == nil {
+		desired.Tags = current.Tags
+	}
+	if desired.SurgeUpgrade == nil {
+		desired.SurgeUpgrade = current.SurgeUpgrade
+	}
+	if a := o.ClusterAutoscalerConfiguration; a != nil {
+		current.ClusterAutoscalerConfiguration = &ClusterAutoscalerConfiguration{
+			ScaleDownUtilizationThreshold: a.ScaleDownUtilizationThreshold,
+			ScaleDownUnneededTime:         canonicalDuration(a.ScaleDownUnneededTime),
+		}
+	}
+	switch d, cur := desired.ClusterAutoscalerConfiguration, current.ClusterAutoscalerConfiguration; {
+	case d == nil:
+		desired.ClusterAutoscalerConfiguration = cur
+	case cur != nil:
+		if d.ScaleDownUtilizationThreshold == nil {
+			d.ScaleDownUtilizationThreshold = cur.ScaleDownUtilizationThreshold
+		}
+		if d.ScaleDownUnneededTime == nil {
+			d.ScaleDownUnneededTime = cur.ScaleDownUnneededTime
+		}
+	}
+	_, diff := do.NeedsUpdate(desired, current, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+	return diff
+}
+
+// DefaultNodePool returns the observed node pool that corresponds to the
+// default (i.e. first) node pool of the supplied DOKubernetesClusterParameters.
+// Pools are matched by name so that pools managed outside of the cluster are
+// never mistaken for the default pool. It returns nil if there is no default
+// pool or it cannot be found.
+func DefaultNodePool(p v1alpha1.DOKubernetesClusterParameters, observed []v1alpha1.KubernetesNodePoolObservation) *v1alpha1.KubernetesNodePoolObservation {
+	if len(p.NodePools) == 0 {
+		return nil
+	}
+	for i := range observed {
+		if observed[i].Name == p.NodePools[0].Name {
+			return &observed[i]
+		}
+	}
+	return nil
+}
+
+// GenerateDefaultNodePoolUpdate generates a godo.KubernetesNodePoolUpdateRequest
+// that brings the observed default node pool in line with the supplied
+// DOKubernetesClusterParameters. The count is left to the autoscaler when
+// autoscaling is enabled. It returns nil if the default pool is up to date or
+// cannot be found.
+func GenerateDefaultNodePoolUpdate(p v1alpha1.DOKubernetesClusterParameters, observed []v1alpha1.KubernetesNodePoolObservation) (string, *godo.KubernetesNodePoolUpdateRequest) {
+	pool := DefaultNodePool(p, observed)
+	if pool == nil {
+		return "", nil
+	}
+	return pool.ID, generateNodePoolUpdate(p.NodePools[0], NodePoolTags(p, p.NodePools[0]), *pool)
+}
+
+// ValidateNodePools returns an error if the default (i.e. first) node pool
+// of the supplied DOKubernetesClusterParameters may be auto-scaled to zero
+// nodes, which DigitalOcean only allows for the other pools.
+func ValidateNodePools(p v1alpha1.DOKubernetesClusterParameters) error {
+	if len(p.NodePools) == 0 {
+		return nil
+	}
+	if pool := p.NodePools[0]; pool.AutoScale && pool.MinNodes == 0 {
+		return errors.Errorf(errScaleToZeroDefaultPool, pool.Name)
+	}
+	return nil
+}
+
+func isScaleUpToDate(desired v1alpha1.KubernetesNodePool, observed v1alpha1.KubernetesNodePoolObservation) bool {
+	if desired.AutoScale != observed.AutoScale {
+		return false
+	}
+	if desired.AutoScale {
+		return desired.MinNodes == observed.MinNodes && desired.MaxNodes == observed.MaxNodes
+	}
+	return desired.Count == observed.Count
+}
+
+// NodePoolTags returns the tags that should be applied to the supplied node
+// pool. The cluster's tags are added to them if tag propagation is enabled.
+func NodePoolTags(p v1alpha1.DOKubernetesClusterParameters, pool v1alpha1.KubernetesNodePool) []string {
+	if !do.BoolValue(p.PropagateTags) {
+		return pool.Tags
+	}
+	tags := append([]string{}, pool.Tags...)
+	for _, t := range clusterTags(p) {
+		if !contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// clusterTags returns the tags of the supplied DOKubernetesClusterParameters,
+// or nil if they are not set.
+func clusterTags(p v1alpha1.DOKubernetesClusterParameters) []string {
+	if p.Tags == nil {
+		return nil
+	}
+	return *p.Tags
+}
+
+// isDefaultTag returns true if the supplied tag is one of the tags that
+// DigitalOcean automatically applies to all clusters and node pools.
+func isDefaultTag(t string) bool {
+	return t == "k8s" || t == "k8s-worker" || strings.HasPrefix(t, "k8s:")
+}
+
+func withoutDefaultTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if !isDefaultTag(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// tagsEqual returns true if the supplied tags contain the same tags,
+// regardless of their order.
+func tagsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, t := range a {
+		if !contains(b, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(tags []string, t string) bool {
+	for _, c := range tags {
+		if c == t {
+			return true
+		}
+	}
+	return false
+}
+
+// KubeconfigExpiry returns how long a kubeconfig requested for the supplied
+// parameters is valid for.
+func KubeconfigExpiry(p v1alpha1.DOKubernetesClusterParameters) time.Duration {
+	if p.KubeconfigExpirySeconds == nil {
+		return DefaultKubeconfigExpiry
+	}
+	return time.Duration(*p.KubeconfigExpirySeconds) * time.Second
+}
+
+// KubeconfigNeedsRefresh returns true if a kubeconfig or token that expires at
+// the supplied time should be requested again. Credentials are refreshed once
+// less than a quarter of their lifetime remains, so that they are re-published
+// well before they expire.
+func KubeconfigNeedsRefresh(p v1alpha1.DOKubernetesClusterParameters, expiresAt *metav1.Time, now time.Time) bool {
+	if expiresAt == nil {
+		return true
+	}
+	return expiresAt.Sub(now) < KubeconfigExpiry(p)/4
+}
+
+// GenerateNodeHealth aggregates the health of the worker nodes of the
+// supplied node pools.
+func GenerateNodeHealth(pools []v1alpha1.KubernetesNodePoolObservation) v1alpha1.KubernetesNodeHealth {
+	h := v1alpha1.KubernetesNodeHealth{}
+	for _, pool := range pools {
+		for _, n := range pool.Nodes {
+			h.Total++
+			switch {
+			case n.Status.State == v1alpha1.NodeStateRunning:
+				h.Ready++
+			case n.Status.State == v1alpha1.NodeStateProvisioning:
+				h.Provisioning++
+			case nodeDegraded(n.Status.State):
+				h.Degraded++
+			}
+			h.Nodes = append(h.Nodes, v1alpha1.KubernetesNodeHealthStatus{
+				Name:      n.Name,
+				NodePool:  pool.Name,
+				DropletID: n.DropletID,
+				State:     n.Status.State,
+				Message:   n.Status.Message,
+			})
+		}
+	}
+	return h
+}
+
+// ReadyCondition returns the Ready condition of a Kubernetes Cluster with the
+// supplied status whose worker nodes have the supplied health, and whether
+// its status determines one. A running cluster is degraded if any of its
+// nodes is, while nodes that are still being provisioned, e.g. because a
+// node pool is scaled up, leave it available.
+func ReadyCondition(status v1alpha1.KubernetesStatus, h v1alpha1.KubernetesNodeHealth) (xpv1.Condition, bool) {
+	switch status.State {
+	case v1alpha1.StatusProvisioning:
+		return xpv1.Creating(), true
+	case v1alpha1.StatusDegraded, v1alpha1.StatusError:
+		return v1alpha1.Degraded(status.Message), true
+	case v1alpha1.StatusRunning:
+		if h.Degraded == 0 {
+			return xpv1.Available(), true
+		}
+		var names []string
+		for _, n := range h.Nodes {
+			if nodeDegraded(n.State) {
+				names = append(names, n.Name)
+			}
+		}
+		return v1alpha1.Degraded(fmt.Sprintf("%d of %d nodes are degraded: %s", h.Degraded, h.Total, strings.Join(names, ", "))), true
+	}
+	return xpv1.Condition{}, false
+}
+
+// nodeDegraded returns true if a worker node in the supplied state is
+// degraded, i.e. neither running, being provisioned, drained nor deleted.
+func nodeDegraded(state string) bool {
+	switch state {
+	case v1alpha1.NodeStateRunning, v1alpha1.NodeStateProvisioning, v1alpha1.NodeStateDraining, v1alpha1.NodeStateDeleting:
+		return false
+	}
+	return true
+}
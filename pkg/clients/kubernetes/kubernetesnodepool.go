@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/digitalocean/godo"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// DefaultNodePoolName returns the name of the default (i.e. first) node pool
+// of the supplied DOKubernetesClusterParameters, or an empty string if it has
+// no node pools.
+func DefaultNodePoolName(p v1alpha1.DOKubernetesClusterParameters) string {
+	if len(p.NodePools) == 0 {
+		return ""
+	}
+	return p.NodePools[0].Name
+}
+
+// GenerateNodePool generates the godo.KubernetesNodePoolCreateRequest that
+// adds the node pool of the supplied DOKubernetesNodePoolParameters to its
+// cluster.
+func GenerateNodePool(p v1alpha1.DOKubernetesNodePoolParameters) *godo.KubernetesNodePoolCreateRequest {
+	return generateNodePoolCreate(p.KubernetesNodePool, p.Tags)
+}
+
+// GenerateNodePoolUpdate generates a godo.KubernetesNodePoolUpdateRequest that
+// brings the observed node pool in line with the supplied
+// DOKubernetesNodePoolParameters. It returns nil if the node pool is up to
+// date.
+func GenerateNodePoolUpdate(p v1alpha1.DOKubernetesNodePoolParameters, observed v1alpha1.KubernetesNodePoolObservation) *godo.KubernetesNodePoolUpdateRequest {
+	return generateNodePoolUpdate(p.KubernetesNodePool, p.Tags, observed)
+}
+
+// GenerateNodePoolObservation generates the KubernetesNodePoolObservation of
+// the supplied node pool.
+func GenerateNodePoolObservation(observed godo.KubernetesNodePool) v1alpha1.KubernetesNodePoolObservation {
+	o := v1alpha1.KubernetesNodePoolObservation{
+		ID:        observed.ID,
+		Size:      observed.Size,
+		Name:      observed.Name,
+		Count:     observed.Count,
+		Tags:      observed.Tags,
+		Labels:    observed.Labels,
+		AutoScale: observed.AutoScale,
+		MinNodes:  observed.MinNodes,
+		MaxNodes:  observed.MaxNodes,
+	}
+
+	o.Taints = make([]v1alpha1.KubernetesNodePoolTaint, len(observed.Taints))
+	for i, taint := range observed.Taints {
+		o.Taints[i] = v1alpha1.KubernetesNodePoolTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: taint.Effect,
+		}
+	}
+
+	o.Nodes = make([]v1alpha1.KubernetesNode, len(observed.Nodes))
+	for i, node := range observed.Nodes {
+		o.Nodes[i] = v1alpha1.KubernetesNode{
+			ID:   node.ID,
+			Name: node.Name,
+			Status: v1alpha1.KubernetesStatus{
+				State:   node.Status.State,
+				Message: node.Status.Message,
+			},
+			DropletID: node.DropletID,
+			CreatedAt: do.Timestamp(node.CreatedAt),
+			UpdatedAt: do.Timestamp(node.UpdatedAt),
+		}
+	}
+	return o
+}
+
+// NodePoolReadyCondition returns the Ready condition of the supplied node
+// pool. A node pool is degraded if any of its nodes is, and is being created
+// until one of its nodes is running, unless it was auto-scaled to zero nodes.
+func NodePoolReadyCondition(o v1alpha1.KubernetesNodePoolObservation) xpv1.Condition {
+	h := GenerateNodeHealth([]v1alpha1.KubernetesNodePoolObservation{o})
+	switch {
+	case h.Degraded > 0:
+		return v1alpha1.Degraded(fmt.Sprintf("%d of %d nodes are degraded", h.Degraded, h.Total))
+	case h.Ready == 0 && o.Count > 0:
+		return xpv1.Creating()
+	}
+	return xpv1.Available()
+}
+
+func generateNodePoolCreate(pool v1alpha1.KubernetesNodePool, tags []string) *godo.KubernetesNodePoolCreateRequest {
+	create := &godo.KubernetesNodePoolCreateRequest{
+		Size:      pool.Size,
+		Name:      pool.Name,
+		Count:     pool.Count,
+		Tags:      tags,
+		Labels:    pool.Labels,
+		AutoScale: pool.AutoScale,
+		MinNodes:  pool.MinNodes,
+		MaxNodes:  pool.MaxNodes,
+	}
+
+	create.Taints = make([]godo.Taint, len(pool.Taints))
+	for i, taint := range pool.Taints {
+		create.Taints[i] = godo.Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: taint.Effect,
+		}
+	}
+	return create
+}
+
+// generateNodePoolUpdate generates the update that applies the supplied
+// scale and tags to the supplied observed node pool. The count is left to the
+// autoscaler when autoscaling is enabled. It returns nil if the pool is up to
+// date.
+func generateNodePoolUpdate(desired v1alpha1.KubernetesNodePool, tags []string, observed v1alpha1.KubernetesNodePoolObservation) *godo.KubernetesNodePoolUpdateRequest {
+	if isScaleUpToDate(desired, observed) && tagsEqual(tags, withoutDefaultTags(observed.Tags)) {
+		return nil
+	}
+
+	update := &godo.KubernetesNodePoolUpdateRequest{
+		Name:      observed.Name,
+		Tags:      tags,
+		AutoScale: &desired.AutoScale,
+	}
+	if desired.AutoScale {
+		update.MinNodes = &desired.MinNodes
+		update.MaxNodes = &desired.MaxNodes
+	} else {
+		update.Count = &desired.Count
+	}
+	return update
+}
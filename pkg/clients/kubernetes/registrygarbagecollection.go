@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// Statuses of a garbage collection run reported by the DigitalOcean API.
+const (
+	GCStatusRequested            = "requested"
+	GCStatusWaitingForJWTs       = "waiting for write JWTs to expire"
+	GCStatusScanningManifests    = "scanning manifests"
+	GCStatusDeletingUnreferenced = "deleting unreferenced blobs"
+	GCStatusCancelling           = "cancelling"
+	GCStatusSucceeded            = "succeeded"
+	GCStatusFailed               = "failed"
+	GCStatusCancelled            = "cancelled"
+)
+
+// GarbageCollectionPhase returns the phase of a garbage collection run with the
+// supplied status. A run is blocked while it waits for the registry to stop
+// accepting writes.
+func GarbageCollectionPhase(status string) string {
+	switch status {
+	case GCStatusRequested:
+		return v1alpha1.GarbageCollectionRequested
+	case GCStatusWaitingForJWTs:
+		return v1alpha1.GarbageCollectionBlocked
+	case GCStatusSucceeded, GCStatusFailed, GCStatusCancelled:
+		return v1alpha1.GarbageCollectionCompleted
+	case "":
+		return ""
+	}
+	return v1alpha1.GarbageCollectionRunning
+}
+
+// GenerateGarbageCollection generates *godo.StartGarbageCollectionRequest
+// instance from RegistryGarbageCollectionParameters.
+func GenerateGarbageCollection(in v1alpha1.RegistryGarbageCollectionParameters) *godo.StartGarbageCollectionRequest {
+	gcType := godo.GCTypeUnreferencedBlobsOnly
+	if in.Type != nil {
+		gcType = godo.GarbageCollectionType(*in.Type)
+	}
+	return &godo.StartGarbageCollectionRequest{Type: gcType}
+}
+
+// GenerateGarbageCollectionObservation generates
+// RegistryGarbageCollectionObservation instance from godo.GarbageCollection.
+func GenerateGarbageCollectionObservation(gc *godo.GarbageCollection) v1alpha1.RegistryGarbageCollectionObservation {
+	return v1alpha1.RegistryGarbageCollectionObservation{
+		UUID:         gc.UUID,
+		RegistryName: gc.RegistryName,
+		Status:       gc.Status,
+		Phase:        GarbageCollectionPhase(gc.Status),
+		Type:         string(gc.Type),
+		CreatedAt:    do.Timestamp(gc.CreatedAt),
+		UpdatedAt:    do.Timestamp(gc.UpdatedAt),
+		BlobsDeleted: gc.BlobsDeleted,
+		FreedBytes:   gc.FreedBytes,
+	}
+}
+
+// GetGarbageCollection gets the garbage collection run with the supplied UUID
+// in the supplied registry. The active run is checked first, since it is the
+// one usually being tracked, before the runs that already ended are listed. A
+// nil run is returned if there is none with the supplied UUID.
+func GetGarbageCollection(ctx context.Context, c *godo.Client, registry, uuid string) (*godo.GarbageCollection, error) {
+	active, resp, err := c.Registry.GetGarbageCollection(ctx, registry)
+	if do.IgnoreNotFound(err, resp) != nil {
+		return nil, err
+	}
+	if err == nil && active != nil && active.UUID == uuid {
+		return active, nil
+	}
+
+	opts := &godo.ListOptions{PerPage: 200}
+	for {
+		page, resp, err := c.Registry.ListGarbageCollections(ctx, registry, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, gc := range page {
+			if gc.UUID == uuid {
+				return gc, nil
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		current, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = current + 1
+	}
+}
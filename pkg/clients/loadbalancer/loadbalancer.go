@@ -17,21 +17,471 @@ limitations under the License.
 package loadbalancer
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 )
 
+const (
+	loadBalancersPath = "v2/load_balancers"
+
+	errFirewallAllowAndDeny = "only one of allow and deny may be set in the firewall of a LoadBalancer"
+	errTagAndDropletIDs     = "only one of dropletIds or dropletRefs and tag may be set on a LoadBalancer"
+	errLBErrored            = "load balancer %q errored"
+	errVPCImmutable         = "the VPC of a LoadBalancer cannot be changed once it is created: it is in VPC %q, not %q"
+
+	errRuleTargetProtocol   = "forwarding rule %d: entry protocol %q cannot forward to target protocol %q"
+	errRuleNoCertificate    = "forwarding rule %d: entry protocol %q requires a certificateId or tlsPassthrough"
+	errRuleHTTP3Certificate = "forwarding rule %d: entry protocol http3 requires a certificateId"
+	errRuleCertAndTLS       = "forwarding rule %d: only one of certificateId and tlsPassthrough may be set"
+	errRuleCertificate      = "forwarding rule %d: a certificateId requires an https, http2 or http3 entry protocol"
+	errRuleTLSPassthrough   = "forwarding rule %d: tlsPassthrough requires https or http2 as both the entry and target protocol"
+	errRuleDuplicatePort    = "forwarding rules %d and %d both listen on %s port %d"
+
+	errPoolNoName         = "pool %d: a name is required"
+	errPoolDuplicateName  = "pools %d and %d are both named %q"
+	errPoolTagAndDroplets = "pool %q: only one of dropletIds or dropletRefs and tag may be set"
+	errPoolTags           = "a LoadBalancer selects Droplets by a single tag, but %s and %s select different tags"
+	errPoolHealthChecks   = "a LoadBalancer supports a single health check, but %s and %s define different ones"
+)
+
+// statusErrored is the status of a load balancer that could not be created or
+// updated.
+const statusErrored = "errored"
+
+// Polling intervals of WaitForActive. The interval doubles after every poll
+// until it reaches the maximum.
+var (
+	activePollInterval    = 1 * time.Second
+	activePollMaxInterval = 8 * time.Second
+)
+
+// Protocols of forwarding rules.
+const (
+	ProtocolHTTP  = "http"
+	ProtocolHTTPS = "https"
+	ProtocolHTTP2 = "http2"
+	ProtocolHTTP3 = "http3"
+	ProtocolTCP   = "tcp"
+	ProtocolUDP   = "udp"
+)
+
+// LoadBalancer is a DigitalOcean load balancer. It extends godo.LoadBalancer
+// with the firewall, which the vendored godo does not expose yet.
+type LoadBalancer struct {
+	godo.LoadBalancer
+	Firewall *Firewall `json:"firewall,omitempty"`
+}
+
+// LoadBalancerRequest represents a request to create or update a load
+// balancer. It extends godo.LoadBalancerRequest with the firewall, which the
+// vendored godo does not expose yet.
+type LoadBalancerRequest struct {
+	godo.LoadBalancerRequest
+	Firewall *Firewall `json:"firewall,omitempty"`
+}
+
+// Firewall is the firewall of a load balancer.
+type Firewall struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type loadBalancerRoot struct {
+	LoadBalancer *LoadBalancer `json:"load_balancer"`
+}
+
+// GetLoadBalancer gets the load balancer with the supplied ID.
+func GetLoadBalancer(ctx context.Context, c *godo.Client, id string) (*LoadBalancer, *godo.Response, error) {
+	return doLoadBalancer(ctx, c, http.MethodGet, loadBalancersPath+"/"+id, nil)
+}
+
+// CreateLoadBalancer creates a load balancer.
+func CreateLoadBalancer(ctx context.Context, c *godo.Client, create *LoadBalancerRequest) (*LoadBalancer, *godo.Response, error) {
+	return doLoadBalancer(ctx, c, http.MethodPost, loadBalancersPath, create)
+}
+
+// UpdateLoadBalancer updates the load balancer with the supplied ID.
+func UpdateLoadBalancer(ctx context.Context, c *godo.Client, id string, update *LoadBalancerRequest) (*LoadBalancer, *godo.Response, error) {
+	return doLoadBalancer(ctx, c, http.MethodPut, loadBalancersPath+"/"+id, update)
+}
+
+// WaitForActive polls the load balancer with the supplied ID until it is
+// active, backing off between polls, so that an update is only reported as
+// done once it was applied to all forwarding rules. It returns an error if
+// the load balancer errored, and the context's error if it is done first.
+func WaitForActive(ctx context.Context, c *godo.Client, id string) (*LoadBalancer, error) {
+	interval := activePollInterval
+	for {
+		lb, _, err := GetLoadBalancer(ctx, c, id)
+		if err != nil {
+			return nil, err
+		}
+		switch lb.Status {
+		case v1alpha1.StatusActive:
+			return lb, nil
+		case statusErrored:
+			return lb, errors.Errorf(errLBErrored, id)
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return lb, ctx.Err()
+		case <-t.C:
+		}
+		if interval *= 2; interval > activePollMaxInterval {
+			interval = activePollMaxInterval
+		}
+	}
+}
+
+func doLoadBalancer(ctx context.Context, c *godo.Client, method, path string, body interface{}) (*LoadBalancer, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &loadBalancerRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.LoadBalancer, resp, nil
+}
+
+// ValidateFirewall returns an error if both the allow and deny lists of the
+// firewall of the supplied LBParameters are set.
+func ValidateFirewall(p v1alpha1.LBParameters) error {
+	if p.Firewall != nil && len(p.Firewall.Allow) > 0 && len(p.Firewall.Deny) > 0 {
+		return errors.New(errFirewallAllowAndDeny)
+	}
+	return nil
+}
+
+// ValidateMembership returns an error if both the Droplet IDs or references
+// and the tag of the supplied LBParameters are set.
+func ValidateMembership(p v1alpha1.LBParameters) error {
+	if p.Tag != nil && (len(p.DropletIDs) > 0 || len(p.DropletRefs) > 0) {
+		return errors.New(errTagAndDropletIDs)
+	}
+	return nil
+}
+
+// ValidateVPC returns an error if the VPC of the supplied LBParameters differs
+// from the VPC the supplied LB was created in. A LoadBalancer cannot be moved
+// to another VPC.
+func ValidateVPC(p v1alpha1.LBParameters, observed LoadBalancer) error {
+	if vpc := do.StringValue(p.VPCUUID); vpc != "" && observed.VPCUUID != "" && vpc != observed.VPCUUID {
+		return errors.Errorf(errVPCImmutable, observed.VPCUUID, vpc)
+	}
+	return nil
+}
+
+// MergePools returns the supplied LBParameters with the Droplets, forwarding
+// rules and health check of its pools merged into them, since a LoadBalancer
+// has a single set of backend Droplets and a single health check. It returns
+// an error if the pools select Droplets by different tags, or define
+// different health checks.
+func MergePools(p v1alpha1.LBParameters) (v1alpha1.LBParameters, error) {
+	if len(p.Pools) == 0 {
+		return p, nil
+	}
+	out := *p.DeepCopy()
+	out.Pools = nil
+
+	tagOwner, hcOwner := "the LoadBalancer", "the LoadBalancer"
+	var hc *v1alpha1.DOLoadBalancerHealthCheck
+	if p.HealthCheck != (v1alpha1.DOLoadBalancerHealthCheck{}) {
+		hc = &p.HealthCheck
+	}
+	names := map[string]int{}
+	for i, pool := range p.Pools {
+		if pool.Name == "" {
+			return p, errors.Errorf(errPoolNoName, i)
+		}
+		if j, ok := names[pool.Name]; ok {
+			return p, errors.Errorf(errPoolDuplicateName, j, i, pool.Name)
+		}
+		names[pool.Name] = i
+		owner := fmt.Sprintf("pool %q", pool.Name)
+
+		if pool.Tag != nil && (len(pool.DropletIDs) > 0 || len(pool.DropletRefs) > 0) {
+			return p, errors.Errorf(errPoolTagAndDroplets, pool.Name)
+		}
+		if pool.Tag != nil {
+			if out.Tag != nil && *out.Tag != *pool.Tag {
+				return p, errors.Errorf(errPoolTags, tagOwner, owner)
+			}
+			out.Tag, tagOwner = pool.Tag, owner
+		}
+		if pool.HealthCheck != nil {
+			if hc != nil && *hc != *pool.HealthCheck {
+				return p, errors.Errorf(errPoolHealthChecks, hcOwner, owner)
+			}
+			hc, hcOwner = pool.HealthCheck, owner
+		}
+
+		for _, id := range pool.DropletIDs {
+			if !containsID(out.DropletIDs, id) {
+				out.DropletIDs = append(out.DropletIDs, id)
+			}
+		}
+		out.DropletRefs = append(out.DropletRefs, pool.DropletRefs...)
+		out.ForwardingRules = append(out.ForwardingRules, pool.ForwardingRules...)
+	}
+	if hc != nil {
+		out.HealthCheck = *hc
+	}
+	return out, nil
+}
+
+func containsID(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateForwardingRules returns an error if the protocols, certificate or
+// ports of the forwarding rules of the supplied LBParameters cannot be
+// combined, so that they are rejected before the API is called.
+func ValidateForwardingRules(p v1alpha1.LBParameters) error {
+	listeners := map[string]int{}
+	for i, r := range p.ForwardingRules {
+		if !canForward(r.EntryProtocol, r.TargetProtocol) {
+			return errors.Errorf(errRuleTargetProtocol, i, r.EntryProtocol, r.TargetProtocol)
+		}
+		tls := r.EntryProtocol == ProtocolHTTPS || r.EntryProtocol == ProtocolHTTP2
+		switch {
+		case r.CertificateID != "" && r.TLSPassthrough:
+			return errors.Errorf(errRuleCertAndTLS, i)
+		case r.EntryProtocol == ProtocolHTTP3 && r.CertificateID == "":
+			return errors.Errorf(errRuleHTTP3Certificate, i)
+		case tls && r.CertificateID == "" && !r.TLSPassthrough:
+			return errors.Errorf(errRuleNoCertificate, i, r.EntryProtocol)
+		case r.CertificateID != "" && !tls && r.EntryProtocol != ProtocolHTTP3:
+			return errors.Errorf(errRuleCertificate, i)
+		case r.TLSPassthrough && (!tls || r.TargetProtocol != r.EntryProtocol):
+			return errors.Errorf(errRuleTLSPassthrough, i)
+		}
+
+		// HTTP/3 is served over UDP, so it can share a port with HTTPS.
+		transport := ProtocolTCP
+		if r.EntryProtocol == ProtocolUDP || r.EntryProtocol == ProtocolHTTP3 {
+			transport = ProtocolUDP
+		}
+		listener := fmt.Sprintf("%s/%d", transport, r.EntryPort)
+		if j, ok := listeners[listener]; ok {
+			return errors.Errorf(errRuleDuplicatePort, j, i, transport, r.EntryPort)
+		}
+		listeners[listener] = i
+	}
+	return nil
+}
+
+// canForward returns true if traffic received with the supplied entry
+// protocol can be forwarded with the supplied target protocol.
+func canForward(entry, target string) bool {
+	switch entry {
+	case ProtocolTCP, ProtocolUDP:
+		return target == entry
+	case ProtocolHTTP, ProtocolHTTPS, ProtocolHTTP2, ProtocolHTTP3:
+		return target == ProtocolHTTP || target == ProtocolHTTPS || target == ProtocolHTTP2
+	}
+	return false
+}
+
+// GenerateFirewall generates the *Firewall of a LoadBalancerRequest from
+// LBParameters.
+func GenerateFirewall(in v1alpha1.LBParameters) *Firewall {
+	if in.Firewall == nil {
+		return nil
+	}
+	return &Firewall{
+		Allow: append([]string{}, in.Firewall.Allow...),
+		Deny:  append([]string{}, in.Firewall.Deny...),
+	}
+}
+
+// GenerateLoadBalancerUpdate returns the request that updates the supplied
+// LB to match the supplied LBParameters. It keeps the observed values of the
+// fields that are not managed by LBParameters.
+func GenerateLoadBalancerUpdate(p v1alpha1.LBParameters, observed LoadBalancer) *LoadBalancerRequest {
+	update := &LoadBalancerRequest{
+		LoadBalancerRequest: *observed.AsRequest(),
+		Firewall:            observed.Firewall,
+	}
+	// The API reports the Droplets that carry the tag of a LB, but rejects
+	// updates that set both, so the tag is passed through rather than being
+	// expanded to Droplet IDs.
+	switch {
+	case p.Tag != nil:
+		update.Tag = *p.Tag
+		update.DropletIDs = nil
+	case len(p.DropletIDs) > 0:
+		update.Tag = ""
+		update.DropletIDs = append([]int{}, p.DropletIDs...)
+	case update.Tag != "":
+		update.DropletIDs = nil
+	}
+	if p.DisableLetsEncryptDNSRecords != nil {
+		update.DisableLetsEncryptDNSRecords = p.DisableLetsEncryptDNSRecords
+	}
+	if p.Firewall != nil {
+		update.Firewall = GenerateFirewall(p)
+	}
+	if len(p.ForwardingRules) > 0 {
+		update.ForwardingRules = generateForwardingRules(p)
+	}
+	return update
+}
+
+// IsUpToDate returns true if the supplied LB matches the updatable fields of
+// the supplied LBParameters.
+func IsUpToDate(p v1alpha1.LBParameters, observed LoadBalancer) bool {
+	if ValidateVPC(p, observed) != nil {
+		return false
+	}
+	if p.DisableLetsEncryptDNSRecords != nil && *p.DisableLetsEncryptDNSRecords != do.BoolValue(observed.DisableLetsEncryptDNSRecords) {
+		return false
+	}
+	if p.Tag != nil && *p.Tag != observed.Tag {
+		return false
+	}
+	if len(p.DropletIDs) > 0 && (observed.Tag != "" || !dropletIDsEqual(p.DropletIDs, observed.DropletIDs)) {
+		return false
+	}
+	if len(p.ForwardingRules) > 0 && !forwardingRulesEqual(generateForwardingRules(p), observed.ForwardingRules) {
+		return false
+	}
+	if p.Firewall == nil {
+		return true
+	}
+	f := observed.Firewall
+	if f == nil {
+		f = &Firewall{}
+	}
+	return sourcesEqual(p.Firewall.Allow, f.Allow) && sourcesEqual(p.Firewall.Deny, f.Deny)
+}
+
+// diffOptions compare load balancer requests regardless of the order of their
+// Droplet IDs, firewall sources and forwarding rules.
+var diffOptions = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmpopts.SortSlices(func(a, b int) bool { return a < b }),
+	cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+	cmpopts.SortSlices(func(a, b godo.ForwardingRule) bool {
+		if a.EntryPort != b.EntryPort {
+			return a.EntryPort < b.EntryPort
+		}
+		return a.EntryProtocol < b.EntryProtocol
+	}),
+}
+
+// Diff returns a human readable diff between the supplied LB and the update
+// that brings it in line with the supplied LBParameters.
+func Diff(p v1alpha1.LBParameters, observed LoadBalancer) string {
+	current := &LoadBalancerRequest{LoadBalancerRequest: *observed.AsRequest(), Firewall: observed.Firewall}
+	_, diff := do.NeedsUpdate(GenerateLoadBalancerUpdate(p, observed), current, diffOptions...)
+	return diff
+}
+
+// sourcesEqual returns true if the supplied firewall sources contain the same
+// sources, regardless of their order.
+func sourcesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[s] = true
+	}
+	for _, s := range a {
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
+
+// dropletIDsEqual returns true if the supplied Droplet IDs contain the same
+// IDs, regardless of their order.
+func dropletIDsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[int]bool, len(b))
+	for _, id := range b {
+		set[id] = true
+	}
+	for _, id := range a {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// forwardingRulesEqual returns true if the supplied forwarding rules contain
+// the same rules, regardless of their order.
+func forwardingRulesEqual(a, b []godo.ForwardingRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[godo.ForwardingRule]bool, len(b))
+	for _, r := range b {
+		set[r] = true
+	}
+	for _, r := range a {
+		if !set[r] {
+			return false
+		}
+	}
+	return true
+}
+
 // GenerateLoadBalancer generates *godo.LoadBalancerRequest instance from LBParameters.
 func GenerateLoadBalancer(name string, in v1alpha1.LBParameters, create *godo.LoadBalancerRequest) {
 	create.Name = name
 	create.Region = in.Region
 	create.Algorithm = in.Algorithm
-	create.ForwardingRules = append(create.ForwardingRules, generateForwardRule(in.Port))
+	create.ForwardingRules = append(create.ForwardingRules, generateForwardingRules(in)...)
 	create.HealthCheck = generateHealthCheck(in.HealthCheck, in.Port)
 	create.Tags = in.Tags
 	create.VPCUUID = do.StringValue(in.VPCUUID)
+	create.DisableLetsEncryptDNSRecords = in.DisableLetsEncryptDNSRecords
+	create.Tag = do.StringValue(in.Tag)
+	create.DropletIDs = in.DropletIDs
+}
+
+func generateForwardingRules(in v1alpha1.LBParameters) []godo.ForwardingRule {
+	if len(in.ForwardingRules) == 0 {
+		return []godo.ForwardingRule{generateForwardRule(in.Port)}
+	}
+	rules := make([]godo.ForwardingRule, len(in.ForwardingRules))
+	for i, r := range in.ForwardingRules {
+		rules[i] = godo.ForwardingRule{
+			EntryProtocol:  r.EntryProtocol,
+			EntryPort:      r.EntryPort,
+			TargetProtocol: r.TargetProtocol,
+			TargetPort:     r.TargetPort,
+			CertificateID:  r.CertificateID,
+			TlsPassthrough: r.TLSPassthrough,
+		}
+	}
+	return rules
 }
 
 func generateForwardRule(param int) godo.ForwardingRule {
@@ -70,7 +520,7 @@ func generateHealthCheck(in v1alpha1.DOLoadBalancerHealthCheck, inPort int) *god
 // LateInitializeSpec updates any unset (i.e. nil) optional fields of the
 // supplied LBParameters that are set (i.e. non-zero) on the supplied
 // LB.
-func LateInitializeSpec(p *v1alpha1.LBParameters, observed godo.LoadBalancer) {
+func LateInitializeSpec(p *v1alpha1.LBParameters, observed LoadBalancer) {
 	p.Tags = do.LateInitializeStringSlice(p.Tags, observed.Tags)
 	p.VPCUUID = do.LateInitializeString(p.VPCUUID, observed.VPCUUID)
 }
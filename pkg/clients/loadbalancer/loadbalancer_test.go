@@ -0,0 +1,532 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func stringPtr(s string) *string { return &s }
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		p        v1alpha1.LBParameters
+		observed LoadBalancer
+		want     bool
+	}{
+		"Unmanaged": {
+			reason:   "A LB should be up to date if neither the firewall nor the DNS records toggle are set.",
+			observed: LoadBalancer{Firewall: &Firewall{Allow: []string{"ip:1.2.3.4"}}},
+			want:     true,
+		},
+		"FirewallReordered": {
+			reason:   "Firewall sources in a different order should be up to date.",
+			p:        v1alpha1.LBParameters{Firewall: &v1alpha1.LBFirewall{Allow: []string{"ip:1.2.3.4", "cidr:2.3.0.0/16"}}},
+			observed: LoadBalancer{Firewall: &Firewall{Allow: []string{"cidr:2.3.0.0/16", "ip:1.2.3.4"}}},
+			want:     true,
+		},
+		"FirewallSourceAdded": {
+			reason:   "A source missing from the observed firewall should not be up to date.",
+			p:        v1alpha1.LBParameters{Firewall: &v1alpha1.LBFirewall{Deny: []string{"ip:1.2.3.4", "ip:5.6.7.8"}}},
+			observed: LoadBalancer{Firewall: &Firewall{Deny: []string{"ip:1.2.3.4"}}},
+		},
+		"FirewallMissing": {
+			reason: "A LB without a firewall should not be up to date if one is desired.",
+			p:      v1alpha1.LBParameters{Firewall: &v1alpha1.LBFirewall{Allow: []string{"ip:1.2.3.4"}}},
+		},
+		"DNSRecordsToggled": {
+			reason:   "Disabling Let's Encrypt DNS records should not be up to date until the LB reports them disabled.",
+			p:        v1alpha1.LBParameters{DisableLetsEncryptDNSRecords: boolPtr(true)},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{DisableLetsEncryptDNSRecords: boolPtr(false)}},
+		},
+		"ForwardingRulesReordered": {
+			reason: "Forwarding rules in a different order should be up to date.",
+			p: v1alpha1.LBParameters{ForwardingRules: []v1alpha1.LBForwardingRule{
+				{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 8080},
+				{EntryProtocol: "http3", EntryPort: 443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "cert"},
+			}},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "http3", EntryPort: 443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "cert"},
+				{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 8080},
+			}}},
+			want: true,
+		},
+		"ForwardingRuleProtocolChanged": {
+			reason: "A forwarding rule whose entry protocol changed should not be up to date.",
+			p: v1alpha1.LBParameters{ForwardingRules: []v1alpha1.LBForwardingRule{
+				{EntryProtocol: "http2", EntryPort: 443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "cert"},
+			}},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "cert"},
+			}}},
+		},
+		"TagMembership": {
+			reason:   "A LB with the desired tag should be up to date regardless of the Droplets that carry it.",
+			p:        v1alpha1.LBParameters{Tag: stringPtr("web")},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{Tag: "web", DropletIDs: []int{1, 2, 3}}},
+			want:     true,
+		},
+		"TagChanged": {
+			reason:   "A LB with another tag should not be up to date.",
+			p:        v1alpha1.LBParameters{Tag: stringPtr("api")},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{Tag: "web"}},
+		},
+		"DropletIDsReordered": {
+			reason:   "Droplet IDs in a different order should be up to date.",
+			p:        v1alpha1.LBParameters{DropletIDs: []int{1, 2}},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{DropletIDs: []int{2, 1}}},
+			want:     true,
+		},
+		"DropletIDsInsteadOfTag": {
+			reason:   "A LB whose members are selected by tag should not be up to date if explicit Droplet IDs are desired.",
+			p:        v1alpha1.LBParameters{DropletIDs: []int{1, 2}},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{Tag: "web", DropletIDs: []int{1, 2}}},
+		},
+		"DNSRecordsUnchanged": {
+			reason:   "An unset observed toggle should match a desired false value.",
+			p:        v1alpha1.LBParameters{DisableLetsEncryptDNSRecords: boolPtr(false)},
+			observed: LoadBalancer{},
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.p, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateLoadBalancerUpdate(t *testing.T) {
+	observed := LoadBalancer{
+		LoadBalancer: godo.LoadBalancer{Name: "example", Algorithm: "round_robin", Region: &godo.Region{Slug: "nyc3"}, Tag: "web", DropletIDs: []int{1, 2}},
+		Firewall:     &Firewall{Allow: []string{"ip:1.2.3.4"}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LBParameters
+		want   *LoadBalancerRequest
+	}{
+		"KeepObserved": {
+			reason: "Fields not managed by the spec should be sent as observed.",
+			want: &LoadBalancerRequest{
+				LoadBalancerRequest: godo.LoadBalancerRequest{Name: "example", Algorithm: "round_robin", Region: "nyc3", Tag: "web"},
+				Firewall:            &Firewall{Allow: []string{"ip:1.2.3.4"}},
+			},
+		},
+		"ReplaceFirewall": {
+			reason: "The desired firewall and DNS records toggle should replace the observed ones.",
+			p: v1alpha1.LBParameters{
+				DisableLetsEncryptDNSRecords: boolPtr(true),
+				Firewall:                     &v1alpha1.LBFirewall{Deny: []string{"cidr:2.3.0.0/16"}},
+			},
+			want: &LoadBalancerRequest{
+				LoadBalancerRequest: godo.LoadBalancerRequest{Name: "example", Algorithm: "round_robin", Region: "nyc3", Tag: "web", DisableLetsEncryptDNSRecords: boolPtr(true)},
+				Firewall:            &Firewall{Allow: []string{}, Deny: []string{"cidr:2.3.0.0/16"}},
+			},
+		},
+		"TagMembership": {
+			reason: "The desired tag should be passed through rather than expanded to the Droplets that carry the observed one.",
+			p:      v1alpha1.LBParameters{Tag: stringPtr("api")},
+			want: &LoadBalancerRequest{
+				LoadBalancerRequest: godo.LoadBalancerRequest{Name: "example", Algorithm: "round_robin", Region: "nyc3", Tag: "api"},
+				Firewall:            &Firewall{Allow: []string{"ip:1.2.3.4"}},
+			},
+		},
+		"DropletIDs": {
+			reason: "Explicit Droplet IDs should replace the tag.",
+			p:      v1alpha1.LBParameters{DropletIDs: []int{3}},
+			want: &LoadBalancerRequest{
+				LoadBalancerRequest: godo.LoadBalancerRequest{Name: "example", Algorithm: "round_robin", Region: "nyc3", DropletIDs: []int{3}},
+				Firewall:            &Firewall{Allow: []string{"ip:1.2.3.4"}},
+			},
+		},
+		"ReplaceForwardingRules": {
+			reason: "The desired forwarding rules should replace the observed ones.",
+			p: v1alpha1.LBParameters{ForwardingRules: []v1alpha1.LBForwardingRule{
+				{EntryProtocol: "http2", EntryPort: 443, TargetProtocol: "http2", TargetPort: 443, TLSPassthrough: true},
+			}},
+			want: &LoadBalancerRequest{
+				LoadBalancerRequest: godo.LoadBalancerRequest{Name: "example", Algorithm: "round_robin", Region: "nyc3", Tag: "web", ForwardingRules: []godo.ForwardingRule{
+					{EntryProtocol: "http2", EntryPort: 443, TargetProtocol: "http2", TargetPort: 443, TlsPassthrough: true},
+				}},
+				Firewall: &Firewall{Allow: []string{"ip:1.2.3.4"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateLoadBalancerUpdate(tc.p, observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateLoadBalancerUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateFirewall(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LBParameters
+		want   error
+	}{
+		"AllowOnly": {
+			reason: "A firewall with only an allow list should be valid.",
+			p:      v1alpha1.LBParameters{Firewall: &v1alpha1.LBFirewall{Allow: []string{"ip:1.2.3.4"}}},
+		},
+		"AllowAndDeny": {
+			reason: "A firewall with both an allow and a deny list should be rejected.",
+			p:      v1alpha1.LBParameters{Firewall: &v1alpha1.LBFirewall{Allow: []string{"ip:1.2.3.4"}, Deny: []string{"ip:5.6.7.8"}}},
+			want:   errors.New(errFirewallAllowAndDeny),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateFirewall(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateFirewall(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateForwardingRules(t *testing.T) {
+	rule := func(entry string, port int, target string, cert string, tls bool) v1alpha1.LBForwardingRule {
+		return v1alpha1.LBForwardingRule{EntryProtocol: entry, EntryPort: port, TargetProtocol: target, TargetPort: 8080, CertificateID: cert, TLSPassthrough: tls}
+	}
+
+	cases := map[string]struct {
+		reason string
+		rules  []v1alpha1.LBForwardingRule
+		want   error
+	}{
+		"HTTP": {
+			reason: "An http rule without a certificate should be valid.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http", 80, "http", "", false)},
+		},
+		"HTTPS": {
+			reason: "An https rule with a certificate should be valid.",
+			rules:  []v1alpha1.LBForwardingRule{rule("https", 443, "http", "cert", false)},
+		},
+		"HTTP2Passthrough": {
+			reason: "An http2 rule passing TLS through to http2 should be valid.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http2", 443, "http2", "", true)},
+		},
+		"HTTP3WithHTTPS": {
+			reason: "An http3 rule with a certificate should be valid and may share its port with https, as it is served over UDP.",
+			rules:  []v1alpha1.LBForwardingRule{rule("https", 443, "http", "cert", false), rule("http3", 443, "http", "cert", false)},
+		},
+		"TCPAndUDP": {
+			reason: "tcp and udp rules may share a port.",
+			rules:  []v1alpha1.LBForwardingRule{rule("tcp", 53, "tcp", "", false), rule("udp", 53, "udp", "", false)},
+		},
+		"HTTPToTCP": {
+			reason: "An http rule cannot forward to tcp.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http", 80, "tcp", "", false)},
+			want:   errors.Errorf(errRuleTargetProtocol, 0, "http", "tcp"),
+		},
+		"TCPToUDP": {
+			reason: "A tcp rule can only forward to tcp.",
+			rules:  []v1alpha1.LBForwardingRule{rule("tcp", 80, "udp", "", false)},
+			want:   errors.Errorf(errRuleTargetProtocol, 0, "tcp", "udp"),
+		},
+		"HTTPSWithoutCertificate": {
+			reason: "An https rule needs a certificate or TLS passthrough.",
+			rules:  []v1alpha1.LBForwardingRule{rule("https", 443, "https", "", false)},
+			want:   errors.Errorf(errRuleNoCertificate, 0, "https"),
+		},
+		"HTTP3WithoutCertificate": {
+			reason: "An http3 rule needs a certificate, even with TLS passthrough.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http3", 443, "https", "", true)},
+			want:   errors.Errorf(errRuleHTTP3Certificate, 0),
+		},
+		"CertificateAndPassthrough": {
+			reason: "A rule cannot both terminate and pass through TLS.",
+			rules:  []v1alpha1.LBForwardingRule{rule("https", 443, "https", "cert", true)},
+			want:   errors.Errorf(errRuleCertAndTLS, 0),
+		},
+		"CertificateOnHTTP": {
+			reason: "A certificate cannot be used with the http entry protocol.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http", 80, "http", "cert", false)},
+			want:   errors.Errorf(errRuleCertificate, 0),
+		},
+		"PassthroughToHTTP": {
+			reason: "TLS passthrough requires the target protocol to match the entry protocol.",
+			rules:  []v1alpha1.LBForwardingRule{rule("https", 443, "http", "", true)},
+			want:   errors.Errorf(errRuleTLSPassthrough, 0),
+		},
+		"DuplicatePort": {
+			reason: "Two rules cannot listen on the same port.",
+			rules:  []v1alpha1.LBForwardingRule{rule("http", 80, "http", "", false), rule("tcp", 80, "tcp", "", false)},
+			want:   errors.Errorf(errRuleDuplicatePort, 0, 1, "tcp", 80),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateForwardingRules(v1alpha1.LBParameters{ForwardingRules: tc.rules})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateForwardingRules(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateMembership(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LBParameters
+		want   error
+	}{
+		"Tag": {
+			reason: "A LB whose members are selected by tag should be valid.",
+			p:      v1alpha1.LBParameters{Tag: stringPtr("web")},
+		},
+		"DropletIDs": {
+			reason: "A LB with explicit Droplet IDs should be valid.",
+			p:      v1alpha1.LBParameters{DropletIDs: []int{1}},
+		},
+		"TagAndDropletIDs": {
+			reason: "A LB with both a tag and explicit Droplet IDs should be rejected.",
+			p:      v1alpha1.LBParameters{Tag: stringPtr("web"), DropletIDs: []int{1}},
+			want:   errors.New(errTagAndDropletIDs),
+		},
+		"TagAndDropletRefs": {
+			reason: "A LB with both a tag and Droplet references should be rejected.",
+			p:      v1alpha1.LBParameters{Tag: stringPtr("web"), DropletRefs: []xpv1.Reference{{Name: "web"}}},
+			want:   errors.New(errTagAndDropletIDs),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateMembership(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateMembership(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateVPC(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		p        v1alpha1.LBParameters
+		observed LoadBalancer
+		want     error
+	}{
+		"SameVPC": {
+			reason:   "A LB in the desired VPC should be valid.",
+			p:        v1alpha1.LBParameters{VPCUUID: stringPtr("vpc-a")},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{VPCUUID: "vpc-a"}},
+		},
+		"Unset": {
+			reason:   "A LB without a desired VPC should be valid.",
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{VPCUUID: "vpc-a"}},
+		},
+		"OtherVPC": {
+			reason:   "A LB that would be moved to another VPC should be rejected.",
+			p:        v1alpha1.LBParameters{VPCUUID: stringPtr("vpc-b")},
+			observed: LoadBalancer{LoadBalancer: godo.LoadBalancer{VPCUUID: "vpc-a"}},
+			want:     errors.Errorf(errVPCImmutable, "vpc-a", "vpc-b"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateVPC(tc.p, tc.observed)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateVPC(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMergePools(t *testing.T) {
+	type want struct {
+		p   v1alpha1.LBParameters
+		err error
+	}
+
+	web := v1alpha1.LBForwardingRule{EntryProtocol: ProtocolHTTP, EntryPort: 80, TargetProtocol: ProtocolHTTP, TargetPort: 8080}
+	api := v1alpha1.LBForwardingRule{EntryProtocol: ProtocolTCP, EntryPort: 9000, TargetProtocol: ProtocolTCP, TargetPort: 9000}
+	hc := v1alpha1.DOLoadBalancerHealthCheck{Interval: 10, Timeout: 5}
+	other := v1alpha1.DOLoadBalancerHealthCheck{Interval: 30, Timeout: 5}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LBParameters
+		want   want
+	}{
+		"NoPools": {
+			reason: "Parameters without pools should be returned unchanged.",
+			p:      v1alpha1.LBParameters{DropletIDs: []int{1}, ForwardingRules: []v1alpha1.LBForwardingRule{web}},
+			want:   want{p: v1alpha1.LBParameters{DropletIDs: []int{1}, ForwardingRules: []v1alpha1.LBForwardingRule{web}}},
+		},
+		"MultiplePools": {
+			reason: "The Droplets and forwarding rules of every pool should be assigned to the LB, and their shared health check used.",
+			p: v1alpha1.LBParameters{
+				DropletIDs: []int{1},
+				Pools: []v1alpha1.LBPool{
+					{Name: "web", DropletIDs: []int{1, 2}, ForwardingRules: []v1alpha1.LBForwardingRule{web}, HealthCheck: &hc},
+					{Name: "api", DropletIDs: []int{3}, DropletRefs: []xpv1.Reference{{Name: "api"}}, ForwardingRules: []v1alpha1.LBForwardingRule{api}, HealthCheck: &hc},
+				},
+			},
+			want: want{p: v1alpha1.LBParameters{
+				DropletIDs:      []int{1, 2, 3},
+				DropletRefs:     []xpv1.Reference{{Name: "api"}},
+				ForwardingRules: []v1alpha1.LBForwardingRule{web, api},
+				HealthCheck:     hc,
+			}},
+		},
+		"SameTag": {
+			reason: "Pools that select Droplets by the same tag should be merged.",
+			p: v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{
+				{Name: "web", Tag: stringPtr("app"), ForwardingRules: []v1alpha1.LBForwardingRule{web}},
+				{Name: "api", Tag: stringPtr("app"), ForwardingRules: []v1alpha1.LBForwardingRule{api}},
+			}},
+			want: want{p: v1alpha1.LBParameters{Tag: stringPtr("app"), ForwardingRules: []v1alpha1.LBForwardingRule{web, api}}},
+		},
+		"DifferentTags": {
+			reason: "Pools that select Droplets by different tags should be rejected.",
+			p: v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{
+				{Name: "web", Tag: stringPtr("web")},
+				{Name: "api", Tag: stringPtr("api")},
+			}},
+			want: want{err: errors.Errorf(errPoolTags, `pool "web"`, `pool "api"`)},
+		},
+		"DifferentHealthChecks": {
+			reason: "Pools that define different health checks should be rejected, since a LB supports a single one.",
+			p: v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{
+				{Name: "web", HealthCheck: &hc},
+				{Name: "api", HealthCheck: &other},
+			}},
+			want: want{err: errors.Errorf(errPoolHealthChecks, `pool "web"`, `pool "api"`)},
+		},
+		"HealthCheckOfLB": {
+			reason: "A pool that defines a health check other than the one of the LB should be rejected.",
+			p: v1alpha1.LBParameters{
+				HealthCheck: hc,
+				Pools:       []v1alpha1.LBPool{{Name: "api", HealthCheck: &other}},
+			},
+			want: want{err: errors.Errorf(errPoolHealthChecks, "the LoadBalancer", `pool "api"`)},
+		},
+		"TagAndDroplets": {
+			reason: "A pool with both a tag and explicit Droplet IDs should be rejected.",
+			p:      v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{{Name: "web", Tag: stringPtr("web"), DropletIDs: []int{1}}}},
+			want:   want{err: errors.Errorf(errPoolTagAndDroplets, "web")},
+		},
+		"DuplicateName": {
+			reason: "Pools with the same name should be rejected.",
+			p:      v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{{Name: "web"}, {Name: "web"}}},
+			want:   want{err: errors.Errorf(errPoolDuplicateName, 0, 1, "web")},
+		},
+		"NoName": {
+			reason: "A pool without a name should be rejected.",
+			p:      v1alpha1.LBParameters{Pools: []v1alpha1.LBPool{{DropletIDs: []int{1}}}},
+			want:   want{err: errors.Errorf(errPoolNoName, 0)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := MergePools(tc.p)
+			if tc.want.err != nil {
+				p = v1alpha1.LBParameters{}
+			}
+			if diff := cmp.Diff(tc.want, want{p: p, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMergePools(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateLoadBalancerTag(t *testing.T) {
+	create := &godo.LoadBalancerRequest{}
+	GenerateLoadBalancer("example", v1alpha1.LBParameters{Tag: stringPtr("web")}, create)
+	if create.Tag != "web" || create.DropletIDs != nil {
+		t.Errorf("GenerateLoadBalancer(...): want tag %q and no Droplet IDs, got tag %q and Droplet IDs %v", "web", create.Tag, create.DropletIDs)
+	}
+}
+
+func TestWaitForActive(t *testing.T) {
+	activePollInterval, activePollMaxInterval = time.Millisecond, 2*time.Millisecond
+
+	type want struct {
+		calls int
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		statuses []string
+		want     want
+	}{
+		"Active": {
+			reason:   "A LB that is being updated should be polled until it is active.",
+			statuses: []string{v1alpha1.StatusNew, v1alpha1.StatusNew, v1alpha1.StatusActive},
+			want:     want{calls: 3},
+		},
+		"Errored": {
+			reason:   "A LB that errored should not be polled any further.",
+			statuses: []string{v1alpha1.StatusNew, statusErrored},
+			want:     want{calls: 2, err: errors.Errorf(errLBErrored, "lb-id")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			c := fake.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+				i := calls
+				if i >= len(tc.statuses) {
+					i = len(tc.statuses) - 1
+				}
+				calls++
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": godo.LoadBalancer{ID: "lb-id", Status: tc.statuses[i]}})
+			})
+
+			_, err := WaitForActive(context.Background(), c, "lb-id")
+			if diff := cmp.Diff(tc.want, want{calls: calls, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWaitForActive(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// Timestamp returns the supplied time in RFC3339 text format, or an empty
+// string if it is not set. Observations report every timestamp the
+// DigitalOcean API returns as a time in this format.
+func Timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// RegionSlug returns the slug of the supplied region, or an empty string if
+// the DigitalOcean API did not report one.
+func RegionSlug(r *godo.Region) string {
+	if r == nil {
+		return ""
+	}
+	return r.Slug
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTimestamp(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		t      time.Time
+		want   string
+	}{
+		"Set": {
+			reason: "A set time should be formatted in RFC3339 text format.",
+			t:      time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+			want:   "2021-06-01T12:00:00Z",
+		},
+		"Zero": {
+			reason: "A time that is not set should be reported as empty.",
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Timestamp(tc.t)); diff != "" {
+				t.Errorf("\n%s\nTimestamp(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRegionSlug(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		r      *godo.Region
+		want   string
+	}{
+		"Set": {
+			reason: "The slug of a reported region should be returned.",
+			r:      &godo.Region{Slug: "nyc3"},
+			want:   "nyc3",
+		},
+		"Nil": {
+			reason: "A region that is not reported should be empty.",
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, RegionSlug(tc.r)); diff != "" {
+				t.Errorf("\n%s\nRegionSlug(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+)
+
+const (
+	errAssignProject  = "cannot assign resource to project %s"
+	errPersistProject = "cannot persist the project assignment of the managed resource"
+)
+
+// AnnotationProject is set on a managed resource to the ID of the project
+// its external resource was last assigned to, since most resources do not
+// report the project they belong to. The resource is assigned again when the
+// project it declares changes.
+const AnnotationProject = "do.crossplane.io/project-id"
+
+// ProjectUpToDate returns true if the external resource of the supplied
+// object was last assigned to the supplied project, or if no project is
+// supplied.
+func ProjectUpToDate(o metav1.Object, projectID *string) bool {
+	return projectID == nil || o.GetAnnotations()[AnnotationProject] == *projectID
+}
+
+// AssignProject assigns the resource with the supplied URN to the supplied
+// project unless it is up to date, and records the assignment in the
+// AnnotationProject annotation of the supplied object. The annotation is not
+// persisted.
+func AssignProject(ctx context.Context, c *godo.Client, o metav1.Object, projectID *string, urn string) error {
+	if ProjectUpToDate(o, projectID) {
+		return nil
+	}
+	if _, _, err := c.Projects.AssignResources(ctx, *projectID, urn); err != nil {
+		return errors.Wrapf(err, errAssignProject, *projectID)
+	}
+	meta.AddAnnotations(o, map[string]string{AnnotationProject: *projectID})
+	return nil
+}
+
+// UpdateProject assigns the resource with the supplied URN to the supplied
+// project like AssignProject, and persists the assignment. It suits updates,
+// whose changes to annotations are not persisted by the managed reconciler.
+func UpdateProject(ctx context.Context, c *godo.Client, kube client.Client, o client.Object, projectID *string, urn string) error {
+	if ProjectUpToDate(o, projectID) {
+		return nil
+	}
+	if err := AssignProject(ctx, c, o, projectID, urn); err != nil {
+		return err
+	}
+	return errors.Wrap(kube.Update(ctx, o), errPersistProject)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package project contains helpers to manage DigitalOcean projects.
+package project
+
+import (
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+// GenerateProject returns a request that creates a project with the supplied
+// name and parameters.
+func GenerateProject(name string, p v1alpha1.ProjectParameters) *godo.CreateProjectRequest {
+	return &godo.CreateProjectRequest{
+		Name:        name,
+		Purpose:     p.Purpose,
+		Description: do.StringValue(p.Description),
+		Environment: do.StringValue(p.Environment),
+	}
+}
+
+// GenerateProjectUpdate returns a request that brings a project in line with
+// the supplied parameters. Fields that are not set are left unchanged.
+func GenerateProjectUpdate(p v1alpha1.ProjectParameters) *godo.UpdateProjectRequest {
+	update := &godo.UpdateProjectRequest{Purpose: p.Purpose}
+	if p.Description != nil {
+		update.Description = *p.Description
+	}
+	if p.Environment != nil {
+		update.Environment = *p.Environment
+	}
+	return update
+}
+
+// GenerateObservation returns the observation of the supplied project.
+func GenerateObservation(observed godo.Project) v1alpha1.ProjectObservation {
+	return v1alpha1.ProjectObservation{
+		ID:        observed.ID,
+		Name:      observed.Name,
+		OwnerUUID: observed.OwnerUUID,
+		IsDefault: observed.IsDefault,
+		CreatedAt: observed.CreatedAt,
+	}
+}
+
+// LateInitializeProject fills the unset optional fields of the supplied
+// parameters from the supplied project.
+func LateInitializeProject(p *v1alpha1.ProjectParameters, observed godo.Project) {
+	p.Description = do.LateInitializeString(p.Description, observed.Description)
+	p.Environment = do.LateInitializeString(p.Environment, observed.Environment)
+}
+
+// IsUpToDate returns true if the supplied project matches the supplied
+// parameters.
+func IsUpToDate(p v1alpha1.ProjectParameters, observed godo.Project) bool {
+	return p.Purpose == observed.Purpose &&
+		(p.Description == nil || *p.Description == observed.Description) &&
+		(p.Environment == nil || *p.Environment == observed.Environment)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+const errGetConnectionSecret = "cannot get connection secret"
+
+// A RotationPolicyOf returns the connection rotation policy of the supplied
+// managed resource, or nil if it does not configure one.
+type RotationPolicyOf func(mg resource.Managed) *v1alpha1.ConnectionRotationPolicy
+
+// A RotationPolicyPublisher publishes connection details with another
+// ConnectionPublisher according to the connection rotation policy of each
+// managed resource. The details of a resource whose policy is OnChange are
+// only published if they differ from the ones already in its connection
+// secret.
+type RotationPolicyPublisher struct {
+	managed.ConnectionPublisher
+
+	kube   client.Reader
+	policy RotationPolicyOf
+}
+
+// NewRotationPolicyPublisher returns a RotationPolicyPublisher that publishes
+// connection details with the supplied ConnectionPublisher.
+func NewRotationPolicyPublisher(kube client.Reader, p managed.ConnectionPublisher, policy RotationPolicyOf) *RotationPolicyPublisher {
+	return &RotationPolicyPublisher{ConnectionPublisher: p, kube: kube, policy: policy}
+}
+
+// PublishConnection publishes the supplied connection details unless the
+// rotation policy of the supplied managed resource is OnChange and they are
+// already in its connection secret.
+func (p *RotationPolicyPublisher) PublishConnection(ctx context.Context, mg resource.Managed, c managed.ConnectionDetails) error {
+	ref := mg.GetWriteConnectionSecretToReference()
+	if ref == nil || len(c) == 0 {
+		return p.ConnectionPublisher.PublishConnection(ctx, mg, c)
+	}
+	if policy := p.policy(mg); policy == nil || *policy != v1alpha1.RotationPolicyOnChange {
+		return p.ConnectionPublisher.PublishConnection(ctx, mg, c)
+	}
+
+	s := &corev1.Secret{}
+	err := p.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if resource.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, errGetConnectionSecret)
+	}
+	if err == nil && published(s.Data, c) {
+		return nil
+	}
+	return p.ConnectionPublisher.PublishConnection(ctx, mg, c)
+}
+
+// published returns true if every connection detail is already in the
+// supplied secret data. Details that are not published again, e.g. a
+// kubeconfig that is not about to expire, are kept in the secret, so data
+// that is not among the details is ignored.
+func published(data map[string][]byte, c managed.ConnectionDetails) bool {
+	for k, v := range c {
+		if d, ok := data[k]; !ok || !bytes.Equal(d, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+)
+
+func TestRotationPolicyPublisher(t *testing.T) {
+	always := v1alpha1.RotationPolicyAlways
+	onChange := v1alpha1.RotationPolicyOnChange
+	details := managed.ConnectionDetails{"password": []byte("secret")}
+
+	cases := map[string]struct {
+		reason  string
+		policy  *v1alpha1.ConnectionRotationPolicy
+		secret  map[string][]byte
+		missing bool
+		want    bool
+	}{
+		"Default": {
+			reason: "Connection details should be written every time by default.",
+			secret: map[string][]byte{"password": []byte("secret")},
+			want:   true,
+		},
+		"Always": {
+			reason: "Unchanged connection details should be written when the policy is Always.",
+			policy: &always,
+			secret: map[string][]byte{"password": []byte("secret")},
+			want:   true,
+		},
+		"OnChangeUnchanged": {
+			reason: "Unchanged connection details should not be written when the policy is OnChange.",
+			policy: &onChange,
+			secret: map[string][]byte{"password": []byte("secret"), "port": []byte("25060")},
+		},
+		"OnChangeChanged": {
+			reason: "Changed connection details should be written when the policy is OnChange.",
+			policy: &onChange,
+			secret: map[string][]byte{"password": []byte("rotated")},
+			want:   true,
+		},
+		"OnChangeNoSecret": {
+			reason:  "Connection details should be written when the policy is OnChange and there is no connection secret yet.",
+			policy:  &onChange,
+			missing: true,
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if tc.missing {
+						return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "conn")
+					}
+					obj.(*corev1.Secret).Data = tc.secret
+					return nil
+				}),
+			}
+			written := false
+			pub := managed.ConnectionPublisherFns{
+				PublishConnectionFn: func(_ context.Context, _ resource.Managed, _ managed.ConnectionDetails) error {
+					written = true
+					return nil
+				},
+			}
+			p := NewRotationPolicyPublisher(kube, pub, func(resource.Managed) *v1alpha1.ConnectionRotationPolicy { return tc.policy })
+
+			mg := &fake.Managed{}
+			mg.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "conn", Namespace: "default"})
+			if err := p.PublishConnection(context.Background(), mg, details); err != nil {
+				t.Fatalf("\n%s\np.PublishConnection(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, written); diff != "" {
+				t.Errorf("\n%s\np.PublishConnection(...): -want written, +got written:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
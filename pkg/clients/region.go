@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+)
+
+const (
+	errListRegions       = "cannot list regions"
+	errRegionUnknown     = "unknown region %q"
+	errRegionUnavailable = "region %q is not available for new resources"
+	errRegionFeatures    = "region %q does not support %s"
+)
+
+// Features of a region, as reported by the API.
+const (
+	RegionFeatureBackups  = "backups"
+	RegionFeatureIPv6     = "ipv6"
+	RegionFeatureMetadata = "metadata"
+	RegionFeatureStorage  = "storage"
+)
+
+// A RegionCache caches the list of regions, which rarely changes, so that
+// validating a region does not list all regions every time. The regions are
+// the same for all credentials. A nil RegionCache lists the regions every
+// time.
+type RegionCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	regions []godo.Region
+	expires time.Time
+}
+
+// NewRegionCache returns a RegionCache that caches the regions for the
+// supplied duration.
+func NewRegionCache(ttl time.Duration) *RegionCache {
+	return &RegionCache{ttl: ttl}
+}
+
+// List returns the regions, listing them with the supplied client if they are
+// not cached or the cached ones expired.
+func (r *RegionCache) List(ctx context.Context, c *godo.Client) ([]godo.Region, error) {
+	if r == nil {
+		regions, _, err := c.Regions.List(ctx, &godo.ListOptions{PerPage: 200})
+		return regions, errors.Wrap(err, errListRegions)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.regions != nil && time.Now().Before(r.expires) {
+		return r.regions, nil
+	}
+	regions, _, err := c.Regions.List(ctx, &godo.ListOptions{PerPage: 200})
+	if err != nil {
+		return nil, errors.Wrap(err, errListRegions)
+	}
+	r.regions, r.expires = regions, time.Now().Add(r.ttl)
+	return regions, nil
+}
+
+// ValidateRegion returns an error if the region with the supplied slug is
+// unknown, not available for new resources, or lacks any of the supplied
+// features.
+func (r *RegionCache) ValidateRegion(ctx context.Context, c *godo.Client, slug string, features ...string) error {
+	regions, err := r.List(ctx, c)
+	if err != nil {
+		return err
+	}
+	return ValidateRegion(regions, slug, features...)
+}
+
+// ValidateRegion returns an error if the region with the supplied slug is not
+// one of the supplied regions, is not available for new resources, or lacks
+// any of the supplied features.
+func ValidateRegion(regions []godo.Region, slug string, features ...string) error {
+	for _, region := range regions {
+		if region.Slug != slug {
+			continue
+		}
+		if !region.Available {
+			return errors.Errorf(errRegionUnavailable, slug)
+		}
+		var missing []string
+		for _, f := range features {
+			if !containsString(region.Features, f) {
+				missing = append(missing, f)
+			}
+		}
+		if len(missing) > 0 {
+			return errors.Errorf(errRegionFeatures, slug, strings.Join(missing, ", "))
+		}
+		return nil
+	}
+	return errors.Errorf(errRegionUnknown, slug)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
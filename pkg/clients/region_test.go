@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestValidateRegion(t *testing.T) {
+	regions := []godo.Region{
+		{Slug: "nyc3", Available: true, Features: []string{RegionFeatureBackups, RegionFeatureIPv6, RegionFeatureMetadata, RegionFeatureStorage}},
+		{Slug: "sfo1", Available: true, Features: []string{RegionFeatureBackups, RegionFeatureMetadata}},
+		{Slug: "ams2", Available: false},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		slug     string
+		features []string
+		want     error
+	}{
+		"Supported": {
+			reason:   "A region supporting all features should be valid.",
+			slug:     "nyc3",
+			features: []string{RegionFeatureIPv6, RegionFeatureStorage},
+		},
+		"MissingFeatures": {
+			reason:   "A region lacking features should be rejected with the missing features.",
+			slug:     "sfo1",
+			features: []string{RegionFeatureBackups, RegionFeatureIPv6, RegionFeatureStorage},
+			want:     errors.Errorf(errRegionFeatures, "sfo1", "ipv6, storage"),
+		},
+		"Unavailable": {
+			reason: "A region that is not available for new resources should be rejected.",
+			slug:   "ams2",
+			want:   errors.Errorf(errRegionUnavailable, "ams2"),
+		},
+		"Unknown": {
+			reason: "An unknown region should be rejected.",
+			slug:   "nyc9",
+			want:   errors.Errorf(errRegionUnknown, "nyc9"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRegion(regions, tc.slug, tc.features...)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateRegion(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reservedip contains helpers to manage DigitalOcean reserved IPs.
+package reservedip
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/reservedip/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const reservedIPsPath = "v2/reserved_ips"
+
+// ResourceType is the type of reserved IPs in tag requests.
+const ResourceType godo.ResourceType = "reserved_ip"
+
+// ReservedIP is a DigitalOcean reserved IP. The vendored godo only supports
+// reserved IPs as floating IPs, which lack the fields added since they were
+// renamed.
+type ReservedIP struct {
+	IP        string        `json:"ip"`
+	Region    *godo.Region  `json:"region,omitempty"`
+	Droplet   *godo.Droplet `json:"droplet,omitempty"`
+	Locked    bool          `json:"locked,omitempty"`
+	ProjectID string        `json:"project_id,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
+}
+
+// A CreateRequest is a request to reserve an IP.
+type CreateRequest struct {
+	Region    string `json:"region,omitempty"`
+	DropletID int    `json:"droplet_id,omitempty"`
+}
+
+type reservedIPRoot struct {
+	ReservedIP *ReservedIP `json:"reserved_ip"`
+}
+
+type actionRequest struct {
+	Type      string `json:"type"`
+	DropletID int    `json:"droplet_id,omitempty"`
+}
+
+type actionRoot struct {
+	Action *godo.Action `json:"action"`
+}
+
+// Get gets the reserved IP with the supplied address.
+func Get(ctx context.Context, c *godo.Client, ip string) (*ReservedIP, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, reservedIPsPath+"/"+ip, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &reservedIPRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.ReservedIP, resp, nil
+}
+
+// Create reserves an IP.
+func Create(ctx context.Context, c *godo.Client, r *CreateRequest) (*ReservedIP, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, reservedIPsPath, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &reservedIPRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.ReservedIP, resp, nil
+}
+
+// Delete releases the reserved IP with the supplied address.
+func Delete(ctx context.Context, c *godo.Client, ip string) (*godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodDelete, reservedIPsPath+"/"+ip, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// Assign assigns the reserved IP with the supplied address to the Droplet
+// with the supplied ID. A reserved IP that is assigned to another Droplet is
+// reassigned.
+func Assign(ctx context.Context, c *godo.Client, ip string, dropletID int) (*godo.Action, *godo.Response, error) {
+	return doAction(ctx, c, ip, &actionRequest{Type: "assign", DropletID: dropletID})
+}
+
+// Unassign unassigns the reserved IP with the supplied address from its
+// Droplet.
+func Unassign(ctx context.Context, c *godo.Client, ip string) (*godo.Action, *godo.Response, error) {
+	return doAction(ctx, c, ip, &actionRequest{Type: "unassign"})
+}
+
+func doAction(ctx context.Context, c *godo.Client, ip string, r *actionRequest) (*godo.Action, *godo.Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, reservedIPsPath+"/"+ip+"/actions", r)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := &actionRoot{}
+	resp, err := c.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	return root.Action, resp, nil
+}
+
+// GenerateCreateRequest returns a request to reserve an IP with the supplied
+// parameters. An IP that is assigned to a Droplet when it is reserved is
+// reserved in the region of the Droplet, so the region is only sent if no
+// Droplet is.
+func GenerateCreateRequest(p v1alpha1.ReservedIPParameters) *CreateRequest {
+	if p.DropletID != nil {
+		return &CreateRequest{DropletID: *p.DropletID}
+	}
+	return &CreateRequest{Region: do.StringValue(p.Region)}
+}
+
+// URN returns the URN of the reserved IP with the supplied address. Reserved
+// IPs kept the URNs they had as floating IPs.
+func URN(ip string) string {
+	return godo.ToURN("FloatingIP", ip)
+}
+
+// GenerateObservation returns the observation of the supplied reserved IP.
+func GenerateObservation(ip ReservedIP) v1alpha1.ReservedIPObservation {
+	o := v1alpha1.ReservedIPObservation{
+		IP:        ip.IP,
+		Region:    do.RegionSlug(ip.Region),
+		Locked:    ip.Locked,
+		ProjectID: ip.ProjectID,
+		Tags:      ip.Tags,
+	}
+	if ip.Droplet != nil {
+		o.DropletID = ip.Droplet.ID
+	}
+	return o
+}
+
+// IsUpToDate returns true if the reserved IP of the supplied observation is
+// assigned to the Droplet of the supplied parameters, or unassigned if they
+// declare none, and its project and tags are up to date.
+func IsUpToDate(p v1alpha1.ReservedIPParameters, o v1alpha1.ReservedIPObservation) bool {
+	add, remove := TagsDiff(p, o)
+	return IsAssigned(p, o) && ProjectUpToDate(p, o) && len(add) == 0 && len(remove) == 0
+}
+
+// IsAssigned returns true if the reserved IP of the supplied observation is
+// assigned to the Droplet of the supplied parameters, or unassigned if they
+// declare none.
+func IsAssigned(p v1alpha1.ReservedIPParameters, o v1alpha1.ReservedIPObservation) bool {
+	return do.IntValue(p.DropletID) == o.DropletID
+}
+
+// ProjectUpToDate returns true if the reserved IP of the supplied
+// observation belongs to the project of the supplied parameters, or if they
+// declare none.
+func ProjectUpToDate(p v1alpha1.ReservedIPParameters, o v1alpha1.ReservedIPObservation) bool {
+	return p.ProjectID == nil || *p.ProjectID == o.ProjectID
+}
+
+// TagsDiff returns the tags of the supplied parameters the reserved IP of the
+// supplied observation lacks, and the tags it carries that they do not
+// declare.
+func TagsDiff(p v1alpha1.ReservedIPParameters, o v1alpha1.ReservedIPObservation) (add, remove []string) {
+	return diff(p.Tags, o.Tags), diff(o.Tags, p.Tags)
+}
+
+// diff returns the elements of a that are not in b.
+func diff(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var d []string
+	for _, s := range a {
+		if !in[s] {
+			d = append(d, s)
+		}
+	}
+	return d
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sshkey contains helpers to manage DigitalOcean SSH keys.
+package sshkey
+
+import (
+	"bytes"
+	"crypto/md5" // nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/sshkey/v1alpha1"
+)
+
+const (
+	errNoPublicKey      = "spec.forProvider.publicKey or spec.forProvider.publicKeySecretRef is required"
+	errBothPublicKeys   = "spec.forProvider.publicKey and spec.forProvider.publicKeySecretRef are mutually exclusive"
+	errMalformedKey     = "public key is not in OpenSSH authorized_keys format"
+	errUnsupportedKey   = "public key type %q is not supported"
+	errKeyTypeMismatch  = "public key is of type %q, but declares type %q"
+	errMalformedKeyData = "public key data is not valid base64"
+)
+
+// keyTypes are the types of public keys DigitalOcean accepts.
+var keyTypes = map[string]bool{
+	"ssh-rsa":             true,
+	"ssh-dss":             true,
+	"ssh-ed25519":         true,
+	"ecdsa-sha2-nistp256": true,
+	"ecdsa-sha2-nistp384": true,
+	"ecdsa-sha2-nistp521": true,
+}
+
+// ValidateParameters returns an error unless exactly one source of the public
+// key is set in the supplied parameters.
+func ValidateParameters(p v1alpha1.SSHKeyParameters) error {
+	switch {
+	case p.PublicKey != nil && p.PublicKeySecretRef != nil:
+		return errors.New(errBothPublicKeys)
+	case p.PublicKey == nil && p.PublicKeySecretRef == nil:
+		return errors.New(errNoPublicKey)
+	}
+	return nil
+}
+
+// Fingerprint validates the supplied public key in OpenSSH authorized_keys
+// format, and returns its MD5 fingerprint as DigitalOcean reports it.
+func Fingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", errors.New(errMalformedKey)
+	}
+	if !keyTypes[fields[0]] {
+		return "", errors.Errorf(errUnsupportedKey, fields[0])
+	}
+	data, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", errors.Wrap(err, errMalformedKeyData)
+	}
+
+	// The key data starts with the length prefixed type of the key.
+	if len(data) < 4 || uint32(len(data)-4) < binary.BigEndian.Uint32(data) {
+		return "", errors.New(errMalformedKey)
+	}
+	if t := data[4 : 4+binary.BigEndian.Uint32(data)]; !bytes.Equal(t, []byte(fields[0])) {
+		return "", errors.Errorf(errKeyTypeMismatch, t, fields[0])
+	}
+
+	// DigitalOcean identifies SSH keys by their MD5 fingerprint.
+	sum := md5.Sum(data) // nolint:gosec
+	octets := make([]string, len(sum))
+	for i, b := range sum {
+		octets[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(octets, ":"), nil
+}
+
+// GenerateObservation returns the observation of the supplied SSH key.
+func GenerateObservation(k godo.Key) v1alpha1.SSHKeyObservation {
+	return v1alpha1.SSHKeyObservation{
+		ID:          k.ID,
+		Name:        k.Name,
+		Fingerprint: k.Fingerprint,
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// StatusConditions maps the statuses reported by the DigitalOcean API for a
+// kind of resource, e.g. 'new' or 'active', to the function returning the
+// Crossplane condition that status corresponds to. Each controller declares
+// its table once so that statuses are handled consistently.
+type StatusConditions map[string]func() xpv1.Condition
+
+// Condition returns the condition the supplied status corresponds to, and
+// whether the status is in the table. A nil table contains no statuses.
+// Resources the DigitalOcean API does not report a status for, e.g. VPCs, use
+// SetAvailableOnObserve rather than a table.
+func (m StatusConditions) Condition(status string) (xpv1.Condition, bool) {
+	if m == nil {
+		return xpv1.Condition{}, false
+	}
+	fn, ok := m[status]
+	if !ok {
+		return xpv1.Condition{}, false
+	}
+	return fn(), true
+}
+
+// SetAvailableOnObserve sets the Available condition on the supplied resource
+// once its Observe found it usable without a StatusConditions table. That is
+// the case for resources the DigitalOcean API does not report a status for,
+// e.g. volumes or tags, which must not linger in Creating once they exist, and
+// for resources whose availability is derived from other observations, e.g.
+// the active members of a DropletGroup. Controllers call it rather than
+// xpv1.Available directly, so that every Available condition not backed by an
+// API status is set next to the tables that handle the others.
+func SetAvailableOnObserve(o resource.Conditioned) {
+	o.SetConditions(xpv1.Available())
+}
+
+// SetCondition sets the condition the supplied status corresponds to on the
+// supplied resource. The conditions of the resource are left untouched if the
+// status is not in the table.
+func (m StatusConditions) SetCondition(o resource.Conditioned, status string) {
+	if c, ok := m.Condition(status); ok {
+		o.SetConditions(c)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// conditioned is a minimal resource.Conditioned.
+type conditioned struct {
+	xpv1.ConditionedStatus
+}
+
+func TestStatusConditions(t *testing.T) {
+	table := StatusConditions{
+		"new":    xpv1.Creating,
+		"active": xpv1.Available,
+	}
+
+	cases := map[string]struct {
+		reason   string
+		table    StatusConditions
+		existing []xpv1.Condition
+		status   string
+		want     []xpv1.Condition
+	}{
+		"Mapped": {
+			reason: "A status in the table should set the condition it maps to.",
+			table:  table,
+			status: "new",
+			want:   []xpv1.Condition{xpv1.Creating()},
+		},
+		"MappedReplacesReady": {
+			reason:   "A status in the table should replace an existing Ready condition.",
+			table:    table,
+			existing: []xpv1.Condition{xpv1.Creating()},
+			status:   "active",
+			want:     []xpv1.Condition{xpv1.Available()},
+		},
+		"Unmapped": {
+			reason:   "A status that is not in the table should leave the conditions untouched.",
+			table:    table,
+			existing: []xpv1.Condition{xpv1.Available()},
+			status:   "off",
+			want:     []xpv1.Condition{xpv1.Available()},
+		},
+		"Empty": {
+			reason: "An empty status should not set any condition.",
+			table:  table,
+		},
+		"NilTable": {
+			reason:   "A nil table should leave the conditions untouched rather than report any status as available.",
+			existing: []xpv1.Condition{xpv1.Creating()},
+			status:   "active",
+			want:     []xpv1.Condition{xpv1.Creating()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &conditioned{}
+			o.SetConditions(tc.existing...)
+			tc.table.SetCondition(o, tc.status)
+			if diff := cmp.Diff(tc.want, o.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nSetCondition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetAvailableOnObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		existing []xpv1.Condition
+		want     []xpv1.Condition
+	}{
+		"Created": {
+			reason:   "A resource without a status should be available as soon as it is observed after being created.",
+			existing: []xpv1.Condition{xpv1.Creating()},
+			want:     []xpv1.Condition{xpv1.Available()},
+		},
+		"NoConditions": {
+			reason: "An observed resource without a status should be available, e.g. once it was adopted.",
+			want:   []xpv1.Condition{xpv1.Available()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &conditioned{}
+			o.SetConditions(tc.existing...)
+			SetAvailableOnObserve(o)
+			if diff := cmp.Diff(tc.want, o.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nSetAvailableOnObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
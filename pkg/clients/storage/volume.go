@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage contains helpers to manage DigitalOcean block storage.
+package storage
+
+import (
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/storage/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const errLabelWithoutFilesystem = "spec.forProvider.filesystemLabel requires spec.forProvider.filesystemType"
+
+// ValidateVolume returns an error if the supplied parameters would be
+// rejected by the API. A filesystem label is only applied when the volume
+// is formatted, so it is rejected without a filesystem type rather than
+// silently ignored.
+func ValidateVolume(p v1alpha1.VolumeParameters) error {
+	if p.FilesystemLabel != nil && p.FilesystemType == nil {
+		return errors.New(errLabelWithoutFilesystem)
+	}
+	return nil
+}
+
+// GenerateVolume returns a request that creates a volume with the supplied
+// name and parameters. The volume is formatted at creation if a filesystem
+// type is set.
+func GenerateVolume(name string, p v1alpha1.VolumeParameters) *godo.VolumeCreateRequest {
+	return &godo.VolumeCreateRequest{
+		Name:            name,
+		Region:          p.Region,
+		SizeGigaBytes:   p.SizeGigabytes,
+		Description:     do.StringValue(p.Description),
+		SnapshotID:      do.StringValue(p.SnapshotID),
+		FilesystemType:  do.StringValue(p.FilesystemType),
+		FilesystemLabel: do.StringValue(p.FilesystemLabel),
+		Tags:            p.Tags,
+	}
+}
+
+// GenerateObservation returns the observation of the supplied volume.
+func GenerateObservation(observed godo.Volume) v1alpha1.VolumeObservation {
+	return v1alpha1.VolumeObservation{
+		ID:              observed.ID,
+		Name:            observed.Name,
+		Region:          do.RegionSlug(observed.Region),
+		SizeGigabytes:   observed.SizeGigaBytes,
+		DropletIDs:      observed.DropletIDs,
+		FilesystemType:  observed.FilesystemType,
+		FilesystemLabel: observed.FilesystemLabel,
+		Tags:            observed.Tags,
+	}
+}
+
+// LateInitializeVolume fills the unset optional fields of the supplied
+// parameters from the supplied volume, e.g. the filesystem of a volume that
+// was formatted after it was created.
+func LateInitializeVolume(p *v1alpha1.VolumeParameters, observed godo.Volume) {
+	p.Description = do.LateInitializeString(p.Description, observed.Description)
+	p.FilesystemType = do.LateInitializeString(p.FilesystemType, observed.FilesystemType)
+	p.FilesystemLabel = do.LateInitializeString(p.FilesystemLabel, observed.FilesystemLabel)
+}
+
+// ImmutableFields returns whether the desired value of each immutable field
+// of the supplied VolumeParameters matches the supplied volume. A volume
+// cannot be shrunk, so a size below the observed size is reported too.
+func ImmutableFields(p v1alpha1.VolumeParameters, observed godo.Volume) do.ImmutableFields {
+	f := do.ImmutableFields{
+		"sizeGigabytes":   p.SizeGigabytes >= observed.SizeGigaBytes,
+		"description":     p.Description == nil || *p.Description == observed.Description,
+		"filesystemType":  p.FilesystemType == nil || *p.FilesystemType == observed.FilesystemType,
+		"filesystemLabel": p.FilesystemLabel == nil || *p.FilesystemLabel == observed.FilesystemLabel,
+	}
+	if observed.Region != nil {
+		f["region"] = p.Region == observed.Region.Slug
+	}
+	return f
+}
+
+// NeedsResize returns true if the supplied volume is smaller than the
+// supplied parameters ask for.
+func NeedsResize(p v1alpha1.VolumeParameters, observed godo.Volume) bool {
+	return p.SizeGigabytes > observed.SizeGigaBytes
+}
+
+// TagsDiff returns the tags of the supplied parameters the volume of the
+// supplied observation lacks, and the tags it carries that must be removed
+// from it. Tags it carries that the parameters do not declare are only
+// removed if they manage the tags of the volume exclusively, since they may
+// have been applied by something else.
+func TagsDiff(p v1alpha1.VolumeParameters, o v1alpha1.VolumeObservation) (add, remove []string) {
+	add = diff(p.Tags, o.Tags)
+	if do.BoolValue(p.ManageTags) {
+		remove = diff(o.Tags, p.Tags)
+	}
+	return add, remove
+}
+
+// TagsUpToDate returns true if the volume of the supplied observation
+// carries the tags of the supplied parameters, and no others if they manage
+// its tags exclusively.
+func TagsUpToDate(p v1alpha1.VolumeParameters, o v1alpha1.VolumeObservation) bool {
+	add, remove := TagsDiff(p, o)
+	return len(add) == 0 && len(remove) == 0
+}
+
+// diff returns the elements of a that are not in b.
+func diff(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var d []string
+	for _, s := range a {
+		if !in[s] {
+			d = append(d, s)
+		}
+	}
+	return d
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/storage/v1alpha1"
+)
+
+func TestTagsDiff(t *testing.T) {
+	managed := true
+
+	type want struct {
+		add    []string
+		remove []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		params   v1alpha1.VolumeParameters
+		observed []string
+		want     want
+	}{
+		"Added": {
+			reason:   "Declared tags the volume lacks should be added.",
+			params:   v1alpha1.VolumeParameters{Tags: []string{"team:storage", "env:prod"}},
+			observed: []string{"env:prod"},
+			want:     want{add: []string{"team:storage"}},
+		},
+		"ExternallyManaged": {
+			reason:   "Tags the volume carries that are not declared should be left in place unless the Volume manages its tags.",
+			params:   v1alpha1.VolumeParameters{Tags: []string{"env:prod"}},
+			observed: []string{"env:prod", "cost-center:42"},
+			want:     want{},
+		},
+		"Managed": {
+			reason:   "Tags the volume carries that are not declared should be removed if the Volume manages its tags.",
+			params:   v1alpha1.VolumeParameters{Tags: []string{"env:prod"}, ManageTags: &managed},
+			observed: []string{"env:prod", "cost-center:42"},
+			want:     want{remove: []string{"cost-center:42"}},
+		},
+		"ManagedCleared": {
+			reason:   "All tags should be removed from a volume whose Volume manages its tags and declares none.",
+			params:   v1alpha1.VolumeParameters{ManageTags: &managed},
+			observed: []string{"env:prod"},
+			want:     want{remove: []string{"env:prod"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := TagsDiff(tc.params, v1alpha1.VolumeObservation{Tags: tc.observed})
+			if diff := cmp.Diff(tc.want, want{add: add, remove: remove}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nTagsDiff(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got, upToDate := TagsUpToDate(tc.params, v1alpha1.VolumeObservation{Tags: tc.observed}), len(add)+len(remove) == 0; got != upToDate {
+				t.Errorf("\n%s\nTagsUpToDate(...): want %t, got %t", tc.reason, upToDate, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vpc contains helpers to observe DigitalOcean VPCs.
+package vpc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1"
+)
+
+const natGatewaysPath = "v2/vpc_nat_gateways"
+
+// NATGateway is a DigitalOcean VPC NAT gateway. The vendored godo does not
+// support NAT gateways yet.
+type NATGateway struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name,omitempty"`
+	State    string              `json:"state,omitempty"`
+	Region   string              `json:"region,omitempty"`
+	VPCs     []NATGatewayVPC     `json:"vpcs,omitempty"`
+	Egresses *NATGatewayEgresses `json:"egresses,omitempty"`
+}
+
+// NATGatewayVPC is a VPC a NAT gateway is attached to.
+type NATGatewayVPC struct {
+	VPCUUID        string `json:"vpc_uuid"`
+	GatewayIP      string `json:"gateway_ip,omitempty"`
+	DefaultGateway bool   `json:"default_gateway,omitempty"`
+}
+
+// NATGatewayEgresses are the addresses traffic leaves a NAT gateway from.
+type NATGatewayEgresses struct {
+	PublicGateways []NATGatewayPublicGateway `json:"public_gateways,omitempty"`
+}
+
+// NATGatewayPublicGateway is a public address of a NAT gateway.
+type NATGatewayPublicGateway struct {
+	IPv4 string `json:"ipv4,omitempty"`
+}
+
+type natGatewaysRoot struct {
+	NATGateways []NATGateway `json:"vpc_nat_gateways"`
+}
+
+// ListNATGateways lists the NAT gateways of the account. Accounts or regions
+// without NAT gateways have none: the API rejecting the request because the
+// feature is not available is not an error.
+func ListNATGateways(ctx context.Context, c *godo.Client) ([]NATGateway, error) {
+	path := natGatewaysPath + "?" + url.Values{"per_page": {"200"}}.Encode()
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	root := &natGatewaysRoot{}
+	_, err = c.Do(ctx, req, root)
+	var e *godo.ErrorResponse
+	if errors.As(err, &e) && e.Response != nil {
+		switch e.Response.StatusCode {
+		case http.StatusNotFound, http.StatusForbidden:
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return root.NATGateways, nil
+}
+
+// GenerateNATGateways returns the observations of those of the supplied NAT
+// gateways that are attached to the VPC with the supplied ID.
+func GenerateNATGateways(id string, gateways []NATGateway) []v1alpha1.VPCNATGateway {
+	var observed []v1alpha1.VPCNATGateway
+	for _, g := range gateways {
+		for _, v := range g.VPCs {
+			if v.VPCUUID != id {
+				continue
+			}
+			o := v1alpha1.VPCNATGateway{
+				ID:             g.ID,
+				Name:           g.Name,
+				State:          g.State,
+				GatewayIP:      v.GatewayIP,
+				DefaultGateway: v.DefaultGateway,
+			}
+			if g.Egresses != nil {
+				for _, p := range g.Egresses.PublicGateways {
+					o.EgressIPs = append(o.EgressIPs, p.IPv4)
+				}
+			}
+			observed = append(observed, o)
+		}
+	}
+	return observed
+}
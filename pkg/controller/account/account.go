@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package account
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/account/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotAccount       = "managed resource is not an Account resource"
+	errGetAccount       = "cannot get account"
+	errCountDroplets    = "cannot count droplets"
+	errCountFloatingIPs = "cannot count floating IPs"
+	errCountVolumes     = "cannot count volumes"
+)
+
+// SetupAccount adds a controller that reconciles Account managed
+// resources.
+func SetupAccount(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.AccountGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Account{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.AccountGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.AccountGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &accountConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type accountConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *accountConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &accountExternal{Client: client}, nil
+}
+
+type accountExternal struct {
+	*godo.Client
+}
+
+func (c *accountExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAccount)
+	}
+
+	// An Account is observe-only. Once it is deleted we report it as gone
+	// so that the reconciler removes its finalizer without touching the
+	// DigitalOcean account.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, _, err := c.Account.Get(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetAccount)
+	}
+
+	// Listing a single item per page is enough to learn the total number of
+	// items from the response metadata.
+	opt := &godo.ListOptions{PerPage: 1}
+	_, droplets, err := c.Droplets.List(ctx, opt)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCountDroplets)
+	}
+	_, floatingIPs, err := c.FloatingIPs.List(ctx, opt)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCountFloatingIPs)
+	}
+	_, volumes, err := c.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCountVolumes)
+	}
+
+	cr.Status.AtProvider = v1alpha1.AccountObservation{
+		Email:           observed.Email,
+		UUID:            observed.UUID,
+		Status:          observed.Status,
+		DropletLimit:    observed.DropletLimit,
+		DropletCount:    total(droplets),
+		FloatingIPLimit: observed.FloatingIPLimit,
+		FloatingIPCount: total(floatingIPs),
+		VolumeLimit:     observed.VolumeLimit,
+		VolumeCount:     total(volumes),
+	}
+	do.SetAvailableOnObserve(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func total(resp *godo.Response) int {
+	if resp == nil || resp.Meta == nil {
+		return 0
+	}
+	return resp.Meta.Total
+}
+
+func (c *accountExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	// Accounts are observe-only and always exist.
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *accountExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Accounts are observe-only and cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *accountExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	// Accounts are observe-only and are never deleted.
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/account/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+func serveAccount(t *testing.T) http.HandlerFunc {
+	bodies := map[string]interface{}{
+		"/v2/account": map[string]interface{}{"account": godo.Account{
+			Email:           "ops@example.com",
+			Status:          "active",
+			DropletLimit:    25,
+			FloatingIPLimit: 3,
+			VolumeLimit:     100,
+		}},
+		"/v2/droplets":     map[string]interface{}{"droplets": []godo.Droplet{{ID: 1}}, "meta": godo.Meta{Total: 12}},
+		"/v2/floating_ips": map[string]interface{}{"floating_ips": []godo.FloatingIP{{IP: "203.0.113.1"}}, "meta": godo.Meta{Total: 2}},
+		"/v2/volumes":      map[string]interface{}{"volumes": []godo.Volume{{ID: "v"}}, "meta": godo.Meta{Total: 40}},
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAccountObserve(t *testing.T) {
+	type want struct {
+		o  managed.ExternalObservation
+		at v1alpha1.AccountObservation
+	}
+
+	now := metav1.Now()
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Account
+		want   want
+	}{
+		"LimitsAndUsage": {
+			reason: "The account limits and current usage should be reported in the status.",
+			cr:     &v1alpha1.Account{},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				at: v1alpha1.AccountObservation{
+					Email:           "ops@example.com",
+					Status:          "active",
+					DropletLimit:    25,
+					DropletCount:    12,
+					FloatingIPLimit: 3,
+					FloatingIPCount: 2,
+					VolumeLimit:     100,
+					VolumeCount:     40,
+				},
+			},
+		},
+		"Deleted": {
+			reason: "A deleted Account should be reported as gone without calling the API.",
+			cr:     &v1alpha1.Account{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &accountExternal{Client: fake.NewClient(t, serveAccount(t))}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.at, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/app/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	doapp "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/app"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotApp               = "managed resource is not an App resource"
+	errGetApp               = "cannot get App"
+	errListDeployments      = "cannot list deployments of App"
+	errAppCreateFailed      = "creation of App resource has failed"
+	errAppUpdateFailed      = "update of App resource has failed"
+	errAppDeleteFailed      = "deletion of App resource has failed"
+	errCreateDeployment     = "cannot deploy App"
+	errRollback             = "cannot roll App back to deployment %q"
+	errRevertRollback       = "cannot revert rollback of App"
+	errPersistGeneration    = "cannot persist deployment generation of App"
+	errDeploymentGeneration = "cannot parse deployment generation of App"
+)
+
+// SetupApp adds a controller that reconciles App managed resources.
+func SetupApp(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.AppGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.App{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.AppGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.AppGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &appConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type appConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *appConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &appExternal{Client: client, kube: c.kube}, nil
+}
+
+type appExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *appExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.App)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotApp)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Apps.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetApp)
+	}
+	deployments, _, err := c.Apps.ListDeployments(ctx, observed.ID, &godo.ListOptions{PerPage: doapp.MaxDeployments})
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListDeployments)
+	}
+	deployed, err := deployedGeneration(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = doapp.GenerateObservation(*observed, deployments)
+	cr.Status.AtProvider.DeploymentGeneration = deployed
+	switch {
+	case cr.Status.AtProvider.ActiveDeploymentID != "":
+		do.SetAvailableOnObserve(cr)
+	case len(deployments) > 0 && deployments[0].Phase == godo.DeploymentPhase_Error:
+		cr.SetConditions(xpv1.Unavailable())
+	default:
+		cr.SetConditions(xpv1.Creating())
+	}
+
+	// Changes to the spec of an app that is rolled back are only deployed
+	// once the rollback is reverted.
+	upToDate := !doapp.NeedsRollback(cr.Spec.ForProvider, cr.Status.AtProvider) &&
+		!doapp.NeedsDeployment(cr.Spec.ForProvider, deployed)
+	if upToDate && cr.Status.AtProvider.PinnedDeploymentID == "" {
+		if upToDate, err = doapp.SpecUpToDate(cr.Spec.ForProvider, observed.Spec); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// deployedGeneration returns the deployment generation that was last deployed
+// for the supplied App.
+func deployedGeneration(cr *v1alpha1.App) (int64, error) {
+	v, ok := cr.GetAnnotations()[doapp.AnnotationDeploymentGeneration]
+	if !ok {
+		return 0, nil
+	}
+	g, err := strconv.ParseInt(v, 10, 64)
+	return g, errors.Wrap(err, errDeploymentGeneration)
+}
+
+func setDeployedGeneration(cr *v1alpha1.App, g int64) {
+	meta.AddAnnotations(cr, map[string]string{doapp.AnnotationDeploymentGeneration: strconv.FormatInt(g, 10)})
+}
+
+func (c *appExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.App)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotApp)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	spec, err := doapp.GenerateAppSpec(cr.GetName(), cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	app, _, err := c.Apps.Create(ctx, &godo.AppCreateRequest{Spec: spec})
+	if err != nil || app == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errAppCreateFailed)
+	}
+
+	// Creating an app deploys it, so its current deployment generation does
+	// not need to be deployed again.
+	meta.SetExternalName(cr, app.ID)
+	setDeployedGeneration(cr, do.Int64Value(cr.Spec.ForProvider.DeploymentGeneration))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *appExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.App)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotApp)
+	}
+
+	// A rollback, an update of the spec and a deployment each deploy the
+	// app, so only one of them is done per reconcile.
+	id := meta.GetExternalName(cr)
+	if doapp.NeedsRollback(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if target := do.StringValue(cr.Spec.ForProvider.RollbackToDeploymentID); target != "" {
+			_, err := doapp.Rollback(ctx, c.Client, id, target)
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errRollback, target)
+		}
+		_, err := doapp.RevertRollback(ctx, c.Client, id)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRevertRollback)
+	}
+
+	if cr.Status.AtProvider.PinnedDeploymentID == "" {
+		observed, _, err := c.Apps.Get(ctx, id)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errGetApp)
+		}
+		upToDate, err := doapp.SpecUpToDate(cr.Spec.ForProvider, observed.Spec)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if !upToDate {
+			spec, err := doapp.GenerateAppSpec(observed.Spec.Name, cr.Spec.ForProvider)
+			if err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+			_, _, err = c.Apps.Update(ctx, id, &godo.AppUpdateRequest{Spec: spec})
+			return managed.ExternalUpdate{}, errors.Wrap(err, errAppUpdateFailed)
+		}
+	}
+
+	if g := do.Int64Value(cr.Spec.ForProvider.DeploymentGeneration); doapp.NeedsDeployment(cr.Spec.ForProvider, cr.Status.AtProvider.DeploymentGeneration) {
+		if _, _, err := c.Apps.CreateDeployment(ctx, id, &godo.DeploymentCreateRequest{ForceBuild: true}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateDeployment)
+		}
+		setDeployedGeneration(cr, g)
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errPersistGeneration)
+		}
+		cr.Status.AtProvider.DeploymentGeneration = g
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *appExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.App)
+	if !ok {
+		return errors.New(errNotApp)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Apps.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errAppDeleteFailed)
+}
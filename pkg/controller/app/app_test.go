@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/app/v1alpha1"
+	doapp "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/app"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const testAppID = "app-id"
+
+type appModifier func(*v1alpha1.App)
+
+func withDeploymentGeneration(g int64) appModifier {
+	return func(cr *v1alpha1.App) { cr.Spec.ForProvider.DeploymentGeneration = &g }
+}
+
+func withDeployed(g string) appModifier {
+	return func(cr *v1alpha1.App) {
+		meta.AddAnnotations(cr, map[string]string{doapp.AnnotationDeploymentGeneration: g})
+	}
+}
+
+func withRollbackTo(id string) appModifier {
+	return func(cr *v1alpha1.App) { cr.Spec.ForProvider.RollbackToDeploymentID = &id }
+}
+
+func app(m ...appModifier) *v1alpha1.App {
+	cr := &v1alpha1.App{}
+	cr.SetName("example")
+	meta.SetExternalName(cr, testAppID)
+	cr.Spec.ForProvider.Spec = runtime.RawExtension{Raw: []byte(`{"region":"ams","services":[{"name":"web","image":{"registry_type":"DOCR","repository":"web","tag":"latest"}}]}`)}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func observedApp() godo.App {
+	return godo.App{
+		ID: testAppID,
+		Spec: &godo.AppSpec{
+			Name:   "example",
+			Region: "ams",
+			Services: []*godo.AppServiceSpec{{
+				Name:          "web",
+				Image:         &godo.ImageSourceSpec{RegistryType: godo.ImageSourceSpecRegistryType_DOCR, Repository: "web", Tag: "latest"},
+				InstanceCount: 1,
+				HTTPPort:      8080,
+			}},
+		},
+		ActiveDeployment: &godo.Deployment{ID: "dep-2"},
+	}
+}
+
+func deployments() []*godo.Deployment {
+	created := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	return []*godo.Deployment{
+		{ID: "dep-2", Phase: godo.DeploymentPhase_Active, Cause: "manual", CreatedAt: created},
+		{ID: "dep-1", Phase: godo.DeploymentPhase_Superseded, Cause: "initial deployment", CreatedAt: created.Add(-time.Hour)},
+	}
+}
+
+func TestAppObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.App
+		want   managed.ExternalObservation
+	}{
+		"UpToDate": {
+			reason: "An App whose spec is contained in the observed spec should be up to date, regardless of the defaults of App Platform.",
+			cr:     app(withDeploymentGeneration(1), withDeployed("1")),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"GenerationBumped": {
+			reason: "An App whose deployment generation was increased past the deployed one should not be up to date.",
+			cr:     app(withDeploymentGeneration(2), withDeployed("1")),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"SpecChanged": {
+			reason: "An App whose spec differs from the observed spec should not be up to date.",
+			cr: app(func(cr *v1alpha1.App) {
+				cr.Spec.ForProvider.Spec.Raw = []byte(`{"region":"fra"}`)
+			}),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"RollbackRequested": {
+			reason: "An App that should be rolled back to a deployment it is not pinned to should not be up to date.",
+			cr:     app(withRollbackTo("dep-1")),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/apps/" + testAppID:                  fake.Respond(t, map[string]interface{}{"app": observedApp()}),
+				"GET /v2/apps/" + testAppID + "/deployments": fake.Respond(t, map[string]interface{}{"deployments": deployments()}),
+			})
+			e := &appExternal{Client: fake.NewClient(t, h)}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			wantDeployments := []v1alpha1.AppDeployment{
+				{ID: "dep-2", Phase: "ACTIVE", Cause: "manual", CreatedAt: "2021-06-01T12:00:00Z"},
+				{ID: "dep-1", Phase: "SUPERSEDED", Cause: "initial deployment", CreatedAt: "2021-06-01T11:00:00Z"},
+			}
+			if diff := cmp.Diff(wantDeployments, tc.cr.Status.AtProvider.Deployments); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want deployments, +got deployments:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAppUpdateRedeploy(t *testing.T) {
+	type want struct {
+		calls    []string
+		deployed string
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.App
+		want   want
+	}{
+		"GenerationBumped": {
+			reason: "Increasing the deployment generation should trigger a deployment that rebuilds the app, once.",
+			cr:     app(withDeploymentGeneration(2), withDeployed("1")),
+			want:   want{calls: []string{"deploy force_build=true"}, deployed: "2"},
+		},
+		"GenerationUnchanged": {
+			reason: "An App whose deployment generation was deployed should not be deployed again.",
+			cr:     app(withDeploymentGeneration(2), withDeployed("2")),
+			want:   want{deployed: "2"},
+		},
+		"Rollback": {
+			reason: "An App should be rolled back to the deployment it references rather than being deployed.",
+			cr:     app(withDeploymentGeneration(2), withDeployed("1"), withRollbackTo("dep-1")),
+			want:   want{calls: []string{"rollback dep-1"}, deployed: "1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/apps/" + testAppID:                  fake.Respond(t, map[string]interface{}{"app": observedApp()}),
+				"GET /v2/apps/" + testAppID + "/deployments": fake.Respond(t, map[string]interface{}{"deployments": deployments()}),
+				"POST /v2/apps/" + testAppID + "/deployments": func(w http.ResponseWriter, r *http.Request) {
+					req := &godo.DeploymentCreateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+						t.Error(err)
+					}
+					if req.ForceBuild {
+						got.calls = append(got.calls, "deploy force_build=true")
+					} else {
+						got.calls = append(got.calls, "deploy")
+					}
+					fake.Respond(t, map[string]interface{}{"deployment": godo.Deployment{ID: "dep-3"}})(w, r)
+				},
+				"POST /v2/apps/" + testAppID + "/rollback": func(w http.ResponseWriter, r *http.Request) {
+					req := struct {
+						DeploymentID string `json:"deployment_id"`
+					}{}
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Error(err)
+					}
+					got.calls = append(got.calls, "rollback "+req.DeploymentID)
+					w.WriteHeader(http.StatusOK)
+				},
+			})
+			e := &appExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			if _, err := e.Observe(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			got.deployed = tc.cr.GetAnnotations()[doapp.AnnotationDeploymentGeneration]
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAppCreateRecordsGeneration(t *testing.T) {
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"POST /v2/apps": func(w http.ResponseWriter, r *http.Request) {
+			req := &godo.AppCreateRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			if req.Spec == nil || req.Spec.Name != "example" {
+				t.Errorf("want the app to be named after the managed resource, got %+v", req.Spec)
+			}
+			fake.Respond(t, map[string]interface{}{"app": godo.App{ID: testAppID}})(w, r)
+		},
+	})
+	e := &appExternal{Client: fake.NewClient(t, h)}
+	cr := app(withDeploymentGeneration(3))
+	meta.SetExternalName(cr, "")
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if diff := cmp.Diff(testAppID, meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): -want external-name, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff("3", cr.GetAnnotations()[doapp.AnnotationDeploymentGeneration]); diff != "" {
+		t.Errorf("e.Create(...): want the deployment generation of a new app to be deployed: -want, +got:\n%s", diff)
+	}
+}
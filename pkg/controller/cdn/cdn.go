@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/cdn/v1alpha1"
+	certificatev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	docdn "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/cdn"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotCDN    = "managed resource is not a CDN resource"
+	errGetCDN    = "cannot get CDN"
+	errCDNUpdate = "cannot update managed CDN resource"
+
+	errCDNCreateFailed = "creation of CDN resource has failed"
+	errCDNUpdateFailed = "update of CDN resource has failed"
+	errCDNDeleteFailed = "deletion of CDN resource has failed"
+
+	errGetCertificate       = "cannot get Certificate %q of CDN"
+	errCertificateNotIssued = "Certificate %q of CDN has not been issued yet"
+)
+
+// SetupCDN adds a controller that reconciles CDN managed resources.
+func SetupCDN(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.CDNGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CDN{}).
+		Watches(&source.Kind{Type: &certificatev1alpha1.Certificate{}}, handler.EnqueueRequestsFromMapFunc(certificateDependents(mgr.GetClient()))).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CDNGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.CDNGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &cdnConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+// certificateDependents returns a function that maps a Certificate to the CDNs
+// that reference it, so that they are updated as soon as a renewed
+// Certificate gets a new ID rather than on their next poll.
+func certificateDependents(kube client.Reader) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.CDNList{}
+		if err := kube.List(context.Background(), l); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, cdn := range l.Items {
+			if ref := cdn.Spec.ForProvider.CertificateRef; ref != nil && ref.Name == o.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: cdn.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+type cdnConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *cdnConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &cdnExternal{Client: client, kube: c.kube}, nil
+}
+
+type cdnExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *cdnExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CDN)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCDN)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.CDNs.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetCDN)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		docdn.LateInitialize(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errCDNUpdate)
+			}
+		}
+	}
+
+	cr.Status.AtProvider = docdn.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+	cr.SetConditions(do.ImmutableFieldCondition(docdn.ImmutableFields(cr.Spec.ForProvider, *observed)))
+
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: docdn.TTLUpToDate(p, *observed) && docdn.CustomDomainUpToDate(p, *observed),
+	}, nil
+}
+
+// desiredParameters returns the parameters of the supplied CDN with the ID of
+// its referenced Certificate. The reference is resolved on every call rather
+// than once, so a referenced Certificate that is renewed with a new ID stays
+// in use.
+func (c *cdnExternal) desiredParameters(ctx context.Context, cr *v1alpha1.CDN) (v1alpha1.CDNParameters, error) {
+	p := *cr.Spec.ForProvider.DeepCopy()
+	ref := p.CertificateRef
+	if ref == nil {
+		return p, nil
+	}
+	cert := &certificatev1alpha1.Certificate{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cert); err != nil {
+		return p, errors.Wrapf(err, errGetCertificate, ref.Name)
+	}
+	id := certificatev1alpha1.CertificateID()(cert)
+	if id == "" {
+		return p, errors.Errorf(errCertificateNotIssued, ref.Name)
+	}
+	p.CertificateID = &id
+	return p, nil
+}
+
+func (c *cdnExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CDN)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCDN)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cdn, _, err := c.CDNs.Create(ctx, docdn.GenerateCDN(p))
+	if err != nil || cdn == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCDNCreateFailed)
+	}
+
+	meta.SetExternalName(cr, cdn.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *cdnExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CDN)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCDN)
+	}
+
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	id := meta.GetExternalName(cr)
+	observed, _, err := c.CDNs.Get(ctx, id)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetCDN)
+	}
+
+	if !docdn.TTLUpToDate(p, *observed) {
+		if _, _, err := c.CDNs.UpdateTTL(ctx, id, &godo.CDNUpdateTTLRequest{TTL: uint32(*p.TTL)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCDNUpdateFailed)
+		}
+	}
+	if docdn.CustomDomainUpToDate(p, *observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+	update := &godo.CDNUpdateCustomDomainRequest{CustomDomain: do.StringValue(p.CustomDomain), CertificateID: do.StringValue(p.CertificateID)}
+	if _, _, err := c.CDNs.UpdateCustomDomain(ctx, id, update); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCDNUpdateFailed)
+	}
+
+	// A custom domain whose Certificate was renewed is served with its new
+	// ID from now on.
+	if ref := p.CertificateRef; ref != nil && observed.CertificateID != "" && observed.CertificateID != update.CertificateID {
+		cr.SetConditions(do.CertificateRotatedCondition([]string{
+			do.CertificateRotation("custom domain", ref.Name, observed.CertificateID, update.CertificateID),
+		}))
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *cdnExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CDN)
+	if !ok {
+		return errors.New(errNotCDN)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.CDNs.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errCDNDeleteFailed)
+}
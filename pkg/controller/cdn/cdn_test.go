@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/cdn/v1alpha1"
+	certificatev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const testCDNID = "19f06b6a-3ace-4315-b086-499a0e521b76"
+
+func TestCDNCertificateRefFollowsRenewal(t *testing.T) {
+	ttl := 3600
+	observed := godo.CDN{
+		ID:            testCDNID,
+		Origin:        "static.example.com.nyc3.digitaloceanspaces.com",
+		TTL:           uint32(ttl),
+		CustomDomain:  "static.example.com",
+		CertificateID: "static-cert",
+	}
+
+	var got *godo.CDNUpdateCustomDomainRequest
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/cdn/endpoints/" + testCDNID: func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"endpoint": observed})
+		},
+		"PUT /v2/cdn/endpoints/" + testCDNID: func(w http.ResponseWriter, r *http.Request) {
+			got = &godo.CDNUpdateCustomDomainRequest{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Error(err)
+			}
+			observed.CertificateID = got.CertificateID
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"endpoint": observed})
+		},
+	})
+
+	// The referenced Certificate was renewed with a new ID after the ID it
+	// had was resolved into the spec.
+	cr := &v1alpha1.CDN{}
+	meta.SetExternalName(cr, testCDNID)
+	cr.Spec.ForProvider = v1alpha1.CDNParameters{
+		Origin:         observed.Origin,
+		TTL:            &ttl,
+		CustomDomain:   &observed.CustomDomain,
+		CertificateID:  &observed.CertificateID,
+		CertificateRef: &xpv1.Reference{Name: "static"},
+	}
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			cert, ok := obj.(*certificatev1alpha1.Certificate)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			cert.Status.AtProvider.ID = "static-cert-renewed"
+			cert.Status.AtProvider.State = certificatev1alpha1.StateVerified
+			return nil
+		}),
+	}
+	e := &cdnExternal{kube: kube, Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a CDN whose referenced Certificate was renewed not to be up to date")
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := &godo.CDNUpdateCustomDomainRequest{CustomDomain: "static.example.com", CertificateID: "static-cert-renewed"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want custom domain update, +got custom domain update:\n%s", diff)
+	}
+	rotated := do.CertificateRotatedCondition([]string{`custom domain: Certificate "static" rotated from static-cert to static-cert-renewed`})
+	if diff := cmp.Diff(rotated, cr.GetCondition(do.TypeCertificateRotated), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Update(...): -want CertificateRotated condition, +got CertificateRotated condition:\n%s", diff)
+	}
+}
+
+func TestCDNCertificateDependents(t *testing.T) {
+	cdn := func(name string, ref *xpv1.Reference) v1alpha1.CDN {
+		cr := v1alpha1.CDN{}
+		cr.SetName(name)
+		cr.Spec.ForProvider.CertificateRef = ref
+		return cr
+	}
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1alpha1.CDNList).Items = []v1alpha1.CDN{
+				cdn("static", &xpv1.Reference{Name: "static"}),
+				cdn("media", &xpv1.Reference{Name: "media"}),
+				cdn("plain", nil),
+			}
+			return nil
+		},
+	}
+	cert := &certificatev1alpha1.Certificate{}
+	cert.SetName("static")
+
+	got := certificateDependents(kube)(cert)
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "static"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("certificateDependents(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotCertificate    = "managed resource is not a Certificate resource"
+	errGetCertificate    = "cannot get Certificate"
+	errListCertificates  = "cannot list Certificates"
+	errGetPrivateKey     = "cannot get private key of Certificate"
+	errCertificateUpdate = "cannot update managed Certificate resource"
+
+	errCertificateCreateFailed = "creation of Certificate resource has failed"
+	errCertificateDeleteFailed = "deletion of Certificate resource has failed"
+)
+
+// certificateConditions maps the state of a Certificate to its Ready
+// condition.
+var certificateConditions = do.StatusConditions{
+	v1alpha1.StatePending:  xpv1.Creating,
+	v1alpha1.StateVerified: xpv1.Available,
+	v1alpha1.StateError:    xpv1.Unavailable,
+}
+
+// SetupCertificate adds a controller that reconciles Certificate managed
+// resources.
+func SetupCertificate(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.CertificateGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Certificate{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CertificateGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.CertificateGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &certificateConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type certificateConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *certificateConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &certificateExternal{Client: client, kube: c.kube}, nil
+}
+
+type certificateExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *certificateExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCertificate)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Certificates.Get(ctx, meta.GetExternalName(cr))
+	if do.IgnoreNotFound(err, response) != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCertificate)
+	}
+	if err != nil {
+		// A lets_encrypt certificate is replaced by one with a new ID and the
+		// same name when it is renewed.
+		if observed, err = c.findRenewed(ctx, cr); err != nil || observed == nil {
+			return managed.ExternalObservation{ResourceExists: false}, err
+		}
+		meta.SetExternalName(cr, observed.ID)
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errCertificateUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = v1alpha1.CertificateObservation{
+		ID:                observed.ID,
+		Name:              observed.Name,
+		Type:              observed.Type,
+		State:             observed.State,
+		DNSNames:          observed.DNSNames,
+		NotAfter:          observed.NotAfter,
+		SHA1Fingerprint:   observed.SHA1Fingerprint,
+		CreationTimestamp: observed.Created,
+	}
+	certificateConditions.SetCondition(cr, observed.State)
+
+	// Certificates cannot be changed once they are created.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// findRenewed returns the lets_encrypt certificate the supplied Certificate
+// was renewed as, or nil if it was not renewed.
+func (c *certificateExternal) findRenewed(ctx context.Context, cr *v1alpha1.Certificate) (*godo.Certificate, error) {
+	if cr.Spec.ForProvider.Type != v1alpha1.TypeLetsEncrypt {
+		return nil, nil
+	}
+	opts := &godo.ListOptions{PerPage: 200}
+	for {
+		page, resp, err := c.Certificates.List(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, errListCertificates)
+		}
+		for i := range page {
+			if page[i].Name == cr.GetName() && page[i].Type == v1alpha1.TypeLetsEncrypt {
+				return &page[i], nil
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		current, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = current + 1
+	}
+}
+
+func (c *certificateExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCertificate)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	p := cr.Spec.ForProvider
+	create := &godo.CertificateRequest{
+		Name:             cr.GetName(),
+		Type:             p.Type,
+		DNSNames:         p.DNSNames,
+		LeafCertificate:  do.StringValue(p.LeafCertificate),
+		CertificateChain: do.StringValue(p.CertificateChain),
+	}
+	if ref := p.PrivateKeySecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetPrivateKey)
+		}
+		create.PrivateKey = string(s.Data[ref.Key])
+	}
+
+	cert, _, err := c.Certificates.Create(ctx, create)
+	if err != nil || cert == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCertificateCreateFailed)
+	}
+
+	meta.SetExternalName(cr, cert.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *certificateExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Certificates cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *certificateExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return errors.New(errNotCertificate)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Certificates.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errCertificateDeleteFailed)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const (
+	testCertID      = "892071a0-bb95-49bc-8021-3afd67a210bf"
+	testRenewedCert = "ba9b9c18-6c59-46c2-99df-70da170a42ba"
+)
+
+func certificate(certType string) *v1alpha1.Certificate {
+	cr := &v1alpha1.Certificate{}
+	cr.SetName("web")
+	meta.SetExternalName(cr, testCertID)
+	cr.Spec.ForProvider = v1alpha1.CertificateParameters{Type: certType, DNSNames: []string{"www.example.com"}}
+	return cr
+}
+
+func TestCertificateObserve(t *testing.T) {
+	type want struct {
+		o            managed.ExternalObservation
+		externalName string
+		cond         xpv1.Condition
+	}
+
+	renewed := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/certificates/" + testCertID: http.NotFound,
+		"GET /v2/certificates": fake.Respond(t, map[string]interface{}{"certificates": []godo.Certificate{
+			{ID: "0c2bf7a4-1b8b-4a55-93dc-1d3d7a8a6a0a", Name: "api", Type: v1alpha1.TypeLetsEncrypt, State: v1alpha1.StateVerified},
+			{ID: testRenewedCert, Name: "web", Type: v1alpha1.TypeLetsEncrypt, State: v1alpha1.StateVerified},
+		}}),
+	})
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Certificate
+		h      http.HandlerFunc
+		want   want
+	}{
+		"Pending": {
+			reason: "A Certificate that is not issued yet should be creating.",
+			cr:     certificate(v1alpha1.TypeLetsEncrypt),
+			h: fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/certificates/" + testCertID: fake.Respond(t, map[string]interface{}{"certificate": godo.Certificate{
+					ID: testCertID, Name: "web", Type: v1alpha1.TypeLetsEncrypt, State: v1alpha1.StatePending,
+				}}),
+			}),
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				externalName: testCertID,
+				cond:         xpv1.Creating(),
+			},
+		},
+		"Renewed": {
+			reason: "A lets_encrypt Certificate that was renewed with a new ID should follow the certificate of the same name.",
+			cr:     certificate(v1alpha1.TypeLetsEncrypt),
+			h:      renewed,
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				externalName: testRenewedCert,
+				cond:         xpv1.Available(),
+			},
+		},
+		"CustomDeleted": {
+			reason: "A custom Certificate that was deleted should not exist, even if a certificate of the same name does.",
+			cr:     certificate(v1alpha1.TypeCustom),
+			h:      renewed,
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: false},
+				externalName: testCertID,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &certificateExternal{
+				Client: fake.NewClient(t, tc.h),
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{o: o, externalName: meta.GetExternalName(tc.cr), cond: tc.cr.GetCondition(xpv1.TypeReady)}
+			if tc.want.cond.Type == "" {
+				got.cond = xpv1.Condition{}
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
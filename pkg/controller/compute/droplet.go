@@ -19,10 +19,14 @@ package compute
 import (
 	"context"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,109 +38,381 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	docompute "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
 const (
 	// Error strings.
-	errNotDroplet = "managed resource is not a Droplet resource"
-	errGetDroplet = "cannot get droplet"
+	errNotDroplet   = "managed resource is not a Droplet resource"
+	errGetDroplet   = "cannot get droplet"
+	errAdoptedID    = "adopted Droplet has an invalid ID %q"
+	errListDroplets = "cannot list droplets by tag"
 
 	errDropletCreateFailed = "creation of Droplet resource has failed"
 	errDropletDeleteFailed = "deletion of Droplet resource has failed"
 	errDropletUpdate       = "cannot update managed Droplet resource"
+	errListSizes           = "cannot list Droplet sizes"
+	errSizeNotInRegion     = "size %q is not available in fallback region %q"
+	errUnknownSize         = "unknown Droplet size %q"
+	errUnknownSizeClosest  = "unknown Droplet size %q, closest known sizes are %s"
+	errDiskShrink          = "cannot resize the disk of Droplet to size %q: its %dGB disk is smaller than the current %dGB disk"
+	errPowerOff            = "cannot power off Droplet to resize it"
+	errPowerOn             = "cannot power on resized Droplet"
+	errResize              = "cannot resize Droplet"
+	errGetVPC              = "cannot get VPC of Droplet"
+	errVPCRegionMismatch   = "VPC %q is in region %q, but the Droplet would be created in region %q"
+	errUpdateFeature       = "cannot update feature %q of Droplet"
+	errRename              = "cannot rename Droplet to update its reverse DNS"
+	errAttachVolume        = "cannot attach volume %q to Droplet"
+	errGetImage            = "cannot get image to rebuild Droplet from"
+	errRebuild             = "cannot rebuild Droplet"
+	errListBackups         = "cannot list backups of Droplet"
+	errCleanupTags         = "cannot delete tags of deleted Droplet"
+	errShutdown            = "cannot shut down Droplet before deleting it"
+	errAction              = "cannot observe the action in progress on Droplet"
+	errUntagDroplet        = "cannot remove the dedupe tag of the Droplet to be recreated"
+
+	msgDeleteLocked       = "waiting for the action in progress on the Droplet to complete before deleting it"
+	msgAddressesPending   = "waiting for the Droplet to be assigned an address of every requested IP family"
+	msgAttachmentsPending = "waiting for attachments of the Droplet to be in place: "
+
+	// Event reasons.
+	reasonResizeDisk       event.Reason = "ResizeDisk"
+	reasonImmutableChanged event.Reason = "ImmutableFieldChanged"
+	reasonActionErrored    event.Reason = "ActionErrored"
+	reasonRecreate         event.Reason = "RecreateDroplet"
 )
 
+// sizesTTL is how long the list of Droplet sizes is cached for.
+const sizesTTL = 30 * time.Minute
+
+// regionsTTL is how long the list of regions is cached for.
+const regionsTTL = 30 * time.Minute
+
+// closestSizes is how many of the closest known sizes an unknown size is
+// reported with.
+const closestSizes = 3
+
+// shutdownTimeout is how long a Droplet that is shut down gracefully before
+// it is deleted may take to shut down. It leaves the deletion enough of the
+// reconcile timeout.
+const shutdownTimeout = 30 * time.Second
+
+// dropletConditions maps the status of a Droplet to its Ready condition. The
+// Ready condition is left untouched while a Droplet is off or archived.
+var dropletConditions = do.StatusConditions{
+	v1alpha1.StatusNew:    xpv1.Creating,
+	v1alpha1.StatusActive: xpv1.Available,
+}
+
 // SetupDroplet adds a controller that reconciles Droplet managed
 // resources.
-func SetupDroplet(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDroplet(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
 	name := managed.ControllerName(v1alpha1.DropletGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Droplet{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DropletGroupVersionKind),
-			managed.WithExternalConnecter(&dropletConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DropletGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &dropletConnector{kube: mgr.GetClient(), clients: cc, record: record, sizes: &sizeCache{ttl: sizesTTL}, regions: do.NewRegionCache(regionsTTL)}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithConnectionPublishers(),
+			managed.WithConnectionPublishers(do.NewRotationPolicyPublisher(mgr.GetClient(), managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), dropletRotationPolicy)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record)))
+}
+
+// dropletRotationPolicy returns the connection rotation policy of a Droplet.
+func dropletRotationPolicy(mg resource.Managed) *dov1alpha1.ConnectionRotationPolicy {
+	cr, ok := mg.(*v1alpha1.Droplet)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.RotationPolicy
 }
 
 type dropletConnector struct {
-	kube client.Client
+	kube    client.Client
+	clients *do.ClientCache
+	record  event.Recorder
+	sizes   *sizeCache
+	regions *do.RegionCache
 }
 
 func (c *dropletConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &dropletExternal{Client: client, kube: c.kube}, nil
+	client := c.clients.GetFor(a)
+	return &dropletExternal{Client: client, kube: c.kube, record: c.record, sizes: c.sizes, regions: c.regions, shutdownTimeout: shutdownTimeout}, nil
 }
 
 type dropletExternal struct {
-	kube client.Client
+	kube            client.Client
+	record          event.Recorder
+	sizes           *sizeCache
+	regions         *do.RegionCache
+	shutdownTimeout time.Duration
 	*godo.Client
 }
 
+// A sizeCache caches the list of Droplet sizes, which rarely changes, so
+// that looking up a size does not list all sizes every time. The sizes are
+// the same for all credentials. A nil sizeCache lists the sizes every time.
+type sizeCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	sizes   []godo.Size
+	expires time.Time
+}
+
+// list returns the Droplet sizes, listing them with the supplied client if
+// they are not cached or the cached ones expired.
+func (s *sizeCache) list(ctx context.Context, c *godo.Client) ([]godo.Size, error) {
+	if s == nil {
+		sizes, _, err := c.Sizes.List(ctx, &godo.ListOptions{PerPage: 200})
+		return sizes, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sizes != nil && time.Now().Before(s.expires) {
+		return s.sizes, nil
+	}
+	sizes, _, err := c.Sizes.List(ctx, &godo.ListOptions{PerPage: 200})
+	if err != nil {
+		return nil, err
+	}
+	s.sizes, s.expires = sizes, time.Now().Add(s.ttl)
+	return sizes, nil
+}
+
 func (c *dropletExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.Droplet)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDroplet)
 	}
 
-	if meta.GetExternalName(cr) == "" {
-		return managed.ExternalObservation{
-			ResourceExists: false,
-		}, nil
-	}
-
-	externalID, err := strconv.Atoi(meta.GetExternalName(cr))
-	if err != nil {
+	externalID, ok := do.ExternalNameAsInt(cr)
+	if !ok {
 		// on the first try the value of 'crossplane.io/external-name' annotation
-		// is name of the 'Droplet' resource (i.e. type string,) which will get
-		// updated to id (i.e. type int) of managed resource when it gets created.
-		externalID = 0
+		// is empty or the name of the 'Droplet' resource (i.e. type string,)
+		// which will get updated to id (i.e. type int) of managed resource when
+		// it gets created. If a previous Create succeeded but the id was never
+		// persisted we adopt the Droplet carrying our dedupe tag instead of
+		// creating a second one. An existing Droplet named like the
+		// external-name is adopted if adoption is enabled, unless it is the
+		// Droplet we destroyed to recreate it.
+		adopted, err := c.findCreated(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListDroplets)
+		}
+		if adopted != nil {
+			meta.SetExternalName(cr, strconv.Itoa(adopted.ID))
+			if err := c.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
+			}
+			externalID = adopted.ID
+		} else {
+			id, err := do.AdoptExisting(ctx, c.kube, cr, c.findByName(cr.Status.AtProvider.ID))
+			if err != nil || id == "" {
+				return managed.ExternalObservation{ResourceExists: false}, err
+			}
+			if externalID, ok = do.ExternalNameAsInt(cr); !ok {
+				return managed.ExternalObservation{}, errors.Errorf(errAdoptedID, id)
+			}
+		}
 	}
 
 	observed, response, err := c.Droplets.Get(ctx, externalID)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetDroplet)
+		if err := do.IgnoreNotFound(err, response); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetDroplet)
+		}
+		// The Droplet is destroyed, so its tags no longer carry it.
+		if meta.WasDeleted(cr) && do.BoolValue(cr.Spec.ForProvider.CleanupTagsOnDelete) {
+			return managed.ExternalObservation{}, errors.Wrap(c.cleanupTags(ctx, cr), errCleanupTags)
+		}
+		return managed.ExternalObservation{}, nil
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	docompute.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		docompute.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errDropletUpdate)
+			}
 		}
 	}
 
-	cr.Status.AtProvider = v1alpha1.DropletObservation{
-		CreationTimestamp: observed.Created,
-		ID:                observed.ID,
-		Status:            observed.Status,
+	action := cr.Status.AtProvider.Action
+	cr.Status.AtProvider = docompute.GenerateObservation(*observed)
+	cr.Status.AtProvider.Action = action
+	if err := do.ObserveAction(ctx, c.Actions, &cr.Status.AtProvider.Action); err != nil {
+		if !do.IsActionError(err) {
+			return managed.ExternalObservation{}, errors.Wrap(err, errAction)
+		}
+		c.record.Event(cr, event.Warning(reasonActionErrored, err))
+	}
+	if contains(observed.Features, docompute.FeatureBackups) {
+		backup, err := c.latestBackup(ctx, observed.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListBackups)
+		}
+		if backup != nil {
+			cr.Status.AtProvider.LatestBackupID = backup.ID
+			cr.Status.AtProvider.LatestBackupCreated = backup.Created
+		}
 	}
 
-	switch cr.Status.AtProvider.Status {
-	case v1alpha1.StatusNew:
+	// A Droplet is being created again until it runs the image it is rebuilt
+	// from.
+	rebuild := docompute.NeedsRebuild(cr.Spec.ForProvider, cr.Status.AtProvider)
+	// An active Droplet is not available until it was assigned an address
+	// of every requested IP family and the volumes and VPC it declares are
+	// attached.
+	attachments := docompute.AttachmentConditions(cr.Spec.ForProvider, cr.Status.AtProvider)
+	pending := docompute.PendingAttachments(attachments)
+	switch {
+	case rebuild:
 		cr.SetConditions(xpv1.Creating())
-	case v1alpha1.StatusActive:
-		cr.SetConditions(xpv1.Available())
+	case cr.Status.AtProvider.Status == v1alpha1.StatusActive && !docompute.AddressesAssigned(cr.Spec.ForProvider, cr.Status.AtProvider):
+		cr.SetConditions(xpv1.Creating().WithMessage(msgAddressesPending))
+	case cr.Status.AtProvider.Status == v1alpha1.StatusActive && len(pending) > 0:
+		cr.SetConditions(xpv1.Creating().WithMessage(msgAttachmentsPending + strings.Join(pending, ", ")))
+	default:
+		dropletConditions.SetCondition(cr, cr.Status.AtProvider.Status)
 	}
+	cr.SetConditions(attachments...)
 
-	// Droplets are always "up to date" because they can't be updated. ¯\_(ツ)_/¯
+	immutable := do.ImmutableFieldCondition(docompute.ImmutableFields(cr.Spec.ForProvider, *observed))
+	if immutable.Status == corev1.ConditionTrue && !immutable.Equal(cr.GetCondition(do.TypeImmutableFieldChanged)) {
+		c.record.Event(cr, event.Warning(reasonImmutableChanged, errors.New(immutable.Message)))
+	}
+	cr.SetConditions(immutable)
+
+	// The size, backups, IPv6, reverse DNS and the image it is rebuilt from
+	// are the only fields of a Droplet that can be updated. A Droplet that
+	// was powered off to be resized is not up to date until it has been
+	// powered on again, and one whose immutable fields changed is not up to
+	// date if it should be recreated. An active Droplet is not up to date
+	// while a volume it declares is not attached, e.g. because attaching it
+	// failed after the Droplet was created, so Update completes the Droplet
+	// rather than it being created again. A Droplet whose project changed is
+	// moved to it.
+	upToDate := cr.Spec.ForProvider.Size == observed.SizeSlug && !poweredOffForResize(cr) && !rebuild &&
+		len(docompute.FeaturesToUpdate(cr.Spec.ForProvider, observed.Features)) == 0 &&
+		!reverseDNSChanged(cr) && !recreateRequired(cr) && len(volumesToAttach(cr)) == 0 &&
+		do.ProjectUpToDate(cr, cr.Spec.ForProvider.ProjectID)
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: connectionDetails(cr.Spec.ForProvider.ConnectionDetailsNetwork, *observed, cr.Status.AtProvider),
 	}, nil
 }
 
+// latestBackup returns the most recent backup of the Droplet with the supplied
+// ID, or nil if it has none.
+func (c *dropletExternal) latestBackup(ctx context.Context, id int) (*godo.Image, error) {
+	var backups []godo.Image
+	opts := &godo.ListOptions{PerPage: 200}
+	for {
+		page, resp, err := c.Droplets.Backups(ctx, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		current, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = current + 1
+	}
+	return docompute.LatestBackup(backups), nil
+}
+
+// connectionDetails returns the addresses of the supplied Droplet on the
+// supplied network, and the public addresses of both IP families with their
+// gateways and CIDRs. Addresses that are not assigned yet are omitted.
+func connectionDetails(network string, observed godo.Droplet, o v1alpha1.DropletObservation) managed.ConnectionDetails {
+	public, _ := observed.PublicIPv4()
+	private, _ := observed.PrivateIPv4()
+
+	cd := managed.ConnectionDetails{}
+	host := public
+	switch network {
+	case v1alpha1.ConnectionDetailsNetworkPrivate:
+		host = private
+	case v1alpha1.ConnectionDetailsNetworkBoth:
+		if private != "" {
+			cd["private_host"] = []byte(private)
+		}
+	}
+	if host != "" {
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(host)
+		cd["host"] = []byte(host)
+	}
+	for k, v := range map[string]string{
+		"public_ipv4":         o.PublicIPv4,
+		"public_ipv4_gateway": o.PublicIPv4Gateway,
+		"public_ipv4_cidr":    o.PublicIPv4CIDR,
+		"public_ipv6":         o.PublicIPv6,
+		"public_ipv6_gateway": o.PublicIPv6Gateway,
+		"public_ipv6_cidr":    o.PublicIPv6CIDR,
+	} {
+		if v != "" {
+			cd[k] = []byte(v)
+		}
+	}
+	if len(cd) == 0 {
+		return nil
+	}
+	return cd
+}
+
+// findByName returns a FindByName that returns the IDs of the Droplets with
+// the supplied name, except the Droplet with the supplied ID. A Droplet that
+// is destroyed to be recreated is still listed until it is gone.
+func (c *dropletExternal) findByName(except int) do.FindByName {
+	return func(ctx context.Context, name string) ([]string, error) {
+		droplets, _, err := docompute.ListDropletsByName(ctx, c.Client, name)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(droplets))
+		for _, d := range droplets {
+			if d.ID != except {
+				ids = append(ids, strconv.Itoa(d.ID))
+			}
+		}
+		return ids, nil
+	}
+}
+
+// findCreated returns the Droplet that was created for the supplied managed
+// resource, or nil if there is none.
+func (c *dropletExternal) findCreated(ctx context.Context, cr *v1alpha1.Droplet) (*godo.Droplet, error) {
+	if cr.GetUID() == "" {
+		return nil, nil
+	}
+	droplets, _, err := c.Droplets.ListByTag(ctx, docompute.DedupeTag(string(cr.GetUID())), nil)
+	if err != nil || len(droplets) == 0 {
+		return nil, err
+	}
+	return &droplets[0], nil
+}
+
 func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Droplet)
 	if !ok {
@@ -150,26 +426,362 @@ func (c *dropletExternal) Create(ctx context.Context, mg resource.Managed) (mana
 		name = cr.GetName()
 	}
 
+	if err := validateCreate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// A previous Create may have succeeded without its external-name being
+	// persisted, e.g. because a concurrent reconcile of a stale copy of the
+	// managed resource raced with it. Adopt that Droplet rather than
+	// creating a duplicate.
+	adopted, err := c.findCreated(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errListDroplets)
+	}
+	if adopted != nil {
+		return managed.ExternalCreation{ExternalNameAssigned: setExternalName(cr, adopted.ID)}, nil
+	}
+
+	droplet, err := c.create(ctx, cr, name)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	assigned := setExternalName(cr, droplet.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: assigned}, do.AssignProject(ctx, c.Client, cr, cr.Spec.ForProvider.ProjectID, droplet.URN())
+}
+
+// validateCreate returns an error if a Droplet cannot be created from the
+// supplied parameters.
+func validateCreate(p v1alpha1.DropletParameters) error {
+	if err := docompute.ValidateRequired(p); err != nil {
+		return err
+	}
+	if err := docompute.ValidateReverseDNS(p); err != nil {
+		return err
+	}
+	return docompute.ValidatePrivateNetworking(p)
+}
+
+// create creates a Droplet with the supplied name for the supplied managed
+// resource, tagged with its dedupe tag.
+func (c *dropletExternal) create(ctx context.Context, cr *v1alpha1.Droplet, name string) (*godo.Droplet, error) {
+	userData, err := docompute.UserData(cr.Spec.ForProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	create := &godo.DropletCreateRequest{}
 	docompute.GenerateDroplet(name, cr.Spec.ForProvider, create)
+	create.UserData = userData
+	if cr.GetUID() != "" {
+		create.Tags = append(append([]string{}, create.Tags...), docompute.DedupeTag(string(cr.GetUID())))
+	}
 
-	droplet, _, err := c.Droplets.Create(ctx, create)
+	if do.BoolValue(cr.Spec.ForProvider.ValidateSize) {
+		if err := c.validateSize(ctx, cr.Spec.ForProvider); err != nil {
+			return nil, err
+		}
+	}
+	if do.BoolValue(cr.Spec.ForProvider.ValidateRegion) {
+		for _, r := range docompute.Regions(cr.Spec.ForProvider) {
+			if err := c.regions.ValidateRegion(ctx, c.Client, r, docompute.RegionFeatures(cr.Spec.ForProvider)...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(cr.Spec.ForProvider.RegionFallback) > 0 {
+		if err := c.validateFallbackRegions(ctx, cr.Spec.ForProvider); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.validateVPCRegion(ctx, cr.Spec.ForProvider); err != nil {
+		return nil, err
+	}
+
+	// Try the preferred region first and fall back to the next region only
+	// if the previous one is out of capacity.
+	var droplet *godo.Droplet
+	for _, region := range docompute.Regions(cr.Spec.ForProvider) {
+		create.Region = region
+		droplet, _, err = c.Droplets.Create(ctx, create)
+		if !do.IsCapacityError(err) {
+			break
+		}
+	}
 	if err != nil || droplet == nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errDropletCreateFailed)
+		return nil, errors.Wrap(err, errDropletCreateFailed)
 	}
+	return droplet, nil
+}
 
-	if meta.GetExternalName(cr) == "" {
-		meta.SetExternalName(cr, strconv.Itoa(droplet.ID))
+// setExternalName sets the external-name of the supplied managed resource to
+// the supplied Droplet ID, and returns true if it changed. Before a Droplet is
+// created its external-name is empty or the name of the Droplet to create,
+// e.g. after it was reset to recreate the Droplet.
+func setExternalName(cr *v1alpha1.Droplet, id int) bool {
+	name := strconv.Itoa(id)
+	if meta.GetExternalName(cr) == name {
+		return false
 	}
+	meta.SetExternalName(cr, name)
+	return true
+}
 
-	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+// validateVPCRegion returns an error if the Droplet is placed in a VPC of a
+// different region than any of the regions it may be created in. The API
+// rejects cross-region VPC placement with an unhelpful error.
+func (c *dropletExternal) validateVPCRegion(ctx context.Context, p v1alpha1.DropletParameters) error {
+	if p.VPCUUID == nil || *p.VPCUUID == "" {
+		return nil
+	}
+	vpc, _, err := c.VPCs.Get(ctx, *p.VPCUUID)
+	if err != nil {
+		return errors.Wrap(err, errGetVPC)
+	}
+	for _, r := range docompute.Regions(p) {
+		if r != vpc.RegionSlug {
+			return errors.Errorf(errVPCRegionMismatch, *p.VPCUUID, vpc.RegionSlug, r)
+		}
+	}
+	return nil
+}
+
+// validateFallbackRegions returns an error if the requested size is not
+// available in any of the fallback regions.
+func (c *dropletExternal) validateFallbackRegions(ctx context.Context, p v1alpha1.DropletParameters) error {
+	size, err := c.getSize(ctx, p.Size)
+	if err != nil {
+		return err
+	}
+	var regions []string
+	if size != nil {
+		regions = size.Regions
+	}
+	for _, r := range p.RegionFallback {
+		if !contains(regions, r) {
+			return errors.Errorf(errSizeNotInRegion, p.Size, r)
+		}
+	}
+	return nil
+}
+
+// getSize returns the Droplet size with the supplied slug, or nil if there is
+// no such size.
+func (c *dropletExternal) getSize(ctx context.Context, slug string) (*godo.Size, error) {
+	sizes, err := c.sizes.list(ctx, c.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, errListSizes)
+	}
+	for i := range sizes {
+		if sizes[i].Slug == slug {
+			return &sizes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// validateSize returns an error listing the closest known sizes if the size
+// of the supplied parameters is unknown.
+func (c *dropletExternal) validateSize(ctx context.Context, p v1alpha1.DropletParameters) error {
+	sizes, err := c.sizes.list(ctx, c.Client)
+	if err != nil {
+		return errors.Wrap(err, errListSizes)
+	}
+	slugs := make([]string, len(sizes))
+	for i := range sizes {
+		if sizes[i].Slug == p.Size {
+			return nil
+		}
+		slugs[i] = sizes[i].Slug
+	}
+	closest := docompute.ClosestSizes(p.Size, slugs, closestSizes)
+	if len(closest) == 0 {
+		return errors.Errorf(errUnknownSize, p.Size)
+	}
+	return errors.Errorf(errUnknownSizeClosest, p.Size, strings.Join(closest, ", "))
+}
+
+// volumesToAttach returns the volumes the supplied Droplet declares but that
+// are not attached to it, if it is active. These are attached one per
+// reconcile.
+func volumesToAttach(cr *v1alpha1.Droplet) []string {
+	if cr.Status.AtProvider.Status != v1alpha1.StatusActive {
+		return nil
+	}
+	return docompute.MissingVolumes(cr.Spec.ForProvider, cr.Status.AtProvider)
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *dropletExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
+	cr, ok := mg.(*v1alpha1.Droplet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDroplet)
+	}
+
+	// A Droplet that is recreated is replaced as a whole, so it is not
+	// updated otherwise. It is moved to the project it declares before any
+	// other update. A rebuild replaces the disk of the Droplet, so it
+	// comes before any other update. The reverse DNS and features are updated one action per
+	// reconcile, unless a resize is in progress, and so are volumes that are
+	// declared but not attached.
+	id := cr.Status.AtProvider.ID
+	if recreateRequired(cr) {
+		return managed.ExternalUpdate{}, c.recreate(ctx, cr)
+	}
+	if err := do.UpdateProject(ctx, c.Client, c.kube, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("Droplet", id)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if docompute.NeedsRebuild(cr.Spec.ForProvider, cr.Status.AtProvider) && !poweredOffForResize(cr) {
+		return managed.ExternalUpdate{}, c.rebuild(ctx, cr)
+	}
+	if reverseDNSChanged(cr) && !poweredOffForResize(cr) {
+		if err := docompute.ValidateReverseDNS(cr.Spec.ForProvider); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		action, _, err := c.DropletActions.Rename(ctx, id, *cr.Spec.ForProvider.ReverseDNS)
+		do.TrackAction(&cr.Status.AtProvider.Action, action)
+		return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errRename)
+	}
+	if update := docompute.FeaturesToUpdate(cr.Spec.ForProvider, cr.Status.AtProvider.Features); len(update) > 0 && !poweredOffForResize(cr) {
+		return managed.ExternalUpdate{}, errors.Wrapf(do.IgnoreLocked(c.updateFeature(ctx, cr, update[0])), errUpdateFeature, update[0])
+	}
+	if attach := volumesToAttach(cr); len(attach) > 0 && !poweredOffForResize(cr) {
+		action, _, err := c.StorageActions.Attach(ctx, attach[0], id)
+		do.TrackAction(&cr.Status.AtProvider.Action, action)
+		return managed.ExternalUpdate{}, errors.Wrapf(do.IgnoreLocked(err), errAttachVolume, attach[0])
+	}
+
+	// A Droplet must be powered off to be resized. Each step is an
+	// asynchronous action, so we take one step per reconcile and observe the
+	// Droplet's status in between: power off, resize, then power on again.
+	// Actions on a Droplet that is still locked by the previous one are
+	// retried on the next reconcile.
+	if cr.Spec.ForProvider.Size == cr.Status.AtProvider.Size {
+		if !poweredOffForResize(cr) || cr.Status.AtProvider.Status != v1alpha1.StatusOff {
+			return managed.ExternalUpdate{}, nil
+		}
+		action, _, err := c.DropletActions.PowerOn(ctx, id)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errPowerOn)
+		}
+		meta.RemoveAnnotations(cr, docompute.AnnotationPoweredOffForResize)
+		return managed.ExternalUpdate{}, errors.Wrap(c.updateTracking(ctx, cr, action), errDropletUpdate)
+	}
+
+	resizeDisk := do.BoolValue(cr.Spec.ForProvider.ResizeDisk)
+	if resizeDisk {
+		size, err := c.getSize(ctx, cr.Spec.ForProvider.Size)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if size == nil {
+			return managed.ExternalUpdate{}, errors.Errorf(errUnknownSize, cr.Spec.ForProvider.Size)
+		}
+		if size.Disk < cr.Status.AtProvider.Disk {
+			return managed.ExternalUpdate{}, errors.Errorf(errDiskShrink, size.Slug, size.Disk, cr.Status.AtProvider.Disk)
+		}
+	}
+
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.StatusActive:
+		action, _, err := c.DropletActions.PowerOff(ctx, id)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errPowerOff)
+		}
+		meta.AddAnnotations(cr, map[string]string{docompute.AnnotationPoweredOffForResize: "true"})
+		return managed.ExternalUpdate{}, errors.Wrap(c.updateTracking(ctx, cr, action), errDropletUpdate)
+	case v1alpha1.StatusOff:
+		if resizeDisk {
+			c.record.Event(cr, event.Warning(reasonResizeDisk, errors.Errorf("resizing the disk of Droplet to size %q is irreversible", cr.Spec.ForProvider.Size)))
+		}
+		action, _, err := c.DropletActions.Resize(ctx, id, cr.Spec.ForProvider.Size, resizeDisk)
+		do.TrackAction(&cr.Status.AtProvider.Action, action)
+		return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errResize)
+	}
+
+	// The Droplet is still being created or powered off.
 	return managed.ExternalUpdate{}, nil
 }
 
+// rebuild rebuilds the supplied Droplet from the image it should be rebuilt
+// from, and waits for the rebuild to complete.
+func (c *dropletExternal) rebuild(ctx context.Context, cr *v1alpha1.Droplet) error {
+	image, err := c.getImage(ctx, *cr.Spec.ForProvider.RebuildFrom)
+	if err != nil {
+		return errors.Wrap(err, errGetImage)
+	}
+	if err := docompute.ValidateRebuildImage(*image, cr.Status.AtProvider); err != nil {
+		return err
+	}
+	action, _, err := c.DropletActions.RebuildByImageID(ctx, cr.Status.AtProvider.ID, image.ID)
+	if err != nil {
+		return errors.Wrap(do.IgnoreLocked(err), errRebuild)
+	}
+	cr.SetConditions(xpv1.Creating())
+	do.TrackAction(&cr.Status.AtProvider.Action, action)
+	return errors.Wrap(do.WaitForTrackedAction(ctx, c.Actions, &cr.Status.AtProvider.Action), errRebuild)
+}
+
+// updateTracking updates the supplied Droplet and records the supplied action
+// in its status afterwards, since the update overwrites the status with the
+// one that is stored.
+func (c *dropletExternal) updateTracking(ctx context.Context, cr *v1alpha1.Droplet, action *godo.Action) error {
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return err
+	}
+	do.TrackAction(&cr.Status.AtProvider.Action, action)
+	return nil
+}
+
+// getImage gets the image with the supplied ID or slug.
+func (c *dropletExternal) getImage(ctx context.Context, image string) (*godo.Image, error) {
+	var i *godo.Image
+	var err error
+	if id, convErr := strconv.Atoi(image); convErr == nil {
+		i, _, err = c.Images.GetByID(ctx, id)
+	} else {
+		i, _, err = c.Images.GetBySlug(ctx, image)
+	}
+	return i, err
+}
+
+// updateFeature enables or disables the supplied feature of the supplied
+// Droplet to match its spec.
+func (c *dropletExternal) updateFeature(ctx context.Context, cr *v1alpha1.Droplet, feature string) error {
+	var action *godo.Action
+	var err error
+	id, p := cr.Status.AtProvider.ID, cr.Spec.ForProvider
+	switch {
+	case feature == docompute.FeatureBackups && do.BoolValue(p.Backups):
+		action, _, err = c.DropletActions.EnableBackups(ctx, id)
+	case feature == docompute.FeatureBackups:
+		action, _, err = c.DropletActions.DisableBackups(ctx, id)
+	case feature == docompute.FeatureIPv6:
+		action, _, err = c.DropletActions.EnableIPv6(ctx, id)
+	}
+	do.TrackAction(&cr.Status.AtProvider.Action, action)
+	return err
+}
+
+// reverseDNSChanged returns true if the desired reverse DNS name of the
+// supplied Droplet differs from the observed one.
+func reverseDNSChanged(cr *v1alpha1.Droplet) bool {
+	rdns := cr.Spec.ForProvider.ReverseDNS
+	return rdns != nil && strings.TrimSuffix(*rdns, ".") != strings.TrimSuffix(cr.Status.AtProvider.ReverseDNS, ".")
+}
+
+func poweredOffForResize(cr *v1alpha1.Droplet) bool {
+	return cr.GetAnnotations()[docompute.AnnotationPoweredOffForResize] == "true"
+}
+
 func (c *dropletExternal) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.Droplet)
 	if !ok {
@@ -178,6 +790,165 @@ func (c *dropletExternal) Delete(ctx context.Context, mg resource.Managed) error
 
 	cr.Status.SetConditions(xpv1.Deleting())
 
-	response, err := c.Droplets.Delete(ctx, cr.Status.AtProvider.ID)
-	return errors.Wrap(do.IgnoreNotFound(err, response), errDropletDeleteFailed)
+	// A Droplet that is locked by an action in progress (e.g. a resize) cannot
+	// be deleted yet. No further step of a resize is taken once the managed
+	// resource is deleted, so we wait for the action to complete rather than
+	// racing it, and retry the deletion once the Droplet is observed unlocked.
+	if cr.Status.AtProvider.Locked {
+		cr.Status.SetConditions(xpv1.Deleting().WithMessage(msgDeleteLocked))
+		return nil
+	}
+
+	return c.destroy(ctx, cr)
+}
+
+// destroy destroys the observed Droplet of the supplied managed resource,
+// shutting it down first if it should be shut down gracefully.
+func (c *dropletExternal) destroy(ctx context.Context, cr *v1alpha1.Droplet) error {
+	if do.BoolValue(cr.Spec.ForProvider.GracefulShutdown) && cr.Status.AtProvider.Status == v1alpha1.StatusActive {
+		if err := c.shutdown(ctx, cr.Status.AtProvider.ID); err != nil {
+			return errors.Wrap(do.IgnoreNotFoundErr(ignoreLocked(cr, err)), errShutdown)
+		}
+	}
+
+	_, err := c.Droplets.Delete(ctx, cr.Status.AtProvider.ID)
+	return errors.Wrap(do.IgnoreNotFoundErr(ignoreLocked(cr, err)), errDropletDeleteFailed)
+}
+
+// recreateRequired returns true if the supplied Droplet should be recreated
+// because an immutable field changed.
+func recreateRequired(cr *v1alpha1.Droplet) bool {
+	return do.BoolValue(cr.Spec.ForProvider.RecreateOnImmutableChange) &&
+		cr.GetCondition(do.TypeImmutableFieldChanged).Status == corev1.ConditionTrue
+}
+
+// recreate replaces the observed Droplet of the supplied managed resource by a
+// new Droplet created from its desired parameters. By default the observed
+// Droplet is destroyed and the external-name reset, so that the next reconcile
+// creates the new Droplet. With the CreateBeforeDestroy strategy the new
+// Droplet is created before the observed one is destroyed.
+func (c *dropletExternal) recreate(ctx context.Context, cr *v1alpha1.Droplet) error {
+	if err := validateCreate(cr.Spec.ForProvider); err != nil {
+		return err
+	}
+	// A Droplet that is locked by an action in progress cannot be destroyed
+	// yet, so we wait for the action to complete.
+	if cr.Status.AtProvider.Locked {
+		return nil
+	}
+
+	// The old Droplet must not be adopted as the Droplet created for the
+	// managed resource anymore, so its dedupe tag is removed first.
+	if err := c.untagDedupe(ctx, cr); err != nil {
+		return errors.Wrap(err, errUntagDroplet)
+	}
+	c.record.Event(cr, event.Normal(reasonRecreate, "Recreating Droplet to apply changes of immutable fields"))
+
+	if cr.Spec.ForProvider.RecreateStrategy == v1alpha1.RecreateStrategyCreateBeforeDestroy {
+		return c.replace(ctx, cr)
+	}
+
+	if err := c.destroy(ctx, cr); err != nil {
+		return err
+	}
+	// The new Droplet is assigned to the project when it is created.
+	meta.SetExternalName(cr, cr.GetName())
+	meta.RemoveAnnotations(cr, do.AnnotationProject)
+	return errors.Wrap(c.kube.Update(ctx, cr), errDropletUpdate)
+}
+
+// replace creates the Droplet replacing the observed Droplet of the supplied
+// managed resource, records its ID and then destroys the observed Droplet.
+// Only the replacement carries the dedupe tag, so a replacement that was
+// created by a previous attempt whose destroy failed is found again rather
+// than created twice.
+func (c *dropletExternal) replace(ctx context.Context, cr *v1alpha1.Droplet) error {
+	replacement, err := c.findCreated(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, errListDroplets)
+	}
+	if replacement == nil {
+		if replacement, err = c.create(ctx, cr, cr.GetName()); err != nil {
+			return err
+		}
+	}
+	meta.RemoveAnnotations(cr, do.AnnotationProject)
+	if err := do.AssignProject(ctx, c.Client, cr, cr.Spec.ForProvider.ProjectID, replacement.URN()); err != nil {
+		return err
+	}
+	setExternalName(cr, replacement.ID)
+
+	// The external-name is only persisted once the observed Droplet is gone.
+	// Otherwise a failed destroy would leave it running without any managed
+	// resource referring to it.
+	if err := c.destroy(ctx, cr); err != nil {
+		return err
+	}
+	return errors.Wrap(c.kube.Update(ctx, cr), errDropletUpdate)
+}
+
+// untagDedupe removes the dedupe tag of the supplied managed resource from
+// its observed Droplet.
+func (c *dropletExternal) untagDedupe(ctx context.Context, cr *v1alpha1.Droplet) error {
+	if cr.GetUID() == "" {
+		return nil
+	}
+	response, err := c.Tags.UntagResources(ctx, docompute.DedupeTag(string(cr.GetUID())), &godo.UntagResourcesRequest{
+		Resources: []godo.Resource{{ID: strconv.Itoa(cr.Status.AtProvider.ID), Type: godo.DropletResourceType}},
+	})
+	return do.IgnoreNotFound(err, response)
+}
+
+// cleanupTags deletes the tags of the supplied Droplet, including its dedupe
+// tag, that no resource carries anymore.
+func (c *dropletExternal) cleanupTags(ctx context.Context, cr *v1alpha1.Droplet) error {
+	tags := cr.Spec.ForProvider.Tags
+	if cr.GetUID() != "" {
+		tags = append(append([]string{}, tags...), docompute.DedupeTag(string(cr.GetUID())))
+	}
+	for _, name := range tags {
+		tag, response, err := c.Tags.Get(ctx, name)
+		if err != nil {
+			if err := do.IgnoreNotFound(err, response); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag.Resources != nil && tag.Resources.Count > 0 {
+			continue
+		}
+		if response, err := c.Tags.Delete(ctx, name); err != nil {
+			if err := do.IgnoreNotFound(err, response); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ignoreLocked ignores the supplied error if the supplied Droplet is locked by
+// an action in progress, and reports that its deletion is waiting for it.
+func ignoreLocked(cr *v1alpha1.Droplet, err error) error {
+	if !do.IsLocked(err) {
+		return err
+	}
+	cr.Status.SetConditions(xpv1.Deleting().WithMessage(msgDeleteLocked))
+	return nil
+}
+
+// shutdown gracefully shuts down the Droplet with the supplied ID and waits
+// for it to be off. A Droplet that does not shut down in time, or whose
+// shutdown errored, is left to be destroyed.
+func (c *dropletExternal) shutdown(ctx context.Context, id int) error {
+	action, _, err := c.DropletActions.Shutdown(ctx, id)
+	if err != nil {
+		return err
+	}
+	wctx, cancel := context.WithTimeout(ctx, c.shutdownTimeout)
+	defer cancel()
+	_, err = do.WaitForAction(wctx, c.Actions, action.ID)
+	if do.IsActionError(err) || (errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil) {
+		return nil
+	}
+	return err
 }
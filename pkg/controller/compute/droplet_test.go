@@ -16,4 +16,1934 @@ limitations under the License.
 
 package compute
 
-// TODO(khos2ow): Stop procrastinating!!
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	docompute "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+)
+
+const (
+	testDropletID = 1234
+	testUID       = "2d7d4a1c-6ad9-4d4a-9d2e-1c2b3a4d5e6f"
+)
+
+type dropletModifier func(*v1alpha1.Droplet)
+
+func withDropletID(id int) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Status.AtProvider.ID = id }
+}
+
+func withExternalName(n string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { meta.SetExternalName(cr, n) }
+}
+
+// withRequiredFields sets the fields a Droplet cannot be created without.
+func withRequiredFields() dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		cr.Spec.ForProvider.Region = "nyc3"
+		cr.Spec.ForProvider.Size = "s-1vcpu-1gb"
+		cr.Spec.ForProvider.Image = "ubuntu-20-04-x64"
+	}
+}
+
+func droplet(m ...dropletModifier) *v1alpha1.Droplet {
+	cr := &v1alpha1.Droplet{}
+	cr.SetName("example")
+	cr.SetUID(types.UID(testUID))
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// untagged responds that no Droplet carries the dedupe tag.
+var untagged = func(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(`{"droplets":[]}`))
+}
+
+func observedDroplet() godo.Droplet {
+	return godo.Droplet{ID: testDropletID, Name: "example", Status: v1alpha1.StatusActive}
+}
+
+func TestDropletConnectTokenRotation(t *testing.T) {
+	token := "old"
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *dov1alpha1.ProviderConfig:
+				o.SetName(key.Name)
+				o.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "do-creds", Namespace: "crossplane-system"},
+					Key:             "token",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"token": []byte(token)}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+	c := &dropletConnector{kube: kube, clients: do.NewClientCache(do.ClientOptions{})}
+	cr := droplet()
+	cr.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+
+	connect := func() *godo.Client {
+		t.Helper()
+		e, err := c.Connect(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("c.Connect(...): %v", err)
+		}
+		return e.(*dropletExternal).Client
+	}
+
+	before := connect()
+	if connect() != before {
+		t.Error("c.Connect(...): want the cached client to be reused while the token is unchanged")
+	}
+	token = "new"
+	if connect() == before {
+		t.Error("c.Connect(...): want a new client once the token in the Secret was rotated")
+	}
+}
+
+func TestDropletObserveAdoptsCreated(t *testing.T) {
+	// The Droplet was created by a previous reconcile that crashed before it
+	// could persist the Droplet's id as the external-name.
+	tagged := observedDroplet()
+	tagged.Tags = []string{"crossplane:" + testUID}
+
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("tag_name"); got != "crossplane:"+testUID {
+				t.Errorf("ListByTag(...): want dedupe tag, got %q", got)
+			}
+			fake.Respond(t, map[string]interface{}{"droplets": []godo.Droplet{tagged}})(w, r)
+		},
+		"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": tagged}),
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withExternalName("example"))
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+	}
+	if got := meta.GetExternalName(cr); got != "1234" {
+		t.Errorf("e.Observe(...): want adopted external-name 1234, got %q", got)
+	}
+	if len(cr.Spec.ForProvider.Tags) != 0 {
+		t.Errorf("e.Observe(...): dedupe tag must not be late initialized, got %v", cr.Spec.ForProvider.Tags)
+	}
+}
+
+func TestDropletObserveNotCreated(t *testing.T) {
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets": fake.Respond(t, map[string]interface{}{"droplets": []godo.Droplet{}}),
+	})
+	e := &dropletExternal{Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), droplet())
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceExists {
+		t.Error("e.Observe(...): want ResourceExists false when no Droplet carries the dedupe tag")
+	}
+}
+
+func TestDropletObserveAdoptExisting(t *testing.T) {
+	existing := observedDroplet()
+	existing.ID = 5678
+	existing.Name = "web-1"
+	existing.VPCUUID = "5a4981aa-9653-4bd1-bef5-d6bff52042e4"
+
+	type want struct {
+		exists       bool
+		externalName string
+		vpc          string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		adopt     bool
+		existing  []godo.Droplet
+		destroyed int
+		want      want
+	}{
+		"Adopt": {
+			reason:   "An existing Droplet named like the external-name should be adopted and late initialized if adoption is enabled.",
+			adopt:    true,
+			existing: []godo.Droplet{existing},
+			want:     want{exists: true, externalName: "5678", vpc: "5a4981aa-9653-4bd1-bef5-d6bff52042e4"},
+		},
+		"Recreated": {
+			reason:    "The Droplet that was destroyed to be recreated should not be adopted again while it is still listed.",
+			adopt:     true,
+			existing:  []godo.Droplet{existing},
+			destroyed: existing.ID,
+			want:      want{externalName: "web-1"},
+		},
+		"NoAdopt": {
+			reason:   "An existing Droplet should not be adopted unless adoption is enabled.",
+			existing: []godo.Droplet{existing},
+			want:     want{externalName: "web-1"},
+		},
+		"NotFound": {
+			reason: "A Droplet should be created if no existing Droplet is named like the external-name.",
+			adopt:  true,
+			want:   want{externalName: "web-1"},
+		},
+		"Ambiguous": {
+			reason:   "An existing Droplet should not be adopted if several Droplets are named like the external-name.",
+			adopt:    true,
+			existing: []godo.Droplet{existing, existing},
+			want: want{
+				externalName: "web-1",
+				err:          errors.Errorf("cannot adopt existing resource: %d resources are named %q", 2, "web-1"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("tag_name") != "" {
+						untagged(w, r)
+						return
+					}
+					if !tc.adopt {
+						t.Error("ListDropletsByName(...): want no Droplets to be listed by name unless adoption is enabled")
+					}
+					if got := r.URL.Query().Get("name"); got != "web-1" {
+						t.Errorf("ListDropletsByName(...): want name web-1, got %q", got)
+					}
+					fake.Respond(t, map[string]interface{}{"droplets": tc.existing})(w, r)
+				},
+				"GET /v2/droplets/5678": fake.Respond(t, map[string]interface{}{"droplet": existing}),
+			})
+			e := &dropletExternal{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+					MockPatch:  test.NewMockPatchFn(nil),
+				},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			cr := droplet(withExternalName("web-1"), withDropletID(tc.destroyed))
+			if tc.adopt {
+				meta.AddAnnotations(cr, map[string]string{do.AnnotationAdoptExisting: "true"})
+			}
+
+			o, err := e.Observe(context.Background(), cr)
+			got := want{exists: o.ResourceExists, externalName: meta.GetExternalName(cr), vpc: do.StringValue(cr.Spec.ForProvider.VPCUUID), err: err}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletCreateDedupeTag(t *testing.T) {
+	var got struct {
+		Tags []string `json:"tags"`
+	}
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets": untagged,
+		"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+		},
+	})
+	e := &dropletExternal{Client: fake.NewClient(t, h)}
+	cr := droplet(withRequiredFields())
+	cr.Spec.ForProvider.Tags = []string{"web"}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"web", "crossplane:" + testUID}, got.Tags); diff != "" {
+		t.Errorf("e.Create(...): -want tags, +got tags:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"web"}, cr.Spec.ForProvider.Tags); diff != "" {
+		t.Errorf("e.Create(...): spec tags must not be modified: -want, +got:\n%s", diff)
+	}
+}
+
+func TestDropletCreateValidateSize(t *testing.T) {
+	sizes := []godo.Size{{Slug: "s-1vcpu-1gb"}, {Slug: "s-1vcpu-2gb"}, {Slug: "s-2vcpu-2gb"}, {Slug: "c-2"}, {Slug: "m-2vcpu-16gb"}}
+
+	type want struct {
+		err     error
+		created int
+	}
+
+	cases := map[string]struct {
+		reason string
+		size   string
+		want   want
+	}{
+		"KnownSize": {
+			reason: "A Droplet of a known size should be created.",
+			size:   "s-1vcpu-2gb",
+			want:   want{created: 2},
+		},
+		"UnknownSize": {
+			reason: "A Droplet of an unknown size should not be created, and the closest known sizes should be reported.",
+			size:   "s-1vcpu-3gb",
+			want: want{
+				err: errors.Errorf("unknown Droplet size %q, closest known sizes are %s", "s-1vcpu-3gb", "s-1vcpu-1gb, s-1vcpu-2gb, s-2vcpu-2gb"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			listed, created := 0, 0
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": untagged,
+				"GET /v2/sizes": func(w http.ResponseWriter, r *http.Request) {
+					listed++
+					fake.Respond(t, map[string]interface{}{"sizes": sizes})(w, r)
+				},
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					created++
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+				},
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h), sizes: &sizeCache{ttl: time.Hour}}
+			validate := true
+
+			// The sizes are listed once and cached for the second Droplet.
+			var err error
+			for i := 0; i < 2 && err == nil; i++ {
+				cr := droplet(withRequiredFields(), withSize(tc.size))
+				cr.Spec.ForProvider.ValidateSize = &validate
+				_, err = e.Create(context.Background(), cr)
+			}
+			if diff := cmp.Diff(tc.want, want{err: err, created: created}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if listed != 1 {
+				t.Errorf("\n%s\ne.Create(...): want sizes to be listed once, listed %d times", tc.reason, listed)
+			}
+		})
+	}
+}
+
+func TestDropletCreateValidateRegion(t *testing.T) {
+	regions := []godo.Region{
+		{Slug: "nyc3", Available: true, Features: []string{do.RegionFeatureIPv6, do.RegionFeatureStorage}},
+		{Slug: "sfo1", Available: true, Features: []string{do.RegionFeatureIPv6}},
+	}
+
+	type want struct {
+		err     error
+		created int
+	}
+
+	cases := map[string]struct {
+		reason   string
+		fallback []string
+		want     want
+	}{
+		"Supported": {
+			reason: "A Droplet with volumes should be created in a region with block storage.",
+			want:   want{created: 2},
+		},
+		"FallbackWithoutStorage": {
+			reason:   "A Droplet with volumes should not be created if a fallback region lacks block storage.",
+			fallback: []string{"sfo1"},
+			want:     want{err: errors.Errorf("region %q does not support %s", "sfo1", do.RegionFeatureStorage)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			listed, created := 0, 0
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": untagged,
+				"GET /v2/regions": func(w http.ResponseWriter, r *http.Request) {
+					listed++
+					fake.Respond(t, map[string]interface{}{"regions": regions})(w, r)
+				},
+				"GET /v2/sizes": fake.Respond(t, map[string]interface{}{"sizes": []godo.Size{{Slug: "s-1vcpu-1gb", Regions: []string{"nyc3", "sfo1"}}}}),
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					created++
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+				},
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h), regions: do.NewRegionCache(time.Hour)}
+			validate := true
+
+			// The regions are listed once and cached for the second Droplet.
+			var err error
+			for i := 0; i < 2 && err == nil; i++ {
+				cr := droplet(withRequiredFields())
+				cr.Spec.ForProvider.ValidateRegion = &validate
+				cr.Spec.ForProvider.RegionFallback = tc.fallback
+				cr.Spec.ForProvider.Volumes = []string{"506f78a4-e098-11e5-ad9f-000f53306ae1"}
+				_, err = e.Create(context.Background(), cr)
+			}
+			if diff := cmp.Diff(tc.want, want{err: err, created: created}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if listed != 1 {
+				t.Errorf("\n%s\ne.Create(...): want regions to be listed once, listed %d times", tc.reason, listed)
+			}
+		})
+	}
+}
+
+func TestDropletCreateMissingFields(t *testing.T) {
+	e := &dropletExternal{Client: fake.NewClient(t, fake.Routes(t, nil))}
+	cr := droplet(withRequiredFields())
+	cr.Spec.ForProvider.Image = ""
+
+	// The Droplet must be rejected before any request is sent to the API.
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(errors.New("missing required fields: spec.forProvider.image"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestDropletCreateRegionFallback(t *testing.T) {
+	sizes := fake.Respond(t, map[string]interface{}{"sizes": []godo.Size{
+		{Slug: "s-1vcpu-1gb", Regions: []string{"nyc1", "nyc3", "ams3"}},
+		{Slug: "s-8vcpu-16gb", Regions: []string{"nyc1", "nyc3"}},
+	}})
+
+	type want struct {
+		regions []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		size     string
+		fallback []string
+		want     want
+	}{
+		"CapacityErrorThenSuccess": {
+			reason:   "A capacity error in the preferred region should be retried in the next fallback region.",
+			size:     "s-1vcpu-1gb",
+			fallback: []string{"ams3"},
+			want:     want{regions: []string{"nyc3", "ams3"}},
+		},
+		"SizeNotInFallbackRegion": {
+			reason:   "A fallback region that does not support the requested size should be rejected before creating.",
+			size:     "s-8vcpu-16gb",
+			fallback: []string{"ams3"},
+			want:     want{err: errors.Errorf(errSizeNotInRegion, "s-8vcpu-16gb", "ams3")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var regions []string
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": untagged,
+				"GET /v2/sizes":    sizes,
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					var got struct {
+						Region string `json:"region"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+						t.Error(err)
+					}
+					regions = append(regions, got.Region)
+					if len(regions) == 1 {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"id":"unprocessable_entity","message":"Region nyc3 is currently unavailable, capacity exceeded"}`))
+						return
+					}
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+				},
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h)}
+			cr := droplet(withRequiredFields())
+			cr.Spec.ForProvider.Region = "nyc3"
+			cr.Spec.ForProvider.Size = tc.size
+			cr.Spec.ForProvider.RegionFallback = tc.fallback
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.regions, regions); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want regions, +got regions:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletCreateVPCRegion(t *testing.T) {
+	vpc := fake.Respond(t, map[string]interface{}{"vpc": godo.VPC{ID: "vpc-id", RegionSlug: "nyc3"}})
+
+	type want struct {
+		created bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		region string
+		want   want
+	}{
+		"SameRegion": {
+			reason: "A Droplet in the region of its VPC should be created.",
+			region: "nyc3",
+			want:   want{created: true},
+		},
+		"RegionMismatch": {
+			reason: "A Droplet in a different region than its VPC should be rejected before creating.",
+			region: "sfo3",
+			want:   want{err: errors.Errorf(errVPCRegionMismatch, "vpc-id", "nyc3", "sfo3")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := false
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets":    untagged,
+				"GET /v2/vpcs/vpc-id": vpc,
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					created = true
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+				},
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h)}
+			cr := droplet(withRequiredFields())
+			vpcID := "vpc-id"
+			cr.Spec.ForProvider.Region = tc.region
+			cr.Spec.ForProvider.VPCUUID = &vpcID
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, want{created: created, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletCreateRetried(t *testing.T) {
+	// Two reconciles of the same managed resource race: the second one
+	// works on a stale copy whose external-name is still empty.
+	var created []godo.Droplet
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			fake.Respond(t, map[string]interface{}{"droplets": created})(w, r)
+		},
+		"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			d := observedDroplet()
+			d.Tags = []string{docompute.DedupeTag(testUID)}
+			created = append(created, d)
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"droplet": d})(w, r)
+		},
+	})
+	e := &dropletExternal{Client: fake.NewClient(t, h)}
+
+	type want struct {
+		ec           managed.ExternalCreation
+		externalName string
+	}
+
+	first, stale := droplet(withRequiredFields()), droplet(withRequiredFields())
+	for name, cr := range map[string]*v1alpha1.Droplet{"First": first, "Stale": stale} {
+		ec, err := e.Create(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("%s: e.Create(...): %v", name, err)
+		}
+		w := want{ec: managed.ExternalCreation{ExternalNameAssigned: true}, externalName: "1234"}
+		if diff := cmp.Diff(w, want{ec: ec, externalName: meta.GetExternalName(cr)}, cmp.AllowUnexported(want{})); diff != "" {
+			t.Errorf("%s: e.Create(...): -want, +got:\n%s", name, diff)
+		}
+	}
+	if len(created) != 1 {
+		t.Errorf("e.Create(...): want a single Droplet to be created, got %d", len(created))
+	}
+
+	// A managed resource whose external-name is already set must not report
+	// it as newly assigned.
+	ec, err := e.Create(context.Background(), droplet(withRequiredFields(), withExternalName("1234")))
+	if err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if ec.ExternalNameAssigned {
+		t.Error("e.Create(...): want an existing external-name not to be reported as assigned")
+	}
+}
+
+// fakeRecorder records the reasons of the events it is sent.
+type fakeRecorder struct {
+	event.Recorder
+	reasons []event.Reason
+}
+
+func (r *fakeRecorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+type dropletAction struct {
+	Type  string `json:"type"`
+	Size  string `json:"size,omitempty"`
+	Disk  bool   `json:"disk,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Image int    `json:"image,omitempty"`
+}
+
+func withSize(size string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Spec.ForProvider.Size = size }
+}
+
+func withResizeDisk(b bool) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Spec.ForProvider.ResizeDisk = &b }
+}
+
+func withObserved(status, size string, disk int) dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		cr.Status.AtProvider.Status = status
+		cr.Status.AtProvider.Size = size
+		cr.Status.AtProvider.Disk = disk
+	}
+}
+
+func withFeatures(features ...string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Status.AtProvider.Features = features }
+}
+
+func withBackups(b bool) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Spec.ForProvider.Backups = &b }
+}
+
+func withIPv6(b bool) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Spec.ForProvider.IPv6 = &b }
+}
+
+func withReverseDNS(name, observed string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		cr.Spec.ForProvider.ReverseDNS = &name
+		cr.Status.AtProvider.ReverseDNS = observed
+	}
+}
+
+func withPoweredOffForResize() dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		meta.AddAnnotations(cr, map[string]string{docompute.AnnotationPoweredOffForResize: "true"})
+	}
+}
+
+func TestDropletUpdate(t *testing.T) {
+	type want struct {
+		action     *dropletAction
+		poweredOff bool
+		reasons    []event.Reason
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Droplet
+		want   want
+	}{
+		"PowerOffToResize": {
+			reason: "An active Droplet should be powered off before it is resized.",
+			cr:     droplet(withDropletID(testDropletID), withSize("s-2vcpu-2gb"), withObserved(v1alpha1.StatusActive, "s-1vcpu-1gb", 25)),
+			want: want{
+				action:     &dropletAction{Type: "power_off"},
+				poweredOff: true,
+			},
+		},
+		"Resize": {
+			reason: "A powered off Droplet should be resized without resizing its disk by default.",
+			cr:     droplet(withDropletID(testDropletID), withSize("s-2vcpu-2gb"), withObserved(v1alpha1.StatusOff, "s-1vcpu-1gb", 25), withPoweredOffForResize()),
+			want: want{
+				action:     &dropletAction{Type: "resize", Size: "s-2vcpu-2gb"},
+				poweredOff: true,
+			},
+		},
+		"ResizeDisk": {
+			reason: "A disk resize should be sent to the API and warned about as it is irreversible.",
+			cr:     droplet(withDropletID(testDropletID), withSize("s-2vcpu-2gb"), withResizeDisk(true), withObserved(v1alpha1.StatusOff, "s-1vcpu-1gb", 25), withPoweredOffForResize()),
+			want: want{
+				action:     &dropletAction{Type: "resize", Size: "s-2vcpu-2gb", Disk: true},
+				poweredOff: true,
+				reasons:    []event.Reason{reasonResizeDisk},
+			},
+		},
+		"ResizeDiskToSmaller": {
+			reason: "A disk resize to a size with a smaller disk should be rejected.",
+			cr:     droplet(withDropletID(testDropletID), withSize("s-1vcpu-1gb"), withResizeDisk(true), withObserved(v1alpha1.StatusOff, "s-2vcpu-2gb", 50)),
+			want: want{
+				err: errors.Errorf(errDiskShrink, "s-1vcpu-1gb", 25, 50),
+			},
+		},
+		"SetReverseDNS": {
+			reason: "A Droplet without reverse DNS should be renamed to the desired name.",
+			cr:     droplet(withDropletID(testDropletID), withReverseDNS("web.example.com", ""), withObserved(v1alpha1.StatusActive, "", 25)),
+			want: want{
+				action: &dropletAction{Type: "rename", Name: "web.example.com"},
+			},
+		},
+		"ChangeReverseDNS": {
+			reason: "A Droplet whose reverse DNS changed should be renamed to the new name.",
+			cr:     droplet(withDropletID(testDropletID), withReverseDNS("api.example.com", "web.example.com"), withObserved(v1alpha1.StatusActive, "", 25)),
+			want: want{
+				action: &dropletAction{Type: "rename", Name: "api.example.com"},
+			},
+		},
+		"ReverseDNSNotFQDN": {
+			reason: "A reverse DNS name that is not a fully qualified domain name should be rejected.",
+			cr:     droplet(withDropletID(testDropletID), withReverseDNS("web", ""), withObserved(v1alpha1.StatusActive, "", 25)),
+			want: want{
+				err: errors.Errorf("reverse DNS name %q is not a fully qualified domain name", "web"),
+			},
+		},
+		"EnableBackups": {
+			reason: "Backups should be enabled on a Droplet that does not have them.",
+			cr:     droplet(withDropletID(testDropletID), withBackups(true), withObserved(v1alpha1.StatusActive, "", 25)),
+			want: want{
+				action: &dropletAction{Type: "enable_backups"},
+			},
+		},
+		"DisableBackups": {
+			reason: "Backups should be disabled on a Droplet that has them.",
+			cr:     droplet(withDropletID(testDropletID), withBackups(false), withObserved(v1alpha1.StatusActive, "", 25), withFeatures("backups")),
+			want: want{
+				action: &dropletAction{Type: "disable_backups"},
+			},
+		},
+		"EnableIPv6": {
+			reason: "IPv6 should be enabled on a Droplet that does not have it, before it is resized.",
+			cr:     droplet(withDropletID(testDropletID), withIPv6(true), withSize("s-2vcpu-2gb"), withObserved(v1alpha1.StatusActive, "s-1vcpu-1gb", 25)),
+			want: want{
+				action: &dropletAction{Type: "enable_ipv6"},
+			},
+		},
+		"PowerOnAfterResize": {
+			reason: "A Droplet that was powered off to be resized should be powered on once the resize is complete.",
+			cr:     droplet(withDropletID(testDropletID), withSize("s-2vcpu-2gb"), withObserved(v1alpha1.StatusOff, "s-2vcpu-2gb", 25), withPoweredOffForResize()),
+			want: want{
+				action: &dropletAction{Type: "power_on"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *dropletAction
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/sizes": fake.Respond(t, map[string]interface{}{"sizes": []godo.Size{
+					{Slug: "s-1vcpu-1gb", Disk: 25},
+					{Slug: "s-2vcpu-2gb", Disk: 50},
+				}}),
+				"POST /v2/droplets/1234/actions": func(w http.ResponseWriter, r *http.Request) {
+					got = &dropletAction{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: got.Type}})(w, r)
+				},
+			})
+			record := &fakeRecorder{}
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: record,
+				Client: fake.NewClient(t, h),
+			}
+
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.action, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want action, +got action:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.poweredOff, poweredOffForResize(tc.cr)); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want powered off annotation, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, record.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withRebuildFrom(image string, observedID int) dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		cr.Spec.ForProvider.RebuildFrom = &image
+		cr.Status.AtProvider.ImageID = observedID
+		cr.Status.AtProvider.Region = "nyc3"
+		cr.Status.AtProvider.Disk = 25
+	}
+}
+
+func TestDropletRebuild(t *testing.T) {
+	snapshot := godo.Image{ID: 5678, Name: "web-snapshot", Type: "snapshot", Regions: []string{"nyc3"}, MinDiskSize: 25}
+
+	type want struct {
+		action   *dropletAction
+		polls    int
+		creating bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Droplet
+		image  godo.Image
+		want   want
+	}{
+		"Rebuild": {
+			reason: "A Droplet that does not run the image it is rebuilt from should be rebuilt, and the rebuild polled until it completes.",
+			cr:     droplet(withDropletID(testDropletID), withRebuildFrom("5678", 1111), withObserved(v1alpha1.StatusActive, "", 25)),
+			image:  snapshot,
+			want: want{
+				action:   &dropletAction{Type: "rebuild", Image: 5678},
+				polls:    2,
+				creating: true,
+			},
+		},
+		"AlreadyRebuilt": {
+			reason: "A Droplet that runs the image it is rebuilt from should not be rebuilt again.",
+			cr:     droplet(withDropletID(testDropletID), withRebuildFrom("5678", 5678), withObserved(v1alpha1.StatusActive, "", 25)),
+			image:  snapshot,
+		},
+		"WrongRegion": {
+			reason: "A Droplet should not be rebuilt from an image that is not available in its region.",
+			cr:     droplet(withDropletID(testDropletID), withRebuildFrom("5678", 1111), withObserved(v1alpha1.StatusActive, "", 25)),
+			image:  godo.Image{ID: 5678, Name: "web-snapshot", Regions: []string{"ams3"}},
+			want: want{
+				err: errors.Errorf("cannot rebuild Droplet from image %q: it is not available in region %q", "web-snapshot", "nyc3"),
+			},
+		},
+		"DiskTooSmall": {
+			reason: "A Droplet should not be rebuilt from an image that needs a larger disk.",
+			cr:     droplet(withDropletID(testDropletID), withRebuildFrom("5678", 1111), withObserved(v1alpha1.StatusActive, "", 25)),
+			image:  godo.Image{ID: 5678, Name: "web-snapshot", Regions: []string{"nyc3"}, MinDiskSize: 50},
+			want: want{
+				err: errors.Errorf("cannot rebuild Droplet from image %q: it needs a disk of at least %d GB, but the Droplet has %d GB", "web-snapshot", 50, 25),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *dropletAction
+			polls := 0
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/images/5678": fake.Respond(t, map[string]interface{}{"image": tc.image}),
+				"POST /v2/droplets/1234/actions": func(w http.ResponseWriter, r *http.Request) {
+					got = &dropletAction{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: got.Type, Status: godo.ActionInProgress}})(w, r)
+				},
+				"GET /v2/actions/1": func(w http.ResponseWriter, r *http.Request) {
+					polls++
+					status := godo.ActionInProgress
+					if polls > 1 {
+						status = godo.ActionCompleted
+					}
+					fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "rebuild", Status: status}})(w, r)
+				},
+			})
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.action, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want action, +got action:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.polls, polls); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want polls, +got polls:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creating, tc.cr.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonCreating); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want creating, +got creating:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletObserveRebuild(t *testing.T) {
+	observed := observedDroplet()
+	observed.Image = &godo.Image{ID: 1111}
+
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockPatch: test.NewMockPatchFn(nil)},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withExternalName("1234"), withRebuildFrom("5678", 0))
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): a Droplet that does not run the image it is rebuilt from should not be up to date")
+	}
+	if diff := cmp.Diff(xpv1.Creating(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Observe(...): -want ready condition, +got:\n%s", diff)
+	}
+}
+
+func TestDropletActionTracking(t *testing.T) {
+	status := godo.ActionInProgress
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"POST /v2/droplets/1234/actions": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "resize", Status: godo.ActionInProgress}})(w, r)
+		},
+		"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()}),
+		"GET /v2/actions/1": func(w http.ResponseWriter, r *http.Request) {
+			fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "resize", Status: status}})(w, r)
+		},
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withExternalName("1234"), withDropletID(testDropletID), withSize("s-2vcpu-2gb"), withObserved(v1alpha1.StatusOff, "s-1vcpu-1gb", 25), withPoweredOffForResize())
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := &dov1alpha1.Action{ID: 1, Type: "resize", Status: godo.ActionInProgress}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.Action); diff != "" {
+		t.Errorf("e.Update(...): -want action, +got action:\n%s", diff)
+	}
+
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.Action); diff != "" {
+		t.Errorf("e.Observe(...): want an action in progress to stay recorded: -want action, +got action:\n%s", diff)
+	}
+
+	status = godo.ActionCompleted
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if cr.Status.AtProvider.Action != nil {
+		t.Errorf("e.Observe(...): want a completed action to be cleared, got %+v", cr.Status.AtProvider.Action)
+	}
+}
+
+func TestDropletObserveDelayedIPv6(t *testing.T) {
+	ipv4 := []godo.NetworkV4{{IPAddress: "203.0.113.10", Type: "public"}}
+	ipv6 := []godo.NetworkV6{{IPAddress: "2001:db8::10", Netmask: 64, Type: "public"}}
+
+	cases := map[string]struct {
+		reason   string
+		ipv6     bool
+		networks *godo.Networks
+		want     xpv1.Condition
+	}{
+		"IPv6Pending": {
+			reason:   "A Droplet with IPv6 enabled should not be available while only its IPv4 address is assigned.",
+			ipv6:     true,
+			networks: &godo.Networks{V4: ipv4},
+			want:     xpv1.Creating().WithMessage(msgAddressesPending),
+		},
+		"DualStack": {
+			reason:   "A Droplet with IPv6 enabled should be available once both its addresses are assigned.",
+			ipv6:     true,
+			networks: &godo.Networks{V4: ipv4, V6: ipv6},
+			want:     xpv1.Available(),
+		},
+		"IPv4Only": {
+			reason:   "A Droplet without IPv6 should be available once its IPv4 address is assigned.",
+			networks: &godo.Networks{V4: ipv4},
+			want:     xpv1.Available(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := observedDroplet()
+			observed.Networks = tc.networks
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+			})
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			cr := droplet(withExternalName("1234"), withIPv6(tc.ipv6))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want ready condition, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withAttachments(volumes []string, vpc string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		cr.Spec.ForProvider.Volumes = volumes
+		cr.Spec.ForProvider.VPCUUID = &vpc
+	}
+}
+
+func TestDropletObserveAttachments(t *testing.T) {
+	type want struct {
+		ready   xpv1.Condition
+		volumes xpv1.Condition
+		vpc     xpv1.Condition
+	}
+
+	attached := func(t xpv1.ConditionType) xpv1.Condition {
+		return xpv1.Condition{Type: t, Status: corev1.ConditionTrue, Reason: docompute.ReasonAttached}
+	}
+	pending := func(t xpv1.ConditionType) xpv1.Condition {
+		return xpv1.Condition{Type: t, Status: corev1.ConditionFalse, Reason: docompute.ReasonPending}
+	}
+
+	cases := map[string]struct {
+		reason  string
+		volumes []string
+		vpc     string
+		want    want
+	}{
+		"VolumesPending": {
+			reason:  "An active Droplet should not be available while only some of its volumes are attached.",
+			volumes: []string{"vol-1"},
+			vpc:     "vpc-uuid",
+			want: want{
+				ready:   xpv1.Creating().WithMessage(msgAttachmentsPending + string(docompute.TypeVolumesAttached)),
+				volumes: pending(docompute.TypeVolumesAttached).WithMessage("volumes not attached yet: vol-2"),
+				vpc:     attached(docompute.TypeVPCAssigned),
+			},
+		},
+		"VPCPending": {
+			reason:  "An active Droplet should not be available while it is not assigned to its VPC.",
+			volumes: []string{"vol-1", "vol-2"},
+			vpc:     "default-vpc-uuid",
+			want: want{
+				ready:   xpv1.Creating().WithMessage(msgAttachmentsPending + string(docompute.TypeVPCAssigned)),
+				volumes: attached(docompute.TypeVolumesAttached),
+				vpc:     pending(docompute.TypeVPCAssigned),
+			},
+		},
+		"AllAttached": {
+			reason:  "An active Droplet should be available once all of its volumes are attached and it is assigned to its VPC.",
+			volumes: []string{"vol-1", "vol-2"},
+			vpc:     "vpc-uuid",
+			want: want{
+				ready:   xpv1.Available(),
+				volumes: attached(docompute.TypeVolumesAttached),
+				vpc:     attached(docompute.TypeVPCAssigned),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := observedDroplet()
+			observed.Networks = &godo.Networks{V4: []godo.NetworkV4{{IPAddress: "203.0.113.10", Type: "public"}}}
+			observed.VolumeIDs = tc.volumes
+			observed.VPCUUID = tc.vpc
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+			})
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			cr := droplet(withExternalName("1234"), withAttachments([]string{"vol-1", "vol-2"}, "vpc-uuid"))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{
+				ready:   cr.GetCondition(xpv1.TypeReady),
+				volumes: cr.GetCondition(docompute.TypeVolumesAttached),
+				vpc:     cr.GetCondition(docompute.TypeVPCAssigned),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletVolumeAttachFailedAfterCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		attach  http.HandlerFunc
+		wantErr bool
+	}{
+		"Attached": {
+			reason: "A volume that was not attached when the Droplet was created should be attached to the existing Droplet.",
+			attach: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "attach_volume", Status: godo.ActionInProgress}})(w, r)
+			},
+		},
+		"AttachFailed": {
+			reason: "A volume that cannot be attached should be retried without creating the Droplet again.",
+			attach: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"id":"unprocessable_entity","message":"volume is attached to another Droplet"}`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var creates int
+			var attached struct {
+				Type      string `json:"type"`
+				DropletID int    `json:"droplet_id"`
+			}
+			observed := observedDroplet()
+			observed.VolumeIDs = []string{"vol-1"}
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": untagged,
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					creates++
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": observedDroplet()})(w, r)
+				},
+				"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+				"POST /v2/volumes/vol-2/actions": func(w http.ResponseWriter, r *http.Request) {
+					if err := json.NewDecoder(r.Body).Decode(&attached); err != nil {
+						t.Error(err)
+					}
+					tc.attach(w, r)
+				},
+			})
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			cr := droplet(withRequiredFields())
+			cr.Spec.ForProvider.Volumes = []string{"vol-1", "vol-2"}
+
+			if _, err := e.Create(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v", tc.reason, err)
+			}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if o.ResourceUpToDate {
+				t.Errorf("\n%s\ne.Observe(...): want a Droplet with a volume that is not attached not to be up to date", tc.reason)
+			}
+			want := xpv1.Condition{Type: docompute.TypeVolumesAttached, Status: corev1.ConditionFalse, Reason: docompute.ReasonPending, Message: "volumes not attached yet: vol-2"}
+			if diff := cmp.Diff(want, cr.GetCondition(docompute.TypeVolumesAttached), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want volumes condition, +got:\n%s\n", tc.reason, diff)
+			}
+
+			_, err = e.Update(context.Background(), cr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\ne.Update(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if attached.Type != "attach" || attached.DropletID != testDropletID {
+				t.Errorf("\n%s\ne.Update(...): want vol-2 attached to Droplet %d, got %+v", tc.reason, testDropletID, attached)
+			}
+			if creates != 1 {
+				t.Errorf("\n%s\nwant the Droplet to be created once, got %d", tc.reason, creates)
+			}
+		})
+	}
+}
+
+func TestDropletObserveImmutableFieldChanged(t *testing.T) {
+	observed := observedDroplet()
+	observed.Features = []string{"ipv6"}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.Droplet
+		want     []event.Reason
+		changed  corev1.ConditionStatus
+		upToDate bool
+	}{
+		"IPv6Disabled": {
+			reason:   "Disabling IPv6, which cannot be changed in place, should be warned about.",
+			cr:       droplet(withExternalName("1234"), withIPv6(false)),
+			want:     []event.Reason{reasonImmutableChanged},
+			changed:  corev1.ConditionTrue,
+			upToDate: true,
+		},
+		"AlreadyReported": {
+			reason:   "A change that was already reported should not be warned about again.",
+			cr:       droplet(withExternalName("1234"), withIPv6(false), withCondition(do.ImmutableFieldCondition(do.ImmutableFields{"ipv6": false}))),
+			changed:  corev1.ConditionTrue,
+			upToDate: true,
+		},
+		"Unchanged": {
+			reason:   "A Droplet whose immutable fields match should not be warned about.",
+			cr:       droplet(withExternalName("1234"), withIPv6(true)),
+			changed:  corev1.ConditionFalse,
+			upToDate: true,
+		},
+		"Recreate": {
+			reason:  "A Droplet whose immutable fields changed should not be up to date if it should be recreated.",
+			cr:      droplet(withExternalName("1234"), withIPv6(false), withRecreate()),
+			want:    []event.Reason{reasonImmutableChanged},
+			changed: corev1.ConditionTrue,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+			})
+			record := &fakeRecorder{}
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: record,
+				Client: fake.NewClient(t, h),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, record.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.changed, tc.cr.GetCondition(do.TypeImmutableFieldChanged).Status); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want up to date, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withCondition(c xpv1.Condition) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.SetConditions(c) }
+}
+
+// withRecreate opts the Droplet into being recreated when an immutable field
+// changed.
+func withRecreate() dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		t := true
+		cr.Spec.ForProvider.RecreateOnImmutableChange = &t
+	}
+}
+
+func withRecreateStrategy(strategy string) dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Spec.ForProvider.RecreateStrategy = strategy }
+}
+
+func withLocked() dropletModifier {
+	return func(cr *v1alpha1.Droplet) { cr.Status.AtProvider.Locked = true }
+}
+
+func TestDropletRecreate(t *testing.T) {
+	// The replacement created by a previous attempt whose destroy failed.
+	replacement := observedDroplet()
+	replacement.ID = 5678
+
+	type want struct {
+		requests     []string
+		externalName string
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.Droplet
+		created []godo.Droplet
+		want    want
+	}{
+		"Destroy": {
+			reason: "A Droplet should be destroyed, leaving the new one to be created by the next reconcile.",
+			cr:     droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate()),
+			want: want{
+				requests:     []string{"untag", "destroy"},
+				externalName: "example",
+			},
+		},
+		"DestroyBeforeCreate": {
+			reason: "A Droplet recreated with the DestroyBeforeCreate strategy should be destroyed before the new one is created.",
+			cr:     droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate(), withRecreateStrategy(v1alpha1.RecreateStrategyDestroyBeforeCreate)),
+			want: want{
+				requests:     []string{"untag", "destroy"},
+				externalName: "example",
+			},
+		},
+		"CreateBeforeDestroy": {
+			reason: "A Droplet recreated with the CreateBeforeDestroy strategy should only be destroyed once its replacement was created and recorded.",
+			cr:     droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate(), withRecreateStrategy(v1alpha1.RecreateStrategyCreateBeforeDestroy)),
+			want: want{
+				requests:     []string{"untag", "create", "destroy"},
+				externalName: "5678",
+			},
+		},
+		"CreateBeforeDestroyReplacementExists": {
+			reason:  "A replacement created by a previous attempt should be recorded rather than created twice.",
+			cr:      droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate(), withRecreateStrategy(v1alpha1.RecreateStrategyCreateBeforeDestroy)),
+			created: []godo.Droplet{replacement},
+			want: want{
+				requests:     []string{"untag", "destroy"},
+				externalName: "5678",
+			},
+		},
+		"Locked": {
+			reason: "A Droplet locked by an action in progress should not be destroyed yet.",
+			cr:     droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate(), withLocked()),
+			want: want{
+				externalName: "1234",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"DELETE /v2/tags/crossplane:" + testUID + "/resources": func(w http.ResponseWriter, _ *http.Request) {
+					got = append(got, "untag")
+					w.WriteHeader(http.StatusNoContent)
+				},
+				"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+					got = append(got, "destroy")
+					w.WriteHeader(http.StatusNoContent)
+				},
+				"GET /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					if tag := r.URL.Query().Get("tag_name"); tag != "crossplane:"+testUID {
+						t.Errorf("ListByTag(...): want the dedupe tag, got %q", tag)
+					}
+					fake.Respond(t, map[string]interface{}{"droplets": append([]godo.Droplet{}, tc.created...)})(w, r)
+				},
+				"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					got = append(got, "create")
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"droplet": replacement})(w, r)
+				},
+			})
+			e := &dropletExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			tc.cr.SetConditions(do.ImmutableFieldCondition(do.ImmutableFields{"ipv6": false}))
+
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, want{requests: got, externalName: meta.GetExternalName(tc.cr)}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletRecreateDestroyFailed(t *testing.T) {
+	replacement := observedDroplet()
+	replacement.ID = 5678
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"DELETE /v2/tags/crossplane:" + testUID + "/resources": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v2/droplets": untagged,
+		"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"droplet": replacement})(w, r)
+		},
+		"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	})
+	updated := false
+	e := &dropletExternal{
+		kube: &test.MockClient{MockUpdate: func(context.Context, client.Object, ...client.UpdateOption) error {
+			updated = true
+			return nil
+		}},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate(), withRecreateStrategy(v1alpha1.RecreateStrategyCreateBeforeDestroy))
+	cr.SetConditions(do.ImmutableFieldCondition(do.ImmutableFields{"ipv6": false}))
+
+	if _, err := e.Update(context.Background(), cr); err == nil {
+		t.Fatal("e.Update(...): want an error if the replaced Droplet cannot be destroyed")
+	}
+	// The replaced Droplet must stay managed until it is destroyed. The
+	// replacement is found by its dedupe tag by the next attempt.
+	if updated {
+		t.Error("e.Update(...): want the ID of the replacement not to be persisted before the replaced Droplet is destroyed")
+	}
+}
+
+func TestDropletRecreateThenCreate(t *testing.T) {
+	var requests []string
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"DELETE /v2/tags/crossplane:" + testUID + "/resources": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+			requests = append(requests, "destroy")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /v2/droplets": untagged,
+		"POST /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, "create")
+			d := observedDroplet()
+			d.ID = 5678
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"droplet": d})(w, r)
+		},
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withRequiredFields(), withExternalName("1234"), withDropletID(testDropletID), withRecreate())
+	cr.SetConditions(do.ImmutableFieldCondition(do.ImmutableFields{"ipv6": false}))
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	ec, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+
+	type want struct {
+		requests     []string
+		ec           managed.ExternalCreation
+		externalName string
+	}
+	// The new Droplet must be recorded rather than found again by the
+	// next Observe.
+	w := want{requests: []string{"destroy", "create"}, ec: managed.ExternalCreation{ExternalNameAssigned: true}, externalName: "5678"}
+	if diff := cmp.Diff(w, want{requests: requests, ec: ec, externalName: meta.GetExternalName(cr)}, cmp.AllowUnexported(want{})); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDropletDeleteLocked(t *testing.T) {
+	calls := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"id":"unprocessable_entity","message":"Droplet is currently locked"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	e := &dropletExternal{Client: fake.NewClient(t, h)}
+	cr := droplet(withDropletID(testDropletID))
+
+	// The first deletion hits a Droplet that is locked by a resize. It must
+	// not be reported as a failure so that the reconciler simply retries.
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Errorf("e.Delete(...): locked Droplet: want no error, got %v", err)
+	}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Errorf("e.Delete(...): unlocked Droplet: want no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("e.Delete(...): want 2 API calls, got %d", calls)
+	}
+}
+
+func TestDropletDeleteDuringResize(t *testing.T) {
+	locked := true
+	var deletes int
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets/1234": func(w http.ResponseWriter, r *http.Request) {
+			d := observedDroplet()
+			d.Status = v1alpha1.StatusOff
+			d.Locked = locked
+			fake.Respond(t, map[string]interface{}{"droplet": d})(w, r)
+		},
+		"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withExternalName("1234"), withSize("s-2vcpu-2gb"), withPoweredOffForResize())
+
+	// The managed resource is deleted while the Droplet is locked by its
+	// resize. The deletion must wait for the resize rather than race it.
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): locked Droplet: %v", err)
+	}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): locked Droplet: %v", err)
+	}
+	if deletes != 0 {
+		t.Errorf("e.Delete(...): want no deletion while the Droplet is locked, got %d", deletes)
+	}
+	if diff := cmp.Diff(xpv1.Deleting().WithMessage(msgDeleteLocked), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Delete(...): -want condition, +got condition:\n%s", diff)
+	}
+
+	locked = false
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unlocked Droplet: %v", err)
+	}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): unlocked Droplet: %v", err)
+	}
+	if deletes != 1 {
+		t.Errorf("e.Delete(...): want the Droplet to be deleted once it is unlocked, got %d deletions", deletes)
+	}
+	if diff := cmp.Diff(xpv1.Deleting(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Delete(...): -want condition, +got condition:\n%s", diff)
+	}
+}
+
+func TestDropletDeleteLockedTimedOut(t *testing.T) {
+	var deletes int
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	c := deletion.NewTimeoutConnecter(deletion.Options{Timeout: 10 * time.Minute}, managed.ExternalConnectorFn(
+		func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+			return &dropletExternal{Client: fake.NewClient(t, h)}, nil
+		}))
+	e, err := c.Connect(context.Background(), droplet())
+	if err != nil {
+		t.Fatalf("c.Connect(...): %v", err)
+	}
+	cr := droplet(withDropletID(testDropletID), withLocked())
+	cr.SetAnnotations(map[string]string{meta.AnnotationKeyExternalName: "1234", deletion.AnnotationForceDelete: "true"})
+	d := metav1.NewTime(time.Now().Add(-time.Hour))
+	cr.SetDeletionTimestamp(&d)
+
+	// The Droplet stays locked past the timeout. Its deletion returns no
+	// error while it waits, but must still be reported as timed out.
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): locked Droplet: %v", err)
+	}
+	if deletes != 0 {
+		t.Errorf("e.Delete(...): want no deletion while the Droplet is locked, got %d", deletes)
+	}
+	if diff := cmp.Diff(corev1.ConditionTrue, cr.GetCondition(deletion.TypeDeleteTimedOut).Status); diff != "" {
+		t.Errorf("e.Delete(...): -want DeleteTimedOut status, +got DeleteTimedOut status:\n%s", diff)
+	}
+
+	// The force-delete annotation then releases the managed resource.
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceExists {
+		t.Error("e.Observe(...): want a force-deleted Droplet to be reported as not existing")
+	}
+}
+
+func TestDropletObserveCleanupTags(t *testing.T) {
+	dedupe := "/v2/tags/crossplane:" + testUID
+
+	cases := map[string]struct {
+		reason  string
+		cleanup bool
+		want    []string
+	}{
+		"Enabled": {
+			reason:  "Tags of a destroyed Droplet that no resource carries anymore should be deleted, while tags still in use are retained.",
+			cleanup: true,
+			want:    []string{"GET /v2/tags/web", "GET " + dedupe, "DELETE " + dedupe},
+		},
+		"Disabled": {
+			reason: "Tags of a destroyed Droplet should be retained unless they are cleaned up on delete.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			record := func(h http.HandlerFunc) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					got = append(got, r.Method+" "+r.URL.Path)
+					h(w, r)
+				}
+			}
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"id":"not_found","message":"The resource you were accessing could not be found."}`))
+				},
+				"GET /v2/tags/web": record(fake.Respond(t, map[string]interface{}{"tag": godo.Tag{Name: "web", Resources: &godo.TaggedResources{Count: 2}}})),
+				"GET " + dedupe:    record(fake.Respond(t, map[string]interface{}{"tag": godo.Tag{Name: "crossplane:" + testUID, Resources: &godo.TaggedResources{}}})),
+				"DELETE " + dedupe: record(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h)}
+			cr := droplet(withExternalName("1234"))
+			cr.Spec.ForProvider.Tags = []string{"web"}
+			cr.Spec.ForProvider.CleanupTagsOnDelete = &tc.cleanup
+			now := metav1.Now()
+			cr.SetDeletionTimestamp(&now)
+
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if o.ResourceExists {
+				t.Errorf("\n%s\ne.Observe(...): want the destroyed Droplet not to exist", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want tag requests, +got tag requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletDeleteUnprocessable(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"id":"unprocessable_entity","message":"something else went wrong"}`))
+	}
+	e := &dropletExternal{Client: fake.NewClient(t, h)}
+
+	if err := e.Delete(context.Background(), droplet(withDropletID(testDropletID))); err == nil {
+		t.Error("e.Delete(...): want error for an unprocessable deletion that is not caused by a lock, got nil")
+	}
+}
+
+func withGracefulShutdown() dropletModifier {
+	return func(cr *v1alpha1.Droplet) {
+		t := true
+		cr.Spec.ForProvider.GracefulShutdown = &t
+	}
+}
+
+func TestDropletDeleteGracefulShutdown(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Droplet
+		status string
+		want   []string
+	}{
+		"Disabled": {
+			reason: "A Droplet should be destroyed right away unless it is shut down gracefully.",
+			cr:     droplet(withDropletID(testDropletID), withObserved(v1alpha1.StatusActive, "", 25)),
+			want:   []string{"destroy"},
+		},
+		"ShutDown": {
+			reason: "A Droplet should be destroyed once it is shut down.",
+			cr:     droplet(withDropletID(testDropletID), withObserved(v1alpha1.StatusActive, "", 25), withGracefulShutdown()),
+			status: godo.ActionCompleted,
+			want:   []string{"shutdown", "poll", "destroy"},
+		},
+		"TimedOut": {
+			reason: "A Droplet that does not shut down in time should be destroyed anyway.",
+			cr:     droplet(withDropletID(testDropletID), withObserved(v1alpha1.StatusActive, "", 25), withGracefulShutdown()),
+			status: godo.ActionInProgress,
+			want:   []string{"shutdown", "poll", "destroy"},
+		},
+		"Errored": {
+			reason: "A Droplet whose shutdown errored should be destroyed anyway.",
+			cr:     droplet(withDropletID(testDropletID), withObserved(v1alpha1.StatusActive, "", 25), withGracefulShutdown()),
+			status: "errored",
+			want:   []string{"shutdown", "poll", "destroy"},
+		},
+		"AlreadyOff": {
+			reason: "A Droplet that is already off should not be shut down again.",
+			cr:     droplet(withDropletID(testDropletID), withObserved(v1alpha1.StatusOff, "", 25), withGracefulShutdown()),
+			want:   []string{"destroy"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/droplets/1234/actions": func(w http.ResponseWriter, r *http.Request) {
+					a := &dropletAction{}
+					if err := json.NewDecoder(r.Body).Decode(a); err != nil {
+						t.Error(err)
+					}
+					got = append(got, a.Type)
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: a.Type, Status: godo.ActionInProgress}})(w, r)
+				},
+				"GET /v2/actions/1": func(w http.ResponseWriter, r *http.Request) {
+					got = append(got, "poll")
+					fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "shutdown", Status: tc.status}})(w, r)
+				},
+				"DELETE /v2/droplets/1234": func(w http.ResponseWriter, _ *http.Request) {
+					got = append(got, "destroy")
+					w.WriteHeader(http.StatusNoContent)
+				},
+			})
+			e := &dropletExternal{Client: fake.NewClient(t, h), shutdownTimeout: 10 * time.Millisecond}
+
+			if err := e.Delete(context.Background(), tc.cr); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletConnectionDetails(t *testing.T) {
+	networked := observedDroplet()
+	networked.Networks = &godo.Networks{V4: []godo.NetworkV4{
+		{IPAddress: "203.0.113.10", Type: "public"},
+		{IPAddress: "10.10.0.2", Type: "private"},
+	}}
+	dualStack := observedDroplet()
+	dualStack.Networks = &godo.Networks{
+		V4: []godo.NetworkV4{{IPAddress: "203.0.113.10", Netmask: "255.255.255.0", Gateway: "203.0.113.1", Type: "public"}},
+		V6: []godo.NetworkV6{{IPAddress: "2001:db8::10", Netmask: 64, Gateway: "2001:db8::1", Type: "public"}},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		network  string
+		observed godo.Droplet
+		want     managed.ConnectionDetails
+	}{
+		"Default": {
+			reason:   "The public address should be published when no network is specified.",
+			observed: networked,
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("203.0.113.10"),
+				"host":        []byte("203.0.113.10"),
+				"public_ipv4": []byte("203.0.113.10"),
+			},
+		},
+		"Public": {
+			reason:   "The public address should be published for the public network.",
+			network:  v1alpha1.ConnectionDetailsNetworkPublic,
+			observed: networked,
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("203.0.113.10"),
+				"host":        []byte("203.0.113.10"),
+				"public_ipv4": []byte("203.0.113.10"),
+			},
+		},
+		"Private": {
+			reason:   "The private address should be published for the private network.",
+			network:  v1alpha1.ConnectionDetailsNetworkPrivate,
+			observed: networked,
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("10.10.0.2"),
+				"host":        []byte("10.10.0.2"),
+				"public_ipv4": []byte("203.0.113.10"),
+			},
+		},
+		"Both": {
+			reason:   "The public address should be the default and the private address published separately for both networks.",
+			network:  v1alpha1.ConnectionDetailsNetworkBoth,
+			observed: networked,
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("203.0.113.10"),
+				"host":         []byte("203.0.113.10"),
+				"private_host": []byte("10.10.0.2"),
+				"public_ipv4":  []byte("203.0.113.10"),
+			},
+		},
+		"DualStack": {
+			reason:   "The public addresses of both IP families should be published with their gateways and CIDRs.",
+			observed: dualStack,
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("203.0.113.10"),
+				"host":                []byte("203.0.113.10"),
+				"public_ipv4":         []byte("203.0.113.10"),
+				"public_ipv4_gateway": []byte("203.0.113.1"),
+				"public_ipv4_cidr":    []byte("203.0.113.10/24"),
+				"public_ipv6":         []byte("2001:db8::10"),
+				"public_ipv6_gateway": []byte("2001:db8::1"),
+				"public_ipv6_cidr":    []byte("2001:db8::10/64"),
+			},
+		},
+		"NotAssigned": {
+			reason:   "Nothing should be published before addresses are assigned.",
+			network:  v1alpha1.ConnectionDetailsNetworkPrivate,
+			observed: observedDroplet(),
+			want:     nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := connectionDetails(tc.network, tc.observed, docompute.GenerateObservation(tc.observed))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nconnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletObserveLatestBackup(t *testing.T) {
+	observed := observedDroplet()
+	observed.Features = []string{"backups"}
+
+	pages := map[string]interface{}{
+		"": map[string]interface{}{
+			"backups": []godo.Image{
+				{ID: 2, Created: "2021-06-08T04:00:00Z"},
+				{ID: 1, Created: "2021-06-01T04:00:00Z"},
+			},
+			"links": map[string]interface{}{"pages": map[string]string{
+				"next": "https://api.digitalocean.com/v2/droplets/1234/backups?page=2",
+				"last": "https://api.digitalocean.com/v2/droplets/1234/backups?page=2",
+			}},
+		},
+		"2": map[string]interface{}{
+			"backups": []godo.Image{
+				{ID: 3, Created: "2021-06-15T04:00:00Z"},
+			},
+			"links": map[string]interface{}{"pages": map[string]string{
+				"prev":  "https://api.digitalocean.com/v2/droplets/1234/backups?page=1",
+				"first": "https://api.digitalocean.com/v2/droplets/1234/backups?page=1",
+			}},
+		},
+	}
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+		"GET /v2/droplets/1234/backups": func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			if page == "1" {
+				page = ""
+			}
+			fake.Respond(t, pages[page])(w, r)
+		},
+	})
+	e := &dropletExternal{
+		kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		record: &fakeRecorder{},
+		Client: fake.NewClient(t, h),
+	}
+	cr := droplet(withExternalName("1234"))
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+
+	want := v1alpha1.DropletObservation{LatestBackupID: 3, LatestBackupCreated: "2021-06-15T04:00:00Z"}
+	got := v1alpha1.DropletObservation{
+		LatestBackupID:      cr.Status.AtProvider.LatestBackupID,
+		LatestBackupCreated: cr.Status.AtProvider.LatestBackupCreated,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Observe(...): -want latest backup, +got:\n%s", diff)
+	}
+}
+
+func TestDropletObserveNoLateInit(t *testing.T) {
+	observed := observedDroplet()
+	observed.Tags = []string{"web"}
+	observed.VPCUUID = "vpc-uuid"
+
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		updated     bool
+	}{
+		"LateInitialized": {
+			reason:  "Unset fields of the spec should be late initialized and written back.",
+			updated: true,
+		},
+		"NoLateInit": {
+			reason:      "The spec of a Droplet annotated to skip late initialization should never be written.",
+			annotations: map[string]string{do.AnnotationNoLateInit: "true"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": observed}),
+			})
+			var updated bool
+			e := &dropletExternal{
+				kube: &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					updated = true
+					return nil
+				}},
+				record: &fakeRecorder{},
+				Client: fake.NewClient(t, h),
+			}
+			cr := droplet(withExternalName("1234"))
+			meta.AddAnnotations(cr, tc.annotations)
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.updated, updated); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want spec written, +got spec written:\n%s", tc.reason, diff)
+			}
+			if !tc.updated && cr.Spec.ForProvider.VPCUUID != nil {
+				t.Errorf("\n%s\ne.Observe(...): want the spec not to be late initialized", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"sort"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	docompute "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/compute"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotDropletGroup = "managed resource is not a DropletGroup resource"
+	errListMembers     = "cannot list Droplets of DropletGroup"
+
+	errDropletGroupCreateFailed = "creation of DropletGroup resource has failed"
+	errDropletGroupDeleteFailed = "deletion of DropletGroup resource has failed"
+	errScaleUp                  = "cannot create Droplets of DropletGroup"
+	errScaleDown                = "cannot delete Droplet %d of DropletGroup"
+	errForeignMembers           = "cannot delete Droplets by tag %q: Droplets %v were not created by the DropletGroup: remove the tag from them or set spec.forProvider.manageByTagExclusive"
+)
+
+// SetupDropletGroup adds a controller that reconciles DropletGroup managed
+// resources.
+func SetupDropletGroup(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.DropletGroupResourceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DropletGroup{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DropletGroupResourceGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DropletGroupResourceGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &dropletGroupConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type dropletGroupConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *dropletGroupConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &dropletGroupExternal{Client: client}, nil
+}
+
+type dropletGroupExternal struct {
+	*godo.Client
+}
+
+func (c *dropletGroupExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DropletGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDropletGroup)
+	}
+
+	droplets, err := docompute.ListDropletsByTag(ctx, c.Client, cr.Spec.ForProvider.Tag)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListMembers)
+	}
+	owned, foreign := docompute.GroupMembers(droplets, docompute.DedupeTag(string(cr.GetUID())))
+	members := groupMembers(cr, owned, foreign)
+	cr.Status.AtProvider = docompute.GenerateGroupObservation(members, foreign)
+
+	// A deleted group exists until all of its members are gone.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: len(members) > 0}, nil
+	}
+	if meta.GetExternalName(cr) == "" && len(owned) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if len(members) >= cr.Spec.ForProvider.Count && cr.Status.AtProvider.ActiveCount >= cr.Spec.ForProvider.Count {
+		do.SetAvailableOnObserve(cr)
+	} else {
+		cr.SetConditions(xpv1.Creating())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(members) == cr.Spec.ForProvider.Count,
+	}, nil
+}
+
+// groupMembers returns the Droplets that are managed by the supplied group,
+// sorted by age. Foreign Droplets with the tag of the group are only managed
+// by it if it manages its tag exclusively.
+func groupMembers(cr *v1alpha1.DropletGroup, owned, foreign []godo.Droplet) []godo.Droplet {
+	if !do.BoolValue(cr.Spec.ForProvider.ManageByTagExclusive) {
+		return owned
+	}
+	members := append(append([]godo.Droplet{}, owned...), foreign...)
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members
+}
+
+func (c *dropletGroupExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DropletGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDropletGroup)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	// Groups of more than MaxMultiCreate Droplets are completed by Update.
+	if n := cr.Spec.ForProvider.Count; n > 0 {
+		create := docompute.GenerateGroupMembers(cr.GetName(), 0, n, cr.Spec.ForProvider, docompute.DedupeTag(string(cr.GetUID())))
+		if _, _, err := c.Droplets.CreateMultiple(ctx, create); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errDropletGroupCreateFailed)
+		}
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Tag)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *dropletGroupExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DropletGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDropletGroup)
+	}
+
+	// A group that is scaled down deletes its newest members first.
+	ids, count := cr.Status.AtProvider.MemberIDs, cr.Spec.ForProvider.Count
+	if len(ids) < count {
+		create := docompute.GenerateGroupMembers(cr.GetName(), len(ids), count-len(ids), cr.Spec.ForProvider, docompute.DedupeTag(string(cr.GetUID())))
+		_, _, err := c.Droplets.CreateMultiple(ctx, create)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errScaleUp)
+	}
+	for _, id := range ids[count:] {
+		if response, err := c.Droplets.Delete(ctx, id); do.IgnoreNotFound(err, response) != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errScaleDown, id)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *dropletGroupExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DropletGroup)
+	if !ok {
+		return errors.New(errNotDropletGroup)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// All Droplets with the tag are deleted at once. That would delete the
+	// Droplets the group did not create too, so it is refused unless the
+	// group manages its tag exclusively. Observe reports the group until
+	// all of its members are gone.
+	tag := cr.Spec.ForProvider.Tag
+	if foreign := cr.Status.AtProvider.ForeignIDs; len(foreign) > 0 && !do.BoolValue(cr.Spec.ForProvider.ManageByTagExclusive) {
+		return errors.Errorf(errForeignMembers, tag, foreign)
+	}
+	response, err := c.Droplets.DeleteByTag(ctx, tag)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errDropletGroupDeleteFailed)
+}
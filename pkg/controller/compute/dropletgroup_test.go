@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const testGroupTag = "web"
+
+type dropletGroupModifier func(*v1alpha1.DropletGroup)
+
+func withForeignIDs(ids ...int) dropletGroupModifier {
+	return func(cr *v1alpha1.DropletGroup) { cr.Status.AtProvider.ForeignIDs = ids }
+}
+
+func withManageByTagExclusive() dropletGroupModifier {
+	return func(cr *v1alpha1.DropletGroup) {
+		exclusive := true
+		cr.Spec.ForProvider.ManageByTagExclusive = &exclusive
+	}
+}
+
+func withDeletionTimestamp() dropletGroupModifier {
+	return func(cr *v1alpha1.DropletGroup) {
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+	}
+}
+
+func dropletGroup(m ...dropletGroupModifier) *v1alpha1.DropletGroup {
+	cr := &v1alpha1.DropletGroup{}
+	cr.SetName("example")
+	cr.SetUID(types.UID(testUID))
+	meta.SetExternalName(cr, testGroupTag)
+	cr.Spec.ForProvider.Tag = testGroupTag
+	cr.Spec.ForProvider.Count = 2
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestDropletGroupDelete(t *testing.T) {
+	type want struct {
+		deleted bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.DropletGroup
+		want   want
+	}{
+		"OwnedMembers": {
+			reason: "A group whose tag is only carried by its own members should delete them by tag.",
+			cr:     dropletGroup(),
+			want:   want{deleted: true},
+		},
+		"ForeignMembers": {
+			reason: "A group should refuse to delete Droplets by tag if Droplets it did not create carry its tag.",
+			cr:     dropletGroup(withForeignIDs(5678)),
+			want:   want{err: errors.Errorf(errForeignMembers, testGroupTag, []int{5678})},
+		},
+		"ForeignMembersExclusive": {
+			reason: "A group that manages its tag exclusively should delete all Droplets with its tag.",
+			cr:     dropletGroup(withForeignIDs(5678), withManageByTagExclusive()),
+			want:   want{deleted: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"DELETE /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					if tag := r.URL.Query().Get("tag_name"); tag != testGroupTag {
+						t.Errorf("DELETE /v2/droplets: tag_name = %q, want %q", tag, testGroupTag)
+					}
+					got.deleted = true
+					w.WriteHeader(http.StatusNoContent)
+				},
+			})
+			e := &dropletGroupExternal{Client: fake.NewClient(t, h)}
+
+			got.err = e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropletGroupObserveDeleted(t *testing.T) {
+	owned := godo.Droplet{ID: testDropletID, Tags: []string{testGroupTag, "crossplane:" + testUID}}
+	foreign := godo.Droplet{ID: 5678, Tags: []string{testGroupTag}}
+
+	type want struct {
+		exists  bool
+		members []int
+		foreign []int
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.DropletGroup
+		droplets []godo.Droplet
+		want     want
+	}{
+		"MembersRemain": {
+			reason:   "A deleted group should exist until all of its members are gone.",
+			cr:       dropletGroup(withDeletionTimestamp()),
+			droplets: []godo.Droplet{owned, foreign},
+			want:     want{exists: true, members: []int{testDropletID}, foreign: []int{5678}},
+		},
+		"OnlyForeignRemain": {
+			reason:   "A deleted group should not wait for Droplets it does not manage.",
+			cr:       dropletGroup(withDeletionTimestamp()),
+			droplets: []godo.Droplet{foreign},
+			want:     want{exists: false, foreign: []int{5678}},
+		},
+		"ExclusiveForeignRemain": {
+			reason:   "A deleted group that manages its tag exclusively should exist until all Droplets with its tag are gone.",
+			cr:       dropletGroup(withDeletionTimestamp(), withManageByTagExclusive()),
+			droplets: []godo.Droplet{foreign},
+			want:     want{exists: true, members: []int{5678}, foreign: []int{5678}},
+		},
+		"AllGone": {
+			reason: "A deleted group should not exist once no Droplet carries its tag.",
+			cr:     dropletGroup(withDeletionTimestamp()),
+			want:   want{exists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/droplets": func(w http.ResponseWriter, r *http.Request) {
+					if tag := r.URL.Query().Get("tag_name"); tag != testGroupTag {
+						t.Errorf("GET /v2/droplets: tag_name = %q, want %q", tag, testGroupTag)
+					}
+					fake.Respond(t, map[string]interface{}{"droplets": tc.droplets})(w, r)
+				},
+			})
+			e := &dropletGroupExternal{Client: fake.NewClient(t, h)}
+
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): %v", err)
+			}
+			got := want{exists: o.ResourceExists, members: tc.cr.Status.AtProvider.MemberIDs, foreign: tc.cr.Status.AtProvider.ForeignIDs}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
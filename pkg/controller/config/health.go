@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const (
+	// Error strings.
+	errListProviderConfigs = "cannot list ProviderConfigs"
+	errUpdateStatus        = "cannot update ProviderConfig status"
+	errEmptyToken          = "credentials secret contains an empty token"
+	errReachAPI            = "cannot reach the DigitalOcean API"
+	errUnhealthy           = "credentials of ProviderConfigs cannot reach the DigitalOcean API"
+)
+
+// Messages of the WriteAccess condition.
+const (
+	msgInactiveAccount = "account is not active: "
+	msgReadOnlyToken   = "token is not allowed to create or modify resources"
+	msgProbeFailed     = "cannot probe the scopes of the token: "
+)
+
+// A HealthChecker periodically verifies that the credentials of every
+// ProviderConfig can reach the DigitalOcean API. The result of each check is
+// recorded in the ProviderConfig's status and reported by Check. Credentials
+// of an account that is not active are read-only. Those of an active account
+// are probed for write access, since the API does not report the scopes of a
+// token.
+type HealthChecker struct {
+	kube     client.Client
+	clients  *do.ClientCache
+	interval time.Duration
+	log      logging.Logger
+
+	mu     sync.RWMutex
+	failed map[string]error
+}
+
+// NewHealthChecker returns a HealthChecker that checks all ProviderConfigs
+// every interval.
+func NewHealthChecker(kube client.Client, cc *do.ClientCache, interval time.Duration, l logging.Logger) *HealthChecker {
+	return &HealthChecker{
+		kube:     kube,
+		clients:  cc,
+		interval: interval,
+		log:      l,
+		failed:   map[string]error{},
+	}
+}
+
+// Start checks all ProviderConfigs until the supplied context is done.
+func (h *HealthChecker) Start(ctx context.Context) error {
+	t := time.NewTicker(h.interval)
+	defer t.Stop()
+	for {
+		if err := h.CheckAll(ctx); err != nil {
+			h.log.Info("Cannot check ProviderConfigs", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// CheckAll checks the credentials of all ProviderConfigs once and records the
+// results in their status.
+func (h *HealthChecker) CheckAll(ctx context.Context) error {
+	l := &v1alpha1.ProviderConfigList{}
+	if err := h.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+
+	failed := map[string]error{}
+	for i := range l.Items {
+		pc := &l.Items[i]
+		err := h.check(ctx, pc)
+		if err != nil {
+			failed[pc.GetName()] = err
+		}
+
+		now := metav1.Now()
+		pc.Status.LastCheckTime = &now
+		pc.Status.LastCheckError = ""
+		if err != nil {
+			pc.Status.LastCheckError = err.Error()
+		}
+		if err := h.kube.Status().Update(ctx, pc); err != nil {
+			h.log.Debug(errUpdateStatus, "error", err, "name", pc.GetName())
+		}
+	}
+
+	h.mu.Lock()
+	h.failed = failed
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *HealthChecker) check(ctx context.Context, pc *v1alpha1.ProviderConfig) error {
+	token, err := do.GetProviderConfigToken(ctx, h.kube, pc)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(token) == "" {
+		return errors.New(errEmptyToken)
+	}
+
+	endpoint, err := do.ParseEndpoint(pc.Spec.Endpoint)
+	if err != nil {
+		return err
+	}
+	c := h.clients.GetFor(do.AuthInfo{Token: token, Team: pc.Spec.TeamID, Endpoint: endpoint, ProviderConfig: pc.GetName()})
+	a, _, err := do.GetAccount(ctx, c)
+	if err != nil {
+		return errors.Wrap(err, errReachAPI)
+	}
+	pc.Status.Account = a.Email
+	pc.Status.Team = ""
+	pc.Status.TeamID = ""
+	if a.Team != nil {
+		pc.Status.Team = a.Team.Name
+		pc.Status.TeamID = a.Team.UUID
+	}
+	if err := do.ValidateTeam(pc.Spec.TeamID, pc.Status.TeamID); err != nil {
+		return err
+	}
+
+	if a.Status != do.AccountStatusActive {
+		pc.Status.SetConditions(v1alpha1.ReadOnly(msgInactiveAccount + a.Status))
+		return nil
+	}
+	// The API was reached, so a failed probe leaves the write access unknown
+	// rather than failing the check.
+	write, err := do.ProbeWriteAccess(ctx, c)
+	switch {
+	case err != nil:
+		pc.Status.SetConditions(v1alpha1.WriteAccessUnknown(msgProbeFailed + err.Error()))
+	case !write:
+		pc.Status.SetConditions(v1alpha1.ReadOnly(msgReadOnlyToken))
+	default:
+		pc.Status.SetConditions(v1alpha1.WriteAccessAvailable())
+	}
+	return nil
+}
+
+// Check returns an error if the credentials of any ProviderConfig failed to
+// reach the DigitalOcean API during the last check. It satisfies
+// healthz.Checker.
+func (h *HealthChecker) Check(_ *http.Request) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.failed) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(h.failed))
+	for name := range h.failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return errors.Errorf("%s: %s", errUnhealthy, strings.Join(names, ", "))
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+)
+
+const testToken = "token"
+
+func providerConfig() v1alpha1.ProviderConfig {
+	pc := v1alpha1.ProviderConfig{}
+	pc.SetName("default")
+	pc.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+	pc.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "do-creds", Namespace: "crossplane-system"},
+		Key:             "token",
+	}
+	return pc
+}
+
+// serveAPI returns a handler that responds to account requests with the
+// supplied status code and account, and rejects the tag without a name that
+// probes for write access as invalid. Any other request fails the test; the
+// health check must not modify anything.
+func serveAPI(t *testing.T, status int, account string) http.HandlerFunc {
+	return serveProbe(t, status, account, http.StatusUnprocessableEntity)
+}
+
+// serveProbe returns a handler like serveAPI that responds to the request
+// probing for write access with the supplied probe status code.
+func serveProbe(t *testing.T, status int, account string, probe int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/account":
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(account))
+		case "POST /v2/tags":
+			tag := &godo.TagCreateRequest{}
+			if err := json.NewDecoder(r.Body).Decode(tag); err != nil || tag.Name != "" {
+				t.Errorf("unexpected tag %+v: %v", tag, err)
+			}
+			w.WriteHeader(probe)
+			_, _ = w.Write([]byte(`{"id":"probe","message":"probed"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+const (
+	activeAccount  = `{"account":{"email":"ops@example.com","status":"active","team":{"uuid":"team-uuid","name":"Ops"}}}`
+	lockedAccount  = `{"account":{"email":"ops@example.com","status":"locked"}}`
+	warningAccount = `{"account":{"email":"ops@example.com","status":"warning"}}`
+)
+
+// newTestClientCache returns a ClientCache whose clients for the test token
+// are served by the supplied handler, whether or not they are scoped to a
+// team.
+func newTestClientCache(t *testing.T, h http.HandlerFunc) *do.ClientCache {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	cc := do.NewClientCache(do.ClientOptions{})
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, team := range []string{"", "team-uuid", "other-team-uuid"} {
+		cc.GetFor(do.AuthInfo{Token: testToken, Team: team}).BaseURL = u
+	}
+	return cc
+}
+
+func TestHealthCheckerCheckAll(t *testing.T) {
+	type want struct {
+		lastError bool
+		checked   bool
+		healthy   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		token  string
+		api    http.HandlerFunc
+		want   want
+	}{
+		"Reachable": {
+			reason: "A token that can reach the API should be reported as healthy.",
+			token:  testToken,
+			api:    serveAPI(t, http.StatusOK, activeAccount),
+			want:   want{healthy: true, checked: true},
+		},
+		"Unauthorized": {
+			reason: "A token rejected by the API should be recorded in the status and reported as unhealthy.",
+			token:  testToken,
+			api:    serveAPI(t, http.StatusUnauthorized, `{"id":"unauthorized","message":"Unable to authenticate you"}`),
+			want:   want{lastError: true, checked: true},
+		},
+		"EmptyToken": {
+			reason: "An empty token should be reported as unhealthy without calling the API.",
+			token:  "",
+			api:    serveAPI(t, http.StatusOK, activeAccount),
+			want:   want{lastError: true, checked: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *v1alpha1.ProviderConfig
+			kube := &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*v1alpha1.ProviderConfigList).Items = []v1alpha1.ProviderConfig{providerConfig()}
+					return nil
+				}),
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte(tc.token)}
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(nil, func(obj client.Object) error {
+					got = obj.(*v1alpha1.ProviderConfig)
+					return nil
+				}),
+			}
+
+			h := NewHealthChecker(kube, newTestClientCache(t, tc.api), time.Minute, logging.NewNopLogger())
+			if err := h.CheckAll(context.Background()); err != nil {
+				t.Fatalf("\n%s\nh.CheckAll(...): %v", tc.reason, err)
+			}
+
+			g := want{healthy: h.Check(nil) == nil}
+			if got != nil {
+				g.checked = got.Status.LastCheckTime != nil
+				g.lastError = got.Status.LastCheckError != ""
+			}
+			if diff := cmp.Diff(tc.want, g, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nh.CheckAll(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthCheckerWriteAccess(t *testing.T) {
+	type want struct {
+		account   string
+		team      string
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		api    http.HandlerFunc
+		want   want
+	}{
+		"WriteAccess": {
+			reason: "A token of an active account should have write access.",
+			api:    serveAPI(t, http.StatusOK, activeAccount),
+			want: want{
+				account:   "ops@example.com",
+				team:      "Ops",
+				condition: v1alpha1.WriteAccessAvailable(),
+			},
+		},
+		"ReadOnlyToken": {
+			reason: "A token of an active account that is forbidden to create a tag should be reported as not having write access.",
+			api:    serveProbe(t, http.StatusOK, activeAccount, http.StatusForbidden),
+			want: want{
+				account:   "ops@example.com",
+				team:      "Ops",
+				condition: v1alpha1.ReadOnly(msgReadOnlyToken),
+			},
+		},
+		"WarningAccount": {
+			reason: "A token of an account with a warning should be reported as not having write access.",
+			api:    serveAPI(t, http.StatusOK, warningAccount),
+			want: want{
+				account:   "ops@example.com",
+				condition: v1alpha1.ReadOnly(msgInactiveAccount + "warning"),
+			},
+		},
+		"LockedAccount": {
+			reason: "A token of a locked account should be reported as not having write access.",
+			api:    serveAPI(t, http.StatusOK, lockedAccount),
+			want: want{
+				account:   "ops@example.com",
+				condition: v1alpha1.ReadOnly(msgInactiveAccount + "locked"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := providerConfig()
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte(testToken)}
+					return nil
+				}),
+			}
+
+			h := NewHealthChecker(kube, newTestClientCache(t, tc.api), time.Minute, logging.NewNopLogger())
+			if err := h.check(context.Background(), &pc); err != nil {
+				t.Fatalf("\n%s\nh.check(...): %v", tc.reason, err)
+			}
+
+			got := want{
+				account:   pc.Status.Account,
+				team:      pc.Status.Team,
+				condition: pc.Status.GetCondition(v1alpha1.TypeWriteAccess),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nh.check(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthCheckerProbeFailed(t *testing.T) {
+	pc := providerConfig()
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte(testToken)}
+			return nil
+		}),
+	}
+
+	h := NewHealthChecker(kube, newTestClientCache(t, serveProbe(t, http.StatusOK, activeAccount, http.StatusInternalServerError)), time.Minute, logging.NewNopLogger())
+	if err := h.check(context.Background(), &pc); err != nil {
+		t.Fatalf("h.check(...): want a failed probe not to fail the check, got %v", err)
+	}
+	c := pc.Status.GetCondition(v1alpha1.TypeWriteAccess)
+	if c.Status != corev1.ConditionUnknown || !strings.HasPrefix(c.Message, msgProbeFailed) {
+		t.Errorf("h.check(...): want unknown write access if it cannot be probed, got %+v", c)
+	}
+}
+
+func TestHealthCheckerTeam(t *testing.T) {
+	type want struct {
+		teamID string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		teamID string
+		want   want
+	}{
+		"Unscoped": {
+			reason: "The team of credentials that are not scoped to a team should be recorded.",
+			want:   want{teamID: "team-uuid"},
+		},
+		"SameTeam": {
+			reason: "Credentials that belong to the desired team should be healthy.",
+			teamID: "team-uuid",
+			want:   want{teamID: "team-uuid"},
+		},
+		"OtherTeam": {
+			reason: "Credentials that belong to another team should be reported as unhealthy.",
+			teamID: "other-team-uuid",
+			want: want{
+				teamID: "team-uuid",
+				err:    errors.Errorf("credentials belong to team %q, not to team %q", "team-uuid", "other-team-uuid"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := providerConfig()
+			pc.Spec.TeamID = tc.teamID
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte(testToken)}
+					return nil
+				}),
+			}
+
+			h := NewHealthChecker(kube, newTestClientCache(t, serveAPI(t, http.StatusOK, activeAccount)), time.Minute, logging.NewNopLogger())
+			err := h.check(context.Background(), &pc)
+			if diff := cmp.Diff(tc.want, want{teamID: pc.Status.TeamID, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nh.check(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
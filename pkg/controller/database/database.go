@@ -15,11 +15,15 @@ package database
 
 import (
 	"context"
+	"net"
 	"strconv"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,8 +35,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	k8sv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dodb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
 const (
@@ -44,11 +52,33 @@ const (
 	errDBCreateFailed = "creation of Database Cluster resource has failed"
 	errDBDeleteFailed = "deletion of Database Cluster resource has failed"
 	errDBUpdate       = "cannot update managed Database Cluster resource"
+	errDBResizeFailed = "resize of Database Cluster resource has failed"
+	errDBShrink       = "storage size of Database Cluster cannot be decreased"
+	errListDBs        = "cannot list Database Clusters"
+	errListBackups    = "cannot list backups of Database Cluster to restore from"
+	errRestoreSource  = "cannot find Database Cluster %q to restore from"
+
+	errGetTrustedCluster   = "cannot get Kubernetes cluster to trust"
+	errGetFirewallRules    = "cannot get trusted sources of Database Cluster"
+	errUpdateFirewallRules = "cannot update trusted sources of Database Cluster"
+
+	errGetMigration         = "cannot get online migration into Database Cluster"
+	errGetMigrationPassword = "cannot get password of the database to migrate from"
+	errStartMigration       = "cannot start online migration into Database Cluster"
+	errStopMigration        = "cannot stop online migration into Database Cluster"
+
+	errGetBackups           = "cannot list backups of Database Cluster"
+	errGetConfig            = "cannot get configuration of Database Cluster"
+	errUpdateBackupSchedule = "cannot update backup schedule of Database Cluster"
+
+	errRotatePassword = "cannot rotate password of the default user of Database Cluster"
+
+	dbOutDated = "database cluster is not up to date"
 )
 
 // SetupDatabase adds a controller that reconciles Database managed
 // resources.
-func SetupDatabase(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDatabase(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
 	name := managed.ControllerName(v1alpha1.DBGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -56,23 +86,34 @@ func SetupDatabase(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.DODatabaseCluster{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DBGroupVersionKind),
-			managed.WithExternalConnecter(&dbConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DBGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &dbConnector{kube: mgr.GetClient(), clients: cc}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(do.NewRotationPolicyPublisher(mgr.GetClient(), managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), dbRotationPolicy)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
+// dbRotationPolicy returns the connection rotation policy of a Database Cluster.
+func dbRotationPolicy(mg resource.Managed) *dov1alpha1.ConnectionRotationPolicy {
+	cr, ok := mg.(*v1alpha1.DODatabaseCluster)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.RotationPolicy
+}
+
 type dbConnector struct {
-	kube client.Client
+	kube    client.Client
+	clients *do.ClientCache
 }
 
 func (c *dbConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
+	client := c.clients.GetFor(a)
 	return &dbExternal{Client: client, kube: c.kube}, nil
 }
 
@@ -93,19 +134,34 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		}, nil
 	}
 
-	observed, response, err := c.Databases.Get(ctx, meta.GetExternalName(cr))
+	observed, response, err := dodb.GetDatabase(ctx, c.Client, meta.GetExternalName(cr))
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetDB)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	original := cr.DeepCopy()
 	dodb.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
+	if err := c.resyncTrustedKubernetesCluster(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+		if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errDBUpdate)
 		}
 	}
 
+	// The Kubernetes cluster that was last trusted cannot be observed, so it
+	// is carried over from the previous observation.
+	trusted := cr.Status.AtProvider.TrustedKubernetesClusterID
+	rotated := cr.Status.AtProvider.PasswordRotationTrigger
+	migration, err := c.observeMigration(ctx, cr, observed.ID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	backups, err := c.observeBackups(ctx, *observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	cr.Status.AtProvider = v1alpha1.DODatabaseClusterObservation{
 		ID:                 &observed.ID,
 		Name:               observed.Name,
@@ -115,10 +171,11 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		Size:               observed.SizeSlug,
 		Region:             observed.RegionSlug,
 		Status:             observed.Status,
-		CreatedAt:          observed.CreatedAt.String(),
+		CreatedAt:          do.Timestamp(observed.CreatedAt),
 		PrivateNetworkUUID: observed.PrivateNetworkUUID,
 		Tags:               observed.Tags,
 		DbNames:            observed.DBNames,
+		StorageSizeMiB:     observed.StorageSizeMiB,
 		Connection: v1alpha1.DODatabaseClusterConnection{
 			URI:      &observed.Connection.URI,
 			Database: &observed.Connection.Database,
@@ -143,6 +200,10 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 			Pending:     observed.MaintenanceWindow.Pending,
 			Description: observed.MaintenanceWindow.Description,
 		},
+		TrustedKubernetesClusterID: trusted,
+		Migration:                  migration,
+		Backups:                    backups,
+		PasswordRotationTrigger:    rotated,
 	}
 
 	cr.Status.AtProvider.Users = make([]v1alpha1.DODatabaseClusterUser, len(observed.Users))
@@ -162,12 +223,97 @@ func (c *dbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 
 	setCrossplaneStatus(cr)
 
+	upToDate := dodb.IsUpToDate(cr.Spec.ForProvider, *observed)
+	if desired := do.StringValue(cr.Spec.ForProvider.TrustedKubernetesClusterID); desired != "" || trusted != "" {
+		rules, _, err := c.Databases.GetFirewallRules(ctx, observed.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetFirewallRules)
+		}
+		upToDate = upToDate && dodb.TrustedSourcesUpToDate(rules, trusted, desired)
+	}
+	if observed.Status == v1alpha1.StatusOnline {
+		upToDate = upToDate && !dodb.NeedsMigration(cr.Spec.ForProvider.Migration, migration) &&
+			!(cr.Spec.ForProvider.Migration == nil && dodb.MigrationInProgress(migration)) &&
+			dodb.BackupScheduleUpToDate(cr.Spec.ForProvider, backups) &&
+			!dodb.NeedsPasswordRotation(cr.Spec.ForProvider, cr.Status.AtProvider)
+	}
+	upToDate = upToDate && do.ProjectUpToDate(cr, cr.Spec.ForProvider.ProjectID)
+
+	// The connection details are returned by every observation. Whether an
+	// unchanged connection secret is written again is up to the rotation
+	// policy.
+	if !upToDate {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			Diff:              dbOutDated,
+			ConnectionDetails: connectionDetails(*observed),
+		}, nil
+	}
+
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: connectionDetails(*observed),
 	}, nil
 }
 
+// resyncTrustedKubernetesCluster sets the ID of the Kubernetes cluster to
+// trust to the ID of the referenced cluster. Resolved references are not
+// resolved again, so this keeps the ID in sync if the cluster is recreated.
+func (c *dbExternal) resyncTrustedKubernetesCluster(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	ref := cr.Spec.ForProvider.TrustedKubernetesClusterIDRef
+	if ref == nil {
+		return nil
+	}
+	k8s := &k8sv1alpha1.DOKubernetesCluster{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, k8s); err != nil {
+		return errors.Wrap(err, errGetTrustedCluster)
+	}
+	if id := meta.GetExternalName(k8s); id != "" {
+		cr.Spec.ForProvider.TrustedKubernetesClusterID = &id
+	}
+	return nil
+}
+
+// observeMigration returns the online migration that was last started into
+// the supplied database cluster. Online migrations are only observed once
+// one is desired or was started.
+func (c *dbExternal) observeMigration(ctx context.Context, cr *v1alpha1.DODatabaseCluster, id string) (*v1alpha1.DODatabaseClusterMigrationObservation, error) {
+	previous := cr.Status.AtProvider.Migration
+	if cr.Spec.ForProvider.Migration == nil && previous == nil {
+		return nil, nil
+	}
+	m, response, err := dodb.GetOnlineMigration(ctx, c.Client, id)
+	if err != nil {
+		if do.IgnoreNotFound(err, response) == nil {
+			return previous, nil
+		}
+		return nil, errors.Wrap(err, errGetMigration)
+	}
+	return &v1alpha1.DODatabaseClusterMigrationObservation{ID: m.ID, Status: m.Status, CreatedAt: m.CreatedAt}, nil
+}
+
+// observeBackups returns the backup schedule and retained backups of the
+// supplied database cluster. They are only observed once the cluster is
+// online, and only for engines whose backups can be listed.
+func (c *dbExternal) observeBackups(ctx context.Context, observed dodb.Database) (*v1alpha1.DODatabaseClusterBackupsObservation, error) {
+	if observed.Status != v1alpha1.StatusOnline || !dodb.ListsBackups(observed.EngineSlug) {
+		return nil, nil
+	}
+	backups, _, err := c.Databases.ListBackups(ctx, observed.ID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetBackups)
+	}
+	var config *dodb.DatabaseConfig
+	if dodb.SchedulesBackups(observed.EngineSlug) {
+		if config, _, err = dodb.GetDatabaseConfig(ctx, c.Client, observed.ID); err != nil {
+			return nil, errors.Wrap(err, errGetConfig)
+		}
+	}
+	return dodb.GenerateBackupsObservation(config, backups), nil
+}
+
 func setCrossplaneStatus(cr *v1alpha1.DODatabaseCluster) {
 	switch cr.Status.AtProvider.Status {
 	case v1alpha1.StatusCreating:
@@ -177,7 +323,9 @@ func setCrossplaneStatus(cr *v1alpha1.DODatabaseCluster) {
 	case v1alpha1.StatusMigrating:
 	case v1alpha1.StatusResizing:
 	case v1alpha1.StatusForking:
-		cr.SetConditions(xpv1.Unavailable())
+		// A database cluster is forking while it is restored from a backup
+		// of another cluster.
+		cr.SetConditions(xpv1.Creating())
 	}
 }
 
@@ -189,7 +337,7 @@ func (c *dbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 
 	cr.Status.SetConditions(xpv1.Creating())
 
-	create := &godo.DatabaseCreateRequest{}
+	create := &dodb.DatabaseCreateRequest{}
 
 	name := ""
 	if meta.GetExternalName(cr) != "" {
@@ -202,33 +350,284 @@ func (c *dbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 		return managed.ExternalCreation{}, errors.New(errDBNameRequired)
 	}
 
+	if err := dodb.ValidateEngine(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := dodb.ValidateStorageSize(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := dodb.ValidateBackupSchedule(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if r := cr.Spec.ForProvider.RestoreFrom; r != nil {
+		if err := c.validateRestoreFrom(ctx, *r); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
 	dodb.GenerateDatabase(name, cr.Spec.ForProvider, create)
 
-	db, _, err := c.Databases.Create(ctx, create)
+	db, _, err := dodb.CreateDatabase(ctx, c.Client, create)
 	if err != nil || db == nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errDBCreateFailed)
 	}
 
 	meta.SetExternalName(cr, db.ID)
 
+	// The password of a new cluster is new, so it is not rotated for the
+	// trigger the cluster was created with.
+	cr.Status.AtProvider.PasswordRotationTrigger = do.StringValue(cr.Spec.ForProvider.PasswordRotationTrigger)
+
 	ec := managed.ExternalCreation{}
 
 	if cr.Spec.WriteConnectionSecretToReference != nil {
-		ec.ConnectionDetails = managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretEndpointKey: []byte(db.Connection.URI),
-			"host":                                    []byte(db.Connection.Host),
-			xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(db.Connection.Port)),
-			xpv1.ResourceCredentialsSecretUserKey:     []byte(db.Connection.User),
-			xpv1.ResourceCredentialsSecretPasswordKey: []byte(db.Connection.Password),
+		ec.ConnectionDetails = connectionDetails(*db)
+	}
+
+	return ec, do.AssignProject(ctx, c.Client, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("dbaas", db.ID))
+}
+
+// validateRestoreFrom returns an error if the database cluster to restore
+// from does not exist or the backup to restore is outside its retention
+// window.
+func (c *dbExternal) validateRestoreFrom(ctx context.Context, r v1alpha1.DODatabaseClusterRestoreFrom) error {
+	name, err := dodb.SourceClusterName(r)
+	if err != nil {
+		return err
+	}
+
+	var source *godo.Database
+	opts := &godo.ListOptions{PerPage: 200}
+	for source == nil {
+		dbs, resp, err := c.Databases.List(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, errListDBs)
+		}
+		for i := range dbs {
+			if dbs[i].Name == name {
+				source = &dbs[i]
+				break
+			}
 		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return errors.Wrap(err, errListDBs)
+		}
+		opts.Page = page + 1
+	}
+	if source == nil {
+		return errors.Errorf(errRestoreSource, name)
 	}
 
-	return ec, nil
+	backups, _, err := c.Databases.ListBackups(ctx, source.ID, nil)
+	if err != nil {
+		return errors.Wrap(err, errListBackups)
+	}
+	return dodb.ValidateRestoreFrom(r, backups, time.Now())
+}
+
+// connectionDetails returns the public and, for database clusters in a VPC,
+// the private connection details of the supplied database cluster. Kafka
+// clusters additionally publish their brokers and schema registry, and
+// OpenSearch clusters their dashboard.
+func connectionDetails(db dodb.Database) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if c := db.Connection; c != nil {
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(c.URI)
+		cd["uri"] = []byte(c.URI)
+		cd["host"] = []byte(c.Host)
+		cd[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(c.Port))
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(c.User)
+		cd[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(c.Password)
+		cd["database"] = []byte(c.Database)
+	}
+	if c := db.PrivateConnection; c != nil && db.PrivateNetworkUUID != "" {
+		cd["private_uri"] = []byte(c.URI)
+		cd["private_host"] = []byte(c.Host)
+	}
+
+	switch db.EngineSlug {
+	case dodb.EngineKafka:
+		if c := db.Connection; c != nil {
+			cd["brokers"] = []byte(net.JoinHostPort(c.Host, strconv.Itoa(c.Port)))
+		}
+		if c := db.PrivateConnection; c != nil && db.PrivateNetworkUUID != "" {
+			cd["private_brokers"] = []byte(net.JoinHostPort(c.Host, strconv.Itoa(c.Port)))
+		}
+		if c := db.SchemaRegistryConnection; c != nil {
+			cd["schema_registry_uri"] = []byte(c.URI)
+		}
+	case dodb.EngineOpenSearch:
+		if c := db.UIConnection; c != nil {
+			cd["dashboard_uri"] = []byte(c.URI)
+		}
+	}
+	return cd
 }
 
 func (c *dbExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// We don't allow the updating of databases right now.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.DODatabaseCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDB)
+	}
+
+	// Only the project, the storage size, the trusted Kubernetes cluster and
+	// the backup schedule of a database cluster can be updated right now. The storage
+	// size is only validated if it should be changed.
+	if err := dodb.ValidateBackupSchedule(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	storage := do.Int64Value(cr.Spec.ForProvider.StorageSizeMiB)
+	resize := cr.Spec.ForProvider.StorageSizeMiB != nil && storage != cr.Status.AtProvider.StorageSizeMiB
+	if resize {
+		if err := dodb.ValidateStorageSize(cr.Spec.ForProvider); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if storage < cr.Status.AtProvider.StorageSizeMiB {
+			return managed.ExternalUpdate{}, errors.New(errDBShrink)
+		}
+	}
+
+	if err := do.UpdateProject(ctx, c.Client, c.kube, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("dbaas", meta.GetExternalName(cr))); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.updateTrustedSources(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.updateMigration(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.updateBackupSchedule(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	cd, err := c.rotatePassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	eu := managed.ExternalUpdate{ConnectionDetails: cd}
+	if !resize {
+		return eu, nil
+	}
+
+	_, err = dodb.ResizeDatabase(ctx, c.Client, meta.GetExternalName(cr), &dodb.DatabaseResizeRequest{
+		SizeSlug:       cr.Status.AtProvider.Size,
+		NumNodes:       cr.Status.AtProvider.NumNodes,
+		StorageSizeMiB: storage,
+	})
+	return eu, errors.Wrap(err, errDBResizeFailed)
+}
+
+// rotatePassword rotates the password of the default user of the supplied
+// database cluster once it is online if its password rotation trigger
+// changed, and returns the connection details with the new password. The
+// trigger is recorded once the password was rotated, so each trigger value
+// rotates the password only once.
+func (c *dbExternal) rotatePassword(ctx context.Context, cr *v1alpha1.DODatabaseCluster) (managed.ConnectionDetails, error) {
+	if cr.Status.AtProvider.Status != v1alpha1.StatusOnline || !dodb.NeedsPasswordRotation(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		return nil, nil
+	}
+	observed, _, err := dodb.GetDatabase(ctx, c.Client, meta.GetExternalName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errGetDB)
+	}
+	if observed.Connection == nil {
+		return nil, errors.New(errRotatePassword)
+	}
+	user := observed.Connection.User
+	u, _, err := c.Databases.ResetUserAuth(ctx, observed.ID, user, dodb.GenerateResetUserAuth(*observed, user))
+	if err != nil {
+		return nil, errors.Wrap(err, errRotatePassword)
+	}
+	observed.Connection.Password = u.Password
+	if observed.PrivateConnection != nil {
+		observed.PrivateConnection.Password = u.Password
+	}
+	cr.Status.AtProvider.PasswordRotationTrigger = do.StringValue(cr.Spec.ForProvider.PasswordRotationTrigger)
+	return connectionDetails(*observed), nil
+}
+
+// updateTrustedSources replaces the previously trusted Kubernetes cluster of
+// the supplied database cluster with the desired one.
+func (c *dbExternal) updateTrustedSources(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	desired := do.StringValue(cr.Spec.ForProvider.TrustedKubernetesClusterID)
+	previous := cr.Status.AtProvider.TrustedKubernetesClusterID
+	if desired == "" && previous == "" {
+		return nil
+	}
+	rules, _, err := c.Databases.GetFirewallRules(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, errGetFirewallRules)
+	}
+	if !dodb.TrustedSourcesUpToDate(rules, previous, desired) {
+		update := &godo.DatabaseUpdateFirewallRulesRequest{Rules: dodb.GenerateTrustedSources(rules, previous, desired)}
+		if _, err := c.Databases.UpdateFirewallRules(ctx, meta.GetExternalName(cr), update); err != nil {
+			return errors.Wrap(err, errUpdateFirewallRules)
+		}
+	}
+	cr.Status.AtProvider.TrustedKubernetesClusterID = desired
+	return nil
+}
+
+// updateMigration starts the desired online migration into the supplied
+// database cluster once it is online, and stops an online migration that is
+// in progress but no longer desired.
+func (c *dbExternal) updateMigration(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	m := cr.Spec.ForProvider.Migration
+	observed := cr.Status.AtProvider.Migration
+	switch {
+	case dodb.NeedsMigration(m, observed) && cr.Status.AtProvider.Status == v1alpha1.StatusOnline:
+		if err := dodb.ValidateMigration(*m); err != nil {
+			return err
+		}
+		password, err := c.migrationPassword(ctx, m.PasswordSecretRef)
+		if err != nil {
+			return errors.Wrap(err, errGetMigrationPassword)
+		}
+		started, _, err := dodb.StartOnlineMigration(ctx, c.Client, meta.GetExternalName(cr), dodb.GenerateOnlineMigration(*m, password))
+		if err != nil {
+			return errors.Wrap(err, errStartMigration)
+		}
+		cr.Status.AtProvider.Migration = &v1alpha1.DODatabaseClusterMigrationObservation{ID: started.ID, Status: started.Status, CreatedAt: started.CreatedAt}
+	case m == nil && dodb.MigrationInProgress(observed):
+		return c.stopMigration(ctx, cr)
+	}
+	return nil
+}
+
+// updateBackupSchedule schedules the backups of the supplied database cluster
+// as desired once it is online.
+func (c *dbExternal) updateBackupSchedule(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	p := cr.Spec.ForProvider
+	if cr.Status.AtProvider.Status != v1alpha1.StatusOnline || dodb.BackupScheduleUpToDate(p, cr.Status.AtProvider.Backups) {
+		return nil
+	}
+	_, err := dodb.UpdateDatabaseConfig(ctx, c.Client, meta.GetExternalName(cr), dodb.GenerateBackupSchedule(p))
+	return errors.Wrap(err, errUpdateBackupSchedule)
+}
+
+func (c *dbExternal) migrationPassword(ctx context.Context, ref xpv1.SecretKeySelector) (string, error) {
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return "", err
+	}
+	return string(s.Data[ref.Key]), nil
+}
+
+// stopMigration stops the online migration into the supplied database cluster
+// that is in progress.
+func (c *dbExternal) stopMigration(ctx context.Context, cr *v1alpha1.DODatabaseCluster) error {
+	observed := cr.Status.AtProvider.Migration
+	response, err := dodb.StopOnlineMigration(ctx, c.Client, meta.GetExternalName(cr), observed.ID)
+	if err := do.IgnoreNotFound(err, response); err != nil {
+		return errors.Wrap(err, errStopMigration)
+	}
+	observed.Status = v1alpha1.MigrationStatusCanceled
+	return nil
 }
 
 func (c *dbExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -239,6 +638,12 @@ func (c *dbExternal) Delete(ctx context.Context, mg resource.Managed) error {
 
 	cr.Status.SetConditions(xpv1.Deleting())
 
+	if dodb.MigrationInProgress(cr.Status.AtProvider.Migration) {
+		if err := c.stopMigration(ctx, cr); err != nil {
+			return err
+		}
+	}
+
 	response, err := c.Databases.Delete(ctx, *cr.Status.AtProvider.ID)
 	return errors.Wrap(do.IgnoreNotFound(err, response), errDBDeleteFailed)
 }
@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/database/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dodb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotPool       = "managed resource is not a DatabaseConnectionPool resource"
+	errGetPool       = "cannot get DatabaseConnectionPool"
+	errNoPoolCluster = "spec.forProvider.clusterId is required"
+
+	errPoolCreateFailed = "creation of DatabaseConnectionPool resource has failed"
+	errPoolUpdateFailed = "update of DatabaseConnectionPool resource has failed"
+	errPoolDeleteFailed = "deletion of DatabaseConnectionPool resource has failed"
+)
+
+// SetupDatabaseConnectionPool adds a controller that reconciles
+// DatabaseConnectionPool managed resources.
+func SetupDatabaseConnectionPool(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.DatabaseConnectionPoolGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DatabaseConnectionPool{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DatabaseConnectionPoolGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DatabaseConnectionPoolGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &poolConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(do.NewRotationPolicyPublisher(mgr.GetClient(), managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), poolRotationPolicy)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+// poolRotationPolicy returns the connection rotation policy of a Database Connection Pool.
+func poolRotationPolicy(mg resource.Managed) *dov1alpha1.ConnectionRotationPolicy {
+	cr, ok := mg.(*v1alpha1.DatabaseConnectionPool)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.RotationPolicy
+}
+
+type poolConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *poolConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &poolExternal{Client: client, kube: c.kube}, nil
+}
+
+type poolExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *poolExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DatabaseConnectionPool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPool)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cluster := do.StringValue(cr.Spec.ForProvider.ClusterID)
+	if cluster == "" {
+		return managed.ExternalObservation{}, errors.New(errNoPoolCluster)
+	}
+
+	observed, response, err := c.Databases.GetPool(ctx, cluster, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetPool)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		dodb.LateInitializePool(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+		}
+	}
+
+	cr.Status.AtProvider = dodb.GeneratePoolObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  dodb.PoolIsUpToDate(cr.Spec.ForProvider, *observed),
+		ConnectionDetails: poolConnectionDetails(*observed),
+	}, nil
+}
+
+// poolConnectionDetails returns the connection details of the supplied
+// connection pool. They differ from those of its database cluster in the
+// port, database and user, and carry the name of the pool.
+func poolConnectionDetails(pool godo.DatabasePool) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{"pool": []byte(pool.Name)}
+	if c := pool.Connection; c != nil {
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(c.URI)
+		cd["uri"] = []byte(c.URI)
+		cd["host"] = []byte(c.Host)
+		cd[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(c.Port))
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(c.User)
+		cd[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(c.Password)
+		cd["database"] = []byte(c.Database)
+	}
+	if c := pool.PrivateConnection; c != nil {
+		cd["private_uri"] = []byte(c.URI)
+		cd["private_host"] = []byte(c.Host)
+	}
+	return cd
+}
+
+func (c *poolExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DatabaseConnectionPool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPool)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	cluster := do.StringValue(cr.Spec.ForProvider.ClusterID)
+	if cluster == "" {
+		return managed.ExternalCreation{}, errors.New(errNoPoolCluster)
+	}
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		name = cr.GetName()
+	}
+
+	pool, _, err := c.Databases.CreatePool(ctx, cluster, dodb.GeneratePool(name, cr.Spec.ForProvider))
+	if err != nil || pool == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPoolCreateFailed)
+	}
+
+	meta.SetExternalName(cr, pool.Name)
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    poolConnectionDetails(*pool),
+	}, nil
+}
+
+func (c *poolExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DatabaseConnectionPool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPool)
+	}
+
+	_, err := dodb.UpdatePool(ctx, c.Client, do.StringValue(cr.Spec.ForProvider.ClusterID), meta.GetExternalName(cr), dodb.GeneratePoolUpdate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errPoolUpdateFailed)
+}
+
+func (c *poolExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DatabaseConnectionPool)
+	if !ok {
+		return errors.New(errNotPool)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Databases.DeletePool(ctx, do.StringValue(cr.Spec.ForProvider.ClusterID), meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errPoolDeleteFailed)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deletion bounds how long the DigitalOcean controllers keep retrying
+// to delete an external resource.
+package deletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationForceDelete allows the controller to remove the finalizer of a
+// managed resource once deleting its external resource timed out, leaving
+// the external resource behind. It is only honoured when set to "true".
+const AnnotationForceDelete = "do.crossplane.io/force-delete-after-timeout"
+
+// TypeDeleteTimedOut resources could not be deleted within the delete
+// timeout.
+const TypeDeleteTimedOut xpv1.ConditionType = "DeleteTimedOut"
+
+// ReasonDeleteTimedOut is the reason of the DeleteTimedOut condition.
+const ReasonDeleteTimedOut xpv1.ConditionReason = "DeleteTimedOut"
+
+const errDeleteTimedOut = "cannot delete external resource within %s"
+
+// DeleteTimedOut returns a condition that indicates the external resource
+// could not be deleted within the delete timeout.
+func DeleteTimedOut(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeleteTimedOut,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeleteTimedOut,
+		Message:            err.Error(),
+	}
+}
+
+// Options configure how the controllers delete external resources.
+type Options struct {
+	// Timeout after which a managed resource whose external resource could
+	// not be deleted is marked as DeleteTimedOut. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// NewTimeoutConnecter returns an ExternalConnecter whose external clients
+// report external resources that could not be deleted within the supplied
+// timeout. The finalizer of a managed resource is only removed without
+// deleting its external resource if it is annotated with
+// AnnotationForceDelete.
+func NewTimeoutConnecter(o Options, c managed.ExternalConnecter) managed.ExternalConnecter {
+	if o.Timeout == 0 {
+		return c
+	}
+	return &timeoutConnecter{timeout: o.Timeout, connecter: c}
+}
+
+type timeoutConnecter struct {
+	timeout   time.Duration
+	connecter managed.ExternalConnecter
+}
+
+func (c *timeoutConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutExternal{ExternalClient: e, timeout: c.timeout, now: time.Now}, nil
+}
+
+type timeoutExternal struct {
+	managed.ExternalClient
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// timedOut returns true if the supplied managed resource has been deleted
+// for longer than the timeout.
+func (e *timeoutExternal) timedOut(mg resource.Managed) bool {
+	d := mg.GetDeletionTimestamp()
+	return d != nil && e.now().Sub(d.Time) > e.timeout
+}
+
+func (e *timeoutExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	// Reporting that the external resource no longer exists makes the managed
+	// reconciler remove the finalizer.
+	if e.timedOut(mg) && mg.GetCondition(TypeDeleteTimedOut).Status == corev1.ConditionTrue &&
+		mg.GetAnnotations()[AnnotationForceDelete] == "true" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+func (e *timeoutExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	err := e.ExternalClient.Delete(ctx, mg)
+	if !e.timedOut(mg) {
+		return err
+	}
+	// Some external clients return no error while their deletion waits, e.g.
+	// for a Droplet locked by an action in progress, so the timeout depends on
+	// the deletion timestamp alone.
+	if err == nil {
+		mg.SetConditions(DeleteTimedOut(errors.Errorf(errDeleteTimedOut, e.timeout)))
+		return nil
+	}
+	err = errors.Wrapf(err, errDeleteTimedOut, e.timeout)
+	mg.SetConditions(DeleteTimedOut(err))
+	return err
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+const timeout = 10 * time.Minute
+
+// deleted returns a managed resource that was deleted the supplied duration
+// ago, annotated with the supplied annotations.
+func deleted(ago time.Duration, annotations map[string]string) *fake.Managed {
+	mg := &fake.Managed{}
+	if ago > 0 {
+		d := metav1.NewTime(time.Now().Add(-ago))
+		mg.SetDeletionTimestamp(&d)
+	}
+	mg.SetAnnotations(annotations)
+	return mg
+}
+
+func TestTimeoutExternalDelete(t *testing.T) {
+	type want struct {
+		err      error
+		timedOut corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *fake.Managed
+		err    error
+		want   want
+	}{
+		"DeletedWithinTimeout": {
+			reason: "A successful delete within the timeout should not be reported as timed out.",
+			mg:     deleted(time.Minute, nil),
+			want:   want{timedOut: corev1.ConditionUnknown},
+		},
+		"WaitingPastTimeout": {
+			reason: "A delete that returns no error but is still waiting past the timeout should set the DeleteTimedOut condition.",
+			mg:     deleted(time.Hour, nil),
+			want:   want{timedOut: corev1.ConditionTrue},
+		},
+		"FailedWithinTimeout": {
+			reason: "A failed delete within the timeout should return its error.",
+			mg:     deleted(time.Minute, nil),
+			err:    errBoom,
+			want:   want{err: errBoom, timedOut: corev1.ConditionUnknown},
+		},
+		"FailedPastTimeout": {
+			reason: "A failed delete past the timeout should set the DeleteTimedOut condition.",
+			mg:     deleted(time.Hour, nil),
+			err:    errBoom,
+			want:   want{err: errors.Wrapf(errBoom, errDeleteTimedOut, timeout), timedOut: corev1.ConditionTrue},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &timeoutExternal{
+				ExternalClient: &managed.ExternalClientFns{
+					DeleteFn: func(_ context.Context, _ resource.Managed) error { return tc.err },
+				},
+				timeout: timeout,
+				now:     time.Now,
+			}
+
+			err := e.Delete(context.Background(), tc.mg)
+			got := want{err: err, timedOut: tc.mg.GetCondition(TypeDeleteTimedOut).Status}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTimeoutExternalObserve(t *testing.T) {
+	force := map[string]string{AnnotationForceDelete: "true"}
+
+	cases := map[string]struct {
+		reason   string
+		mg       *fake.Managed
+		timedOut bool
+		want     bool
+	}{
+		"NotDeleted": {
+			reason: "A managed resource that was not deleted should be observed.",
+			mg:     deleted(0, force),
+			want:   true,
+		},
+		"NotForced": {
+			reason:   "A timed out delete should never release the external resource without the annotation.",
+			mg:       deleted(time.Hour, nil),
+			timedOut: true,
+			want:     true,
+		},
+		"ForcedWithinTimeout": {
+			reason: "An annotated managed resource should be observed until its delete times out.",
+			mg:     deleted(time.Minute, force),
+			want:   true,
+		},
+		"ForcedPastTimeout": {
+			reason:   "An annotated managed resource whose delete timed out should be reported as not existing.",
+			mg:       deleted(time.Hour, force),
+			timedOut: true,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.timedOut {
+				tc.mg.SetConditions(DeleteTimedOut(errBoom))
+			}
+			e := &timeoutExternal{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+				timeout: timeout,
+				now:     time.Now,
+			}
+
+			o, err := e.Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, o.ResourceExists); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want exists, +got exists:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -20,25 +20,59 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/account"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/app"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/cdn"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/certificate"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/compute"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/config"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/database"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/dns"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/firewall"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/functions"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/kubernetes"
 	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/loadbalancer"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/project"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/reservedip"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/sshkey"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/storage"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/tag"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/vpc"
 )
 
 // Setup creates all DigitalOcean controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
+// the supplied manager. All controllers share the supplied client cache and
+// delete external resources according to the supplied options.
+func Setup(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	for _, setup := range []func(ctrl.Manager, logging.Logger, *do.ClientCache, deletion.Options) error{
 		config.Setup,
+		account.SetupAccount,
+		app.SetupApp,
+		cdn.SetupCDN,
+		certificate.SetupCertificate,
 		compute.SetupDroplet,
+		compute.SetupDropletGroup,
 		database.SetupDatabase,
+		database.SetupDatabaseConnectionPool,
+		dns.SetupDomain,
+		dns.SetupRecord,
+		firewall.SetupFirewall,
+		functions.SetupFunctionNamespace,
 		kubernetes.SetupKubernetesCluster,
+		kubernetes.SetupKubernetesNodePool,
 		kubernetes.SetupDOContainerRegistry,
+		kubernetes.SetupRegistryGarbageCollection,
 		loadbalancer.SetupLB,
+		project.SetupProject,
+		reservedip.SetupReservedIP,
+		sshkey.SetupSSHKey,
+		storage.SetupVolume,
+		tag.SetupTag,
+		vpc.SetupVPC,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, l, cc, o); err != nil {
 			return err
 		}
 	}
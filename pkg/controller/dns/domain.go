@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/dns/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotDomain = "managed resource is not a Domain resource"
+	errGetDomain = "cannot get Domain"
+
+	errDomainUpdate = "cannot update managed Domain resource"
+
+	errDomainCreateFailed = "creation of Domain resource has failed"
+	errDomainDeleteFailed = "deletion of Domain resource has failed"
+)
+
+// SetupDomain adds a controller that reconciles Domain managed resources.
+func SetupDomain(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.DomainGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Domain{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DomainGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DomainGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &domainConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type domainConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *domainConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &domainExternal{Client: client, kube: c.kube}, nil
+}
+
+type domainExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *domainExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Domain)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDomain)
+	}
+
+	// An existing domain named like the Domain is only imported along with
+	// its records, so that a Domain never takes over a zone by accident.
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		if !do.BoolValue(cr.Spec.ForProvider.AdoptExistingRecords) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		name = cr.GetName()
+	}
+
+	observed, response, err := c.Domains.Get(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetDomain)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		meta.SetExternalName(cr, observed.Name)
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errDomainUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = v1alpha1.DomainObservation{
+		Name: observed.Name,
+		TTL:  observed.TTL,
+	}
+	do.SetAvailableOnObserve(cr)
+
+	// The IP address is only used to create the domain, so a domain is
+	// always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *domainExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Domain)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDomain)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		name = cr.GetName()
+	}
+
+	domain, _, err := c.Domains.Create(ctx, &godo.DomainCreateRequest{
+		Name:      name,
+		IPAddress: do.StringValue(cr.Spec.ForProvider.IPAddress),
+	})
+	if err != nil || domain == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDomainCreateFailed)
+	}
+
+	meta.SetExternalName(cr, domain.Name)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *domainExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Domains cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *domainExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Domain)
+	if !ok {
+		return errors.New(errNotDomain)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// Deleting a domain deletes all of its records.
+	response, err := c.Domains.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errDomainDeleteFailed)
+}
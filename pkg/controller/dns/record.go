@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/dns/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dodns "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/dns"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotRecord = "managed resource is not a Record resource"
+	errGetRecord = "cannot get Record"
+	errNoDomain  = "spec.forProvider.domain is required"
+	errNoID      = "external-name %q is not the ID of a record"
+
+	errListRecords    = "cannot list existing records to adopt"
+	errListDomains    = "cannot list Domains"
+	errAdoptAmbiguous = "cannot adopt existing record: %d %s records are named %q"
+
+	errRecordCreateFailed = "creation of Record resource has failed"
+	errRecordDeleteFailed = "deletion of Record resource has failed"
+	errRecordUpdate       = "cannot update managed Record resource"
+	errRecordUpdateFailed = "update of Record resource has failed"
+
+	recordOutDated = "record is not up to date"
+)
+
+// SetupRecord adds a controller that reconciles Record managed resources.
+func SetupRecord(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.RecordGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Record{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RecordGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.RecordGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &recordConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type recordConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *recordConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &recordExternal{Client: client, kube: c.kube}, nil
+}
+
+type recordExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *recordExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Record)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRecord)
+	}
+
+	// The external-name is not the ID of a record until one is created or
+	// adopted. An existing record with the name and type of the Record is
+	// adopted instead of creating a duplicate if adoption is enabled for the
+	// Record or for its Domain.
+	id, ok := do.ExternalNameAsInt(cr)
+	if !ok {
+		adopted, err := c.adopt(ctx, cr)
+		if err != nil || adopted == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, err
+		}
+		id = adopted
+	}
+	domain := do.StringValue(cr.Spec.ForProvider.Domain)
+	if domain == "" {
+		return managed.ExternalObservation{}, errors.New(errNoDomain)
+	}
+
+	observed, response, err := c.Domains.Record(ctx, domain, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetRecord)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		dodns.LateInitializeRecord(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errRecordUpdate)
+			}
+		}
+	}
+
+	cr.Status.AtProvider = dodns.GenerateRecordObservation(domain, *observed)
+	do.SetAvailableOnObserve(cr)
+
+	if diff := dodns.RecordDiff(cr.Spec.ForProvider, *observed); diff != "" {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             recordOutDated + ":\n" + diff,
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *recordExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Record)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRecord)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	domain := do.StringValue(cr.Spec.ForProvider.Domain)
+	if domain == "" {
+		return managed.ExternalCreation{}, errors.New(errNoDomain)
+	}
+	if err := dodns.ValidateRecord(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	record, _, err := c.Domains.CreateRecord(ctx, domain, dodns.GenerateRecord(cr.Spec.ForProvider))
+	if err != nil || record == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRecordCreateFailed)
+	}
+
+	meta.SetExternalName(cr, strconv.Itoa(record.ID))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *recordExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Record)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRecord)
+	}
+
+	// A TTL below the minimum is rejected before it is sent, so the error
+	// says what is wrong with the spec.
+	if err := dodns.ValidateRecord(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Several records may share the name and type of the Record. Only the
+	// one whose ID is its external-name is owned by it and may be edited.
+	id, ok := do.ExternalNameAsInt(cr)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.Errorf(errNoID, meta.GetExternalName(cr))
+	}
+	domain := do.StringValue(cr.Spec.ForProvider.Domain)
+	observed, _, err := c.Domains.Record(ctx, domain, id)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetRecord)
+	}
+
+	_, _, err = c.Domains.EditRecord(ctx, domain, id, dodns.GenerateRecordUpdate(cr.Spec.ForProvider, *observed))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errRecordUpdateFailed)
+}
+
+func (c *recordExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Record)
+	if !ok {
+		return errors.New(errNotRecord)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	id, _ := do.ExternalNameAsInt(cr)
+	response, err := c.Domains.DeleteRecord(ctx, do.StringValue(cr.Spec.ForProvider.Domain), id)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errRecordDeleteFailed)
+}
+
+// adopt adopts the existing record with the name and type of the supplied
+// Record if adoption is enabled for it. It returns the ID of the adopted
+// record, or 0 if none was adopted.
+func (c *recordExternal) adopt(ctx context.Context, cr *v1alpha1.Record) (int, error) {
+	p := cr.Spec.ForProvider
+	domain := do.StringValue(p.Domain)
+	if domain == "" {
+		return 0, nil
+	}
+	adopt, err := c.shouldAdopt(ctx, cr, domain)
+	if err != nil || !adopt {
+		return 0, err
+	}
+
+	records, _, err := c.Domains.RecordsByTypeAndName(ctx, domain, p.Type, dodns.RecordFQDN(p.Name, domain), nil)
+	if err != nil {
+		return 0, errors.Wrap(err, errListRecords)
+	}
+	matched := dodns.MatchRecords(p, records)
+	switch len(matched) {
+	case 0:
+		return 0, nil
+	case 1:
+	default:
+		return 0, errors.Errorf(errAdoptAmbiguous, len(matched), p.Type, p.Name)
+	}
+
+	meta.SetExternalName(cr, strconv.Itoa(matched[0].ID))
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return 0, errors.Wrap(err, errRecordUpdate)
+	}
+	return matched[0].ID, nil
+}
+
+// shouldAdopt returns true if adoption is enabled for the supplied Record,
+// or if the Domain of the supplied domain name adopts its existing records.
+func (c *recordExternal) shouldAdopt(ctx context.Context, cr *v1alpha1.Record, domain string) (bool, error) {
+	if do.ShouldAdopt(cr) {
+		return true, nil
+	}
+	l := &v1alpha1.DomainList{}
+	if err := c.kube.List(ctx, l); err != nil {
+		return false, errors.Wrap(err, errListDomains)
+	}
+	for i := range l.Items {
+		if meta.GetExternalName(&l.Items[i]) == domain {
+			return do.BoolValue(l.Items[i].Spec.ForProvider.AdoptExistingRecords), nil
+		}
+	}
+	return false, nil
+}
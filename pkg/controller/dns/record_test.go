@@ -0,0 +1,349 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/dns/v1alpha1"
+	dodns "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/dns"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const (
+	testDomain   = "example.com"
+	testRecordID = "3352896"
+	recordPath   = "/v2/domains/" + testDomain + "/records/" + testRecordID
+)
+
+func stringPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+type recordModifier func(*v1alpha1.Record)
+
+func withTTL(ttl int) recordModifier {
+	return func(cr *v1alpha1.Record) { cr.Spec.ForProvider.TTL = &ttl }
+}
+
+func record(m ...recordModifier) *v1alpha1.Record {
+	cr := &v1alpha1.Record{}
+	meta.SetExternalName(cr, testRecordID)
+	cr.Spec.ForProvider = v1alpha1.RecordParameters{
+		Domain: stringPtr(testDomain),
+		Type:   "A",
+		Name:   "www",
+		Data:   "192.0.2.10",
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func observedRecord(ttl int) godo.DomainRecord {
+	return godo.DomainRecord{ID: 3352896, Type: "A", Name: "www", Data: "192.0.2.10", TTL: ttl}
+}
+
+func TestRecordObserve(t *testing.T) {
+	type want struct {
+		upToDate bool
+		ttl      *int
+		patched  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Record
+		want   want
+	}{
+		"LateInitTTL": {
+			reason: "A Record without a TTL should be late initialized with the TTL of its domain, so it does not report a change on every reconcile.",
+			cr:     record(),
+			want:   want{upToDate: true, ttl: intPtr(1800), patched: true},
+		},
+		"ChangedTTL": {
+			reason: "A Record whose TTL is the only change should not be up to date.",
+			cr:     record(withTTL(300)),
+			want:   want{upToDate: false, ttl: intPtr(300)},
+		},
+		"UpToDate": {
+			reason: "A Record whose TTL matches should be up to date and not be patched.",
+			cr:     record(withTTL(1800)),
+			want:   want{upToDate: true, ttl: intPtr(1800)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + recordPath: fake.Respond(t, map[string]interface{}{"domain_record": observedRecord(1800)}),
+			})
+			patched := false
+			e := &recordExternal{
+				Client: fake.NewClient(t, h),
+				kube: &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = true
+					return nil
+				}},
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{upToDate: o.ResourceUpToDate, ttl: tc.cr.Spec.ForProvider.TTL, patched: patched}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordUpdate(t *testing.T) {
+	type want struct {
+		err error
+		ttl int
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Record
+		want   want
+	}{
+		"BelowMinimumTTL": {
+			reason: "A TTL below the minimum should be rejected before the record is edited.",
+			cr:     record(withTTL(10)),
+			want:   want{err: errors.Errorf("spec.forProvider.ttl is 10 seconds, but DigitalOcean requires a TTL of at least 30 seconds")},
+		},
+		"WeightOfARecord": {
+			reason: "A weight should be rejected for a record type that does not support it, rather than be silently dropped.",
+			cr:     record(func(cr *v1alpha1.Record) { cr.Spec.ForProvider.Weight = intPtr(10) }),
+			want:   want{err: errors.Errorf("spec.forProvider.weight is not supported by A records")},
+		},
+		"ChangedTTL": {
+			reason: "A changed TTL should be applied by editing the record.",
+			cr:     record(withTTL(300)),
+			want:   want{ttl: 300},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *godo.DomainRecordEditRequest
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + recordPath: fake.Respond(t, map[string]interface{}{"domain_record": observedRecord(1800)}),
+				"PUT " + recordPath: func(w http.ResponseWriter, r *http.Request) {
+					got = &godo.DomainRecordEditRequest{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					fake.Respond(t, map[string]interface{}{"domain_record": observedRecord(got.TTL)})(w, r)
+				},
+			})
+			e := &recordExternal{Client: fake.NewClient(t, h)}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				if got != nil {
+					t.Errorf("\n%s\ne.Update(...): unexpected edit %+v", tc.reason, got)
+				}
+				return
+			}
+			want := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "192.0.2.10", TTL: tc.want.ttl}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want edit, +got edit:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordObserveAdoptZone(t *testing.T) {
+	// The zone already has these records when it is imported.
+	zone := []godo.DomainRecord{
+		{ID: 3352890, Type: "A", Name: "@", Data: "192.0.2.1", TTL: 1800},
+		{ID: 3352896, Type: "A", Name: "www", Data: "192.0.2.10", TTL: 1800},
+		{ID: 3352897, Type: "CNAME", Name: "www", Data: "example.com.", TTL: 1800},
+		{ID: 3352898, Type: "A", Name: "lb", Data: "192.0.2.20", TTL: 1800},
+		{ID: 3352899, Type: "A", Name: "lb", Data: "192.0.2.21", TTL: 1800},
+		{ID: 3352900, Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 1800},
+	}
+
+	adopting := &v1alpha1.Domain{}
+	meta.SetExternalName(adopting, testDomain)
+	adopting.Spec.ForProvider.AdoptExistingRecords = boolPtr(true)
+
+	type want struct {
+		exists       bool
+		upToDate     bool
+		externalName string
+	}
+
+	cases := map[string]struct {
+		reason string
+		domain *v1alpha1.Domain
+		params v1alpha1.RecordParameters
+		want   want
+	}{
+		"Apex": {
+			reason: "A Record for the apex of an imported zone should adopt the existing record without reporting a change.",
+			domain: adopting,
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "A", Name: "@", Data: "192.0.2.1"},
+			want:   want{exists: true, upToDate: true, externalName: "3352890"},
+		},
+		"NameAndType": {
+			reason: "A Record should adopt the existing record with its name and type, not another type of the same name.",
+			domain: adopting,
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "CNAME", Name: "www", Data: "example.com"},
+			want:   want{exists: true, upToDate: true, externalName: "3352897"},
+		},
+		"RoundRobin": {
+			reason: "A Record should adopt the one of several records with its name and type that has its data.",
+			domain: adopting,
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "A", Name: "lb", Data: "192.0.2.21"},
+			want:   want{exists: true, upToDate: true, externalName: "3352899"},
+		},
+		"ChangedData": {
+			reason: "An adopted record whose data differs should be reported as a change, not duplicated.",
+			domain: adopting,
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "MX", Name: "@", Data: "mx.example.com", Priority: intPtr(10)},
+			want:   want{exists: true, upToDate: false, externalName: "3352900"},
+		},
+		"Missing": {
+			reason: "A Record of an imported zone that has no existing record should be created.",
+			domain: adopting,
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "A", Name: "api", Data: "192.0.2.30"},
+			want:   want{exists: false},
+		},
+		"NotAdopting": {
+			reason: "A Record of a Domain that does not adopt its existing records should be created.",
+			domain: func() *v1alpha1.Domain {
+				d := adopting.DeepCopy()
+				d.Spec.ForProvider.AdoptExistingRecords = nil
+				return d
+			}(),
+			params: v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "A", Name: "www", Data: "192.0.2.10"},
+			want:   want{exists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			routes := map[string]http.HandlerFunc{
+				"GET /v2/domains/" + testDomain + "/records": func(w http.ResponseWriter, r *http.Request) {
+					var found []godo.DomainRecord
+					for _, rec := range zone {
+						if rec.Type == r.URL.Query().Get("type") && dodns.RecordFQDN(rec.Name, testDomain) == r.URL.Query().Get("name") {
+							found = append(found, rec)
+						}
+					}
+					fake.Respond(t, map[string]interface{}{"domain_records": found})(w, r)
+				},
+			}
+			for i := range zone {
+				rec := zone[i]
+				routes["GET /v2/domains/"+testDomain+"/records/"+strconv.Itoa(rec.ID)] = fake.Respond(t, map[string]interface{}{"domain_record": rec})
+			}
+			cr := &v1alpha1.Record{}
+			cr.SetName("record")
+			cr.Spec.ForProvider = tc.params
+			e := &recordExternal{
+				Client: fake.NewClient(t, fake.Routes(t, routes)),
+				kube: &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						obj.(*v1alpha1.DomainList).Items = []v1alpha1.Domain{*tc.domain}
+						return nil
+					},
+					MockUpdate: test.NewMockUpdateFn(nil),
+					MockPatch:  test.NewMockPatchFn(nil),
+				},
+			}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{exists: o.ResourceExists, upToDate: o.ResourceUpToDate, externalName: meta.GetExternalName(cr)}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordUpdateOwnsItsID(t *testing.T) {
+	// Two A records share the name of the Record, which owns the second.
+	sibling := godo.DomainRecord{ID: 3352898, Type: "A", Name: "lb", Data: "192.0.2.20", TTL: 1800}
+	owned := godo.DomainRecord{ID: 3352899, Type: "A", Name: "lb", Data: "192.0.2.21", TTL: 1800}
+	siblingPath := "/v2/domains/" + testDomain + "/records/" + strconv.Itoa(sibling.ID)
+	ownedPath := "/v2/domains/" + testDomain + "/records/" + strconv.Itoa(owned.ID)
+
+	var got *godo.DomainRecordEditRequest
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET " + ownedPath: func(w http.ResponseWriter, r *http.Request) {
+			fake.Respond(t, map[string]interface{}{"domain_record": owned})(w, r)
+		},
+		"PUT " + ownedPath: func(w http.ResponseWriter, r *http.Request) {
+			got = &godo.DomainRecordEditRequest{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Error(err)
+			}
+			owned.Data = got.Data
+			fake.Respond(t, map[string]interface{}{"domain_record": owned})(w, r)
+		},
+		"GET " + siblingPath: func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request for sibling record %s %s", r.Method, r.URL.Path)
+		},
+		"PUT " + siblingPath: func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request for sibling record %s %s", r.Method, r.URL.Path)
+		},
+	})
+
+	cr := &v1alpha1.Record{}
+	meta.SetExternalName(cr, strconv.Itoa(owned.ID))
+	cr.Spec.ForProvider = v1alpha1.RecordParameters{Domain: stringPtr(testDomain), Type: "A", Name: "lb", Data: "192.0.2.22", TTL: intPtr(1800)}
+	e := &recordExternal{Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a Record whose data changed not to be up to date")
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := &godo.DomainRecordEditRequest{Type: "A", Name: "lb", Data: "192.0.2.22", TTL: 1800}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want edit, +got edit:\n%s", diff)
+	}
+}
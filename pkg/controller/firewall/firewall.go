@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"context"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/firewall/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dofw "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/firewall"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotFirewall = "managed resource is not a Firewall resource"
+	errGetFirewall = "cannot get Firewall"
+
+	errFirewallCreateFailed = "creation of Firewall resource has failed"
+	errFirewallUpdateFailed = "update of Firewall resource has failed"
+	errFirewallDeleteFailed = "deletion of Firewall resource has failed"
+
+	firewallOutDated = "firewall is not up to date"
+)
+
+// firewallConditions maps the statuses of a Firewall to conditions. A failed
+// Firewall is unavailable with a message describing its pending changes.
+var firewallConditions = do.StatusConditions{
+	v1alpha1.StatusWaiting:   xpv1.Creating,
+	v1alpha1.StatusSucceeded: xpv1.Available,
+}
+
+// SetupFirewall adds a controller that reconciles Firewall managed
+// resources.
+func SetupFirewall(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.FirewallGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Firewall{}).
+		Complete(&waitingRequeuer{kube: mgr.GetClient(), after: waitingPollInterval, Reconciler: managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.FirewallGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.FirewallGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &firewallConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))})
+}
+
+// waitingPollInterval is how soon a Firewall that is waiting for its rules to
+// be applied is observed again. Rules are usually applied within seconds, so
+// waiting for the regular poll interval would report a Firewall as creating
+// for much longer than it is.
+const waitingPollInterval = 10 * time.Second
+
+// A waitingRequeuer requeues a Firewall that is waiting for its rules to be
+// applied after a short interval rather than the regular poll interval.
+type waitingRequeuer struct {
+	reconcile.Reconciler
+	kube  client.Client
+	after time.Duration
+}
+
+func (r *waitingRequeuer) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(ctx, req)
+	if err != nil || result.Requeue || (result.RequeueAfter > 0 && result.RequeueAfter <= r.after) {
+		return result, err
+	}
+	fw := &v1alpha1.Firewall{}
+	if r.kube.Get(ctx, req.NamespacedName, fw) == nil && fw.Status.AtProvider.Status == v1alpha1.StatusWaiting {
+		result.RequeueAfter = r.after
+	}
+	return result, nil
+}
+
+type firewallConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *firewallConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &firewallExternal{Client: client, kube: c.kube}, nil
+}
+
+type firewallExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *firewallExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Firewall)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotFirewall)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Firewalls.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetFirewall)
+	}
+
+	cr.Status.AtProvider = dofw.GenerateObservation(*observed)
+	if observed.Status == v1alpha1.StatusFailed {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(dofw.PendingChangesMessage(cr.Status.AtProvider.PendingChanges)))
+	} else {
+		firewallConditions.SetCondition(cr, observed.Status)
+	}
+
+	if diff := dofw.Diff(cr.Spec.ForProvider, *observed); diff != "" {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             firewallOutDated + ":\n" + diff,
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *firewallExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Firewall)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotFirewall)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	if err := dofw.ValidateFirewall(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	fw, _, err := c.Firewalls.Create(ctx, dofw.GenerateFirewall(cr.GetName(), cr.Spec.ForProvider))
+	if err != nil || fw == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFirewallCreateFailed)
+	}
+
+	meta.SetExternalName(cr, fw.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *firewallExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Firewall)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotFirewall)
+	}
+
+	if err := dofw.ValidateFirewall(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Updates replace all rules, Droplets and tags of the Firewall, so they
+	// are never applied partially. The name is kept as observed.
+	_, _, err := c.Firewalls.Update(ctx, meta.GetExternalName(cr), dofw.GenerateFirewall(cr.Status.AtProvider.Name, cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errFirewallUpdateFailed)
+}
+
+func (c *firewallExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Firewall)
+	if !ok {
+		return errors.New(errNotFirewall)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Firewalls.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errFirewallDeleteFailed)
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/firewall/v1alpha1"
+	k8sv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	lbv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+	tagv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const firewallID = "bb4b2611-3d72-467b-8602-280330ecd65c"
+
+var anywhere = v1alpha1.FirewallRuleTarget{Addresses: []string{"0.0.0.0/0", "::/0"}}
+
+func firewall() *v1alpha1.Firewall {
+	cr := &v1alpha1.Firewall{}
+	cr.SetName("web")
+	meta.SetExternalName(cr, firewallID)
+	cr.Spec.ForProvider = v1alpha1.FirewallParameters{
+		InboundRules: []v1alpha1.FirewallInboundRule{{Protocol: "tcp", PortRange: "443", Sources: anywhere}},
+		Tags:         []string{"web"},
+	}
+	return cr
+}
+
+func observedFirewall(ports string) godo.Firewall {
+	return godo.Firewall{
+		ID:     firewallID,
+		Name:   "web",
+		Status: "succeeded",
+		InboundRules: []godo.InboundRule{{
+			Protocol:  "tcp",
+			PortRange: ports,
+			Sources:   &godo.Sources{Addresses: []string{"0.0.0.0/0", "::/0"}},
+		}},
+		OutboundRules: []godo.OutboundRule{},
+		Tags:          []string{"web"},
+		Created:       "2021-06-01T04:00:00Z",
+	}
+}
+
+func TestFirewallObserve(t *testing.T) {
+	type want struct {
+		exists   bool
+		upToDate bool
+		at       v1alpha1.FirewallObservation
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed godo.Firewall
+		want     want
+	}{
+		"UpToDate": {
+			reason:   "A Firewall whose rules match the spec should be up to date.",
+			observed: observedFirewall("443"),
+			want: want{
+				exists:   true,
+				upToDate: true,
+				at:       v1alpha1.FirewallObservation{ID: firewallID, Name: "web", Status: "succeeded", CreationTimestamp: "2021-06-01T04:00:00Z"},
+			},
+		},
+		"ChangedRule": {
+			reason:   "A Firewall whose inbound rule allows other ports should not be up to date.",
+			observed: observedFirewall("80"),
+			want: want{
+				exists:   true,
+				upToDate: false,
+				at:       v1alpha1.FirewallObservation{ID: firewallID, Name: "web", Status: "succeeded", CreationTimestamp: "2021-06-01T04:00:00Z"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/firewalls/" + firewallID: fake.Respond(t, map[string]interface{}{"firewall": tc.observed}),
+			})
+			cr := firewall()
+			e := &firewallExternal{Client: fake.NewClient(t, h)}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{exists: o.ResourceExists, upToDate: o.ResourceUpToDate, at: cr.Status.AtProvider}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(xpv1.Available(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFirewallObserveNotFound(t *testing.T) {
+	e := &firewallExternal{Client: fake.NewClient(t, nil)}
+	o, err := e.Observe(context.Background(), firewall())
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: false}, o); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestFirewallObserveStatus(t *testing.T) {
+	// The steps share one Firewall, so each one observes the transition from
+	// the status of the previous step.
+	steps := []struct {
+		reason  string
+		status  string
+		pending []godo.PendingChange
+		want    xpv1.Condition
+	}{
+		{
+			reason: "A Firewall whose rules are not applied yet should be creating.",
+			status: v1alpha1.StatusWaiting,
+			pending: []godo.PendingChange{
+				{DropletID: 8043964, Removing: false, Status: "waiting"},
+			},
+			want: xpv1.Creating(),
+		},
+		{
+			reason: "A Firewall that failed to apply its rules should be unavailable, naming its pending changes.",
+			status: v1alpha1.StatusFailed,
+			pending: []godo.PendingChange{
+				{DropletID: 8043964, Removing: false, Status: "failed"},
+				{DropletID: 8043965, Removing: true, Status: "waiting"},
+			},
+			want: xpv1.Unavailable().WithMessage("firewall failed to apply pending changes: adding Droplet 8043964: failed, removing Droplet 8043965: waiting"),
+		},
+		{
+			reason: "A Firewall whose rules are applied should become available again.",
+			status: v1alpha1.StatusSucceeded,
+			want:   xpv1.Available(),
+		},
+	}
+
+	cr := firewall()
+	for _, step := range steps {
+		t.Run(step.status, func(t *testing.T) {
+			observed := observedFirewall("443")
+			observed.Status = step.status
+			observed.PendingChanges = step.pending
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/firewalls/" + firewallID: fake.Respond(t, map[string]interface{}{"firewall": observed}),
+			})
+			e := &firewallExternal{Client: fake.NewClient(t, h)}
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", step.reason, err)
+			}
+			if diff := cmp.Diff(step.want, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", step.reason, diff)
+			}
+		})
+	}
+}
+
+type reconcilerFn func(ctx context.Context, req reconcile.Request) (reconcile.Result, error)
+
+func (fn reconcilerFn) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return fn(ctx, req)
+}
+
+func TestWaitingRequeuer(t *testing.T) {
+	poll := reconcilerFn(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	})
+
+	cases := map[string]struct {
+		reason string
+		status string
+		want   reconcile.Result
+	}{
+		"Waiting": {
+			reason: "A Firewall that is waiting for its rules to be applied should be requeued soon.",
+			status: v1alpha1.StatusWaiting,
+			want:   reconcile.Result{RequeueAfter: waitingPollInterval},
+		},
+		"Succeeded": {
+			reason: "A Firewall whose rules are applied should be requeued after the regular poll interval.",
+			status: v1alpha1.StatusSucceeded,
+			want:   reconcile.Result{RequeueAfter: time.Minute},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &waitingRequeuer{
+				Reconciler: poll,
+				after:      waitingPollInterval,
+				kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.(*v1alpha1.Firewall).Status.AtProvider.Status = tc.status
+					return nil
+				}},
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFirewallCreate(t *testing.T) {
+	var got *godo.FirewallRequest
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"POST /v2/firewalls": func(w http.ResponseWriter, r *http.Request) {
+			got = &godo.FirewallRequest{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"firewall": observedFirewall("443")})(w, r)
+		},
+	})
+
+	cr := firewall()
+	meta.SetExternalName(cr, "")
+	e := &firewallExternal{Client: fake.NewClient(t, h)}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	want := &godo.FirewallRequest{
+		Name: "web",
+		InboundRules: []godo.InboundRule{{
+			Protocol:  "tcp",
+			PortRange: "443",
+			Sources:   &godo.Sources{Addresses: []string{"0.0.0.0/0", "::/0"}},
+		}},
+		Tags: []string{"web"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Create(...): -want request, +got request:\n%s", diff)
+	}
+	if diff := cmp.Diff(firewallID, meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): -want external-name, +got external-name:\n%s", diff)
+	}
+}
+
+// tags returns a client that serves Tags with the supplied names keyed by
+// the names of their managed resources. A Tag with an empty name was not
+// created yet.
+func tags(names map[string]string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			tag, ok := obj.(*tagv1alpha1.Tag)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			tag.SetName(key.Name)
+			tag.Status.AtProvider.Name = names[key.Name]
+			return nil
+		},
+	}
+}
+
+func TestFirewallTagResolution(t *testing.T) {
+	type want struct {
+		tags []string
+		err  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		refs   []xpv1.Reference
+		names  map[string]string
+		want   want
+	}{
+		"MultipleRefs": {
+			reason: "The names of all referenced Tags should be resolved into the tags of the Firewall.",
+			refs:   []xpv1.Reference{{Name: "web"}, {Name: "team"}},
+			names:  map[string]string{"web": "web", "team": "team:platform"},
+			want:   want{tags: []string{"web", "team:platform"}},
+		},
+		"NotCreated": {
+			reason: "A Firewall should not be applied to tags while a referenced Tag was not created yet.",
+			refs:   []xpv1.Reference{{Name: "web"}, {Name: "team"}},
+			names:  map[string]string{"web": "web"},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := firewall()
+			cr.Spec.ForProvider.Tags = nil
+			cr.Spec.ForProvider.TagRefs = tc.refs
+			err := cr.ResolveReferences(context.Background(), tags(tc.names))
+			got := want{tags: cr.Spec.ForProvider.Tags, err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ncr.ResolveReferences(...): -want, +got:\n%s\nerror: %v", tc.reason, diff, err)
+			}
+		})
+	}
+}
+
+// targets returns a client that serves LBs and Kubernetes clusters with the
+// supplied IDs keyed by the names of their managed resources. A resource
+// without an ID was not created yet.
+func targets(ids map[string]string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch obj.(type) {
+			case *lbv1alpha1.LB, *k8sv1alpha1.DOKubernetesCluster:
+			default:
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			obj.SetName(key.Name)
+			meta.SetExternalName(obj, ids[key.Name])
+			return nil
+		},
+	}
+}
+
+func TestFirewallRuleTargetResolution(t *testing.T) {
+	type want struct {
+		inbound  v1alpha1.FirewallRuleTarget
+		outbound v1alpha1.FirewallRuleTarget
+		err      bool
+	}
+
+	lbRefs := []xpv1.Reference{{Name: "lb"}}
+	k8sRefs := []xpv1.Reference{{Name: "k8s"}}
+
+	cases := map[string]struct {
+		reason string
+		ids    map[string]string
+		want   want
+	}{
+		"Resolved": {
+			reason: "The IDs of the referenced LB and Kubernetes cluster should be resolved into the targets of the rules.",
+			ids:    map[string]string{"lb": "4de7ac8b-495b-4884-9a69-1050c6793cd6", "k8s": "bd5f5959-5e1e-4205-a714-a914373942af"},
+			want: want{
+				inbound:  v1alpha1.FirewallRuleTarget{LoadBalancerUIDs: []string{"4de7ac8b-495b-4884-9a69-1050c6793cd6"}, LoadBalancerUIDRefs: lbRefs},
+				outbound: v1alpha1.FirewallRuleTarget{KubernetesIDs: []string{"bd5f5959-5e1e-4205-a714-a914373942af"}, KubernetesIDRefs: k8sRefs},
+			},
+		},
+		"LBNotCreated": {
+			reason: "Resolution should fail rather than leave out a target while a referenced LB was not created yet.",
+			ids:    map[string]string{"k8s": "bd5f5959-5e1e-4205-a714-a914373942af"},
+			want: want{
+				inbound:  v1alpha1.FirewallRuleTarget{LoadBalancerUIDRefs: lbRefs},
+				outbound: v1alpha1.FirewallRuleTarget{KubernetesIDRefs: k8sRefs},
+				err:      true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := firewall()
+			cr.Spec.ForProvider.InboundRules = []v1alpha1.FirewallInboundRule{
+				{Protocol: "tcp", PortRange: "80", Sources: v1alpha1.FirewallRuleTarget{LoadBalancerUIDRefs: lbRefs}},
+			}
+			cr.Spec.ForProvider.OutboundRules = []v1alpha1.FirewallOutboundRule{
+				{Protocol: "tcp", PortRange: "6443", Destinations: v1alpha1.FirewallRuleTarget{KubernetesIDRefs: k8sRefs}},
+			}
+			err := cr.ResolveReferences(context.Background(), targets(tc.ids))
+			got := want{
+				inbound:  cr.Spec.ForProvider.InboundRules[0].Sources,
+				outbound: cr.Spec.ForProvider.OutboundRules[0].Destinations,
+				err:      err != nil,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ncr.ResolveReferences(...): -want, +got:\n%s\nerror: %v", tc.reason, diff, err)
+			}
+		})
+	}
+}
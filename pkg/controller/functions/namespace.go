@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/functions/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dofunctions "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/functions"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotNamespace = "managed resource is not a FunctionNamespace resource"
+	errGetNamespace = "cannot get Functions namespace"
+
+	errNamespaceCreateFailed = "creation of FunctionNamespace resource has failed"
+	errNamespaceDeleteFailed = "deletion of FunctionNamespace resource has failed"
+	errNamespaceUpdate       = "cannot update managed FunctionNamespace resource"
+)
+
+// SetupFunctionNamespace adds a controller that reconciles FunctionNamespace
+// managed resources.
+func SetupFunctionNamespace(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.FunctionNamespaceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.FunctionNamespace{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.FunctionNamespaceGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.FunctionNamespaceGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &namespaceConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithConnectionPublishers(do.NewRotationPolicyPublisher(mgr.GetClient(), managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), namespaceRotationPolicy)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+// namespaceRotationPolicy returns the connection rotation policy of a Function Namespace.
+func namespaceRotationPolicy(mg resource.Managed) *dov1alpha1.ConnectionRotationPolicy {
+	cr, ok := mg.(*v1alpha1.FunctionNamespace)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.RotationPolicy
+}
+
+type namespaceConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *namespaceConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &namespaceExternal{Client: client, kube: c.kube}, nil
+}
+
+type namespaceExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *namespaceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.FunctionNamespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNamespace)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := dofunctions.GetNamespace(ctx, c.Client, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetNamespace)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		dofunctions.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errNamespaceUpdate)
+			}
+		}
+	}
+
+	cr.Status.AtProvider = dofunctions.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+	cr.SetConditions(do.ImmutableFieldCondition(dofunctions.ImmutableFields(cr.Spec.ForProvider, *observed)))
+
+	// A namespace cannot be changed once it is created, so it is always up
+	// to date.
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: connectionDetails(*observed),
+	}, nil
+}
+
+func (c *namespaceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.FunctionNamespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNamespace)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	if err := dofunctions.ValidateRegion(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	create := &dofunctions.NamespaceCreateRequest{}
+	dofunctions.GenerateNamespace(cr.GetName(), cr.Spec.ForProvider, create)
+
+	ns, _, err := dofunctions.CreateNamespace(ctx, c.Client, create)
+	if err != nil || ns == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errNamespaceCreateFailed)
+	}
+
+	meta.SetExternalName(cr, ns.ID)
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    connectionDetails(*ns),
+	}, nil
+}
+
+// connectionDetails returns the host of the API of the supplied namespace
+// and the credentials deployment tooling authenticates with. The 'auth' key
+// holds the credentials in the form the OpenWhisk CLI expects.
+func connectionDetails(ns dofunctions.Namespace) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		"namespace": []byte(ns.ID),
+	}
+	if ns.APIHost != "" {
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(ns.APIHost)
+		cd["api_host"] = []byte(ns.APIHost)
+	}
+	if ns.UUID != "" && ns.Key != "" {
+		cd["uuid"] = []byte(ns.UUID)
+		cd["key"] = []byte(ns.Key)
+		cd["auth"] = []byte(ns.UUID + ":" + ns.Key)
+	}
+	return cd
+}
+
+func (c *namespaceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Functions namespaces cannot be updated. Changes to their region or
+	// label are reported by the ImmutableFieldChanged condition.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *namespaceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.FunctionNamespace)
+	if !ok {
+		return errors.New(errNotNamespace)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := dofunctions.DeleteNamespace(ctx, c.Client, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errNamespaceDeleteFailed)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/functions/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	dofunctions "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/functions"
+)
+
+const namespaceID = "fn-b0d3f7b6-7b0c-4c1e-8f5d-2b4a6a1f9e21"
+
+func observedNamespace() dofunctions.Namespace {
+	return dofunctions.Namespace{
+		ID:        namespaceID,
+		APIHost:   "https://faas-nyc1-2ef2e6cc.doserverless.co",
+		UUID:      "b0d3f7b6-7b0c-4c1e-8f5d-2b4a6a1f9e21",
+		Key:       "s3cr3t",
+		Label:     "example",
+		Region:    "nyc1",
+		CreatedAt: "2022-09-14T04:16:45Z",
+		UpdatedAt: "2022-09-14T04:16:45Z",
+	}
+}
+
+func namespace(region string) *v1alpha1.FunctionNamespace {
+	cr := &v1alpha1.FunctionNamespace{}
+	cr.SetName("example")
+	cr.Spec.ForProvider.Region = region
+	return cr
+}
+
+func credentials() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte("https://faas-nyc1-2ef2e6cc.doserverless.co"),
+		"api_host":  []byte("https://faas-nyc1-2ef2e6cc.doserverless.co"),
+		"namespace": []byte(namespaceID),
+		"uuid":      []byte("b0d3f7b6-7b0c-4c1e-8f5d-2b4a6a1f9e21"),
+		"key":       []byte("s3cr3t"),
+		"auth":      []byte("b0d3f7b6-7b0c-4c1e-8f5d-2b4a6a1f9e21:s3cr3t"),
+	}
+}
+
+func TestNamespaceCreate(t *testing.T) {
+	type want struct {
+		request      *dofunctions.NamespaceCreateRequest
+		externalName string
+		ec           managed.ExternalCreation
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.FunctionNamespace
+		want   want
+	}{
+		"Created": {
+			reason: "A namespace labelled with the name of the managed resource should be created and its credentials published.",
+			cr:     namespace("nyc1"),
+			want: want{
+				request:      &dofunctions.NamespaceCreateRequest{Region: "nyc1", Label: "example"},
+				externalName: namespaceID,
+				ec:           managed.ExternalCreation{ExternalNameAssigned: true, ConnectionDetails: credentials()},
+			},
+		},
+		"UnsupportedRegion": {
+			reason: "A namespace should not be created in a region that does not support Functions.",
+			cr:     namespace("nyc3"),
+			want: want{
+				err: errors.New("region \"nyc3\" does not support Functions, use one of ams3, blr1, fra1, lon1, nyc1, sfo3, sgp1, syd1, tor1"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *dofunctions.NamespaceCreateRequest
+			h := func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v2/functions/namespaces" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					return
+				}
+				got = &dofunctions.NamespaceCreateRequest{}
+				if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+					t.Error(err)
+				}
+				ns := observedNamespace()
+				if err := json.NewEncoder(w).Encode(map[string]interface{}{"namespace": ns}); err != nil {
+					t.Error(err)
+				}
+			}
+			e := &namespaceExternal{Client: fake.NewClient(t, h)}
+
+			ec, err := e.Create(context.Background(), tc.cr)
+			g := want{request: got, externalName: meta.GetExternalName(tc.cr), ec: ec, err: err}
+			if diff := cmp.Diff(tc.want, g, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceObserve(t *testing.T) {
+	type want struct {
+		o  managed.ExternalObservation
+		at v1alpha1.FunctionNamespaceObservation
+	}
+
+	cases := map[string]struct {
+		reason       string
+		externalName string
+		status       int
+		want         want
+	}{
+		"NotCreated": {
+			reason: "A namespace without an external-name should not exist.",
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Observed": {
+			reason:       "The credentials of an existing namespace should be published.",
+			externalName: namespaceID,
+			status:       http.StatusOK,
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: credentials()},
+				at: v1alpha1.FunctionNamespaceObservation{
+					ID:        namespaceID,
+					UUID:      "b0d3f7b6-7b0c-4c1e-8f5d-2b4a6a1f9e21",
+					APIHost:   "https://faas-nyc1-2ef2e6cc.doserverless.co",
+					Label:     "example",
+					Region:    "nyc1",
+					CreatedAt: "2022-09-14T04:16:45Z",
+					UpdatedAt: "2022-09-14T04:16:45Z",
+				},
+			},
+		},
+		"Deleted": {
+			reason:       "A namespace that no longer exists should not exist.",
+			externalName: namespaceID,
+			status:       http.StatusNotFound,
+			want:         want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/v2/functions/namespaces/"+namespaceID {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					return
+				}
+				w.WriteHeader(tc.status)
+				if tc.status != http.StatusOK {
+					_, _ = w.Write([]byte(`{"id":"not_found","message":"The resource you were accessing could not be found."}`))
+					return
+				}
+				if err := json.NewEncoder(w).Encode(map[string]interface{}{"namespace": observedNamespace()}); err != nil {
+					t.Error(err)
+				}
+			}
+			e := &namespaceExternal{
+				kube:   &test.MockClient{MockPatch: test.NewMockPatchFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			cr := namespace("nyc1")
+			meta.SetExternalName(cr, tc.externalName)
+
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, want{o: o, at: cr.Status.AtProvider}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
 const (
@@ -48,7 +50,7 @@ const (
 
 // SetupDOContainerRegistry adds a controller that reconciles DOContainerRegistry managed
 // resources.
-func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
 	name := managed.ControllerName(v1alpha1.DOContainerRegistryGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -56,7 +58,7 @@ func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.DOContainerRegistry{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DOContainerRegistryGroupVersionKind),
-			managed.WithExternalConnecter(&containerRegistryConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DOContainerRegistryGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &containerRegistryConnector{kube: mgr.GetClient(), clients: cc}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
@@ -65,15 +67,16 @@ func SetupDOContainerRegistry(mgr ctrl.Manager, l logging.Logger) error {
 }
 
 type containerRegistryConnector struct {
-	kube client.Client
+	kube    client.Client
+	clients *do.ClientCache
 }
 
 func (c *containerRegistryConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
+	client := c.clients.GetFor(a)
 	return &containerRegistryExternal{Client: client, kube: c.kube}, nil
 }
 
@@ -100,17 +103,17 @@ func (c *containerRegistryExternal) Observe(ctx context.Context, mg resource.Man
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetContainerRegistry)
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	do.SetAvailableOnObserve(cr)
 
 	subscription, response, err := c.Registry.GetSubscription(ctx)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetContainerRegistrySubscription)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	original := cr.DeepCopy()
 	dok8s.RegistryLateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
+	if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+		if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errContainerRegistryUpdate)
 		}
 	}
@@ -15,10 +15,12 @@ package kubernetes
 
 import (
 	"context"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,24 +32,40 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	dov1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
 const (
 	// Error strings.
-	errNotK8s          = "managed resource is not a DOKubernetesCluster resource"
-	errGetK8s          = "cannot get a DOKubernetesCluster"
-	errK8sNameRequired = "name of DOKubernetesCluster is required"
+	errNotK8s           = "managed resource is not a DOKubernetesCluster resource"
+	errGetK8s           = "cannot get a DOKubernetesCluster"
+	errGetK8sAutoscaler = "cannot get cluster autoscaler configuration of DOKubernetesCluster"
+	errK8sNameRequired  = "name of DOKubernetesCluster is required"
 
 	errK8sCreateFailed = "creation of DOKubernetesCluster resource has failed"
 	errK8sDeleteFailed = "deletion of DOKubernetesCluster resource has failed"
 	errK8sUpdate       = "cannot update managed DOKubernetesCluster resource"
+	errK8sUpdateFailed = "update of DOKubernetesCluster resource has failed"
+	errK8sDisableHA    = "highly available control plane of DOKubernetesCluster cannot be disabled once enabled"
+
+	errK8sNodePoolUpdateFailed = "update of the default node pool of DOKubernetesCluster has failed"
+	errK8sGetKubeconfig        = "cannot get kubeconfig of DOKubernetesCluster"
+	errK8sGetCredentials       = "cannot get credentials of DOKubernetesCluster"
+
+	k8sOutDated = "cluster is not up to date"
 )
 
+// connectionKeyTokenExpiresAt is the connection detail the time the published
+// token expires at is written to, in RFC3339 text format.
+const connectionKeyTokenExpiresAt = "tokenExpiresAt"
+
 // SetupKubernetesCluster adds a controller that reconciles DOKubernetesCluster managed
 // resources.
-func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
 	name := managed.ControllerName(v1alpha1.DOKubernetesClusterKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -55,24 +73,34 @@ func SetupKubernetesCluster(mgr ctrl.Manager, l logging.Logger) error {
 		For(&v1alpha1.DOKubernetesCluster{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DOKubernetesClusterGroupVersionKind),
-			managed.WithExternalConnecter(&k8sConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DOKubernetesClusterGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &k8sConnector{kube: mgr.GetClient(), clients: cc}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithConnectionPublishers(),
+			managed.WithConnectionPublishers(do.NewRotationPolicyPublisher(mgr.GetClient(), managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()), k8sRotationPolicy)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
+// k8sRotationPolicy returns the connection rotation policy of a Kubernetes Cluster.
+func k8sRotationPolicy(mg resource.Managed) *dov1alpha1.ConnectionRotationPolicy {
+	cr, ok := mg.(*v1alpha1.DOKubernetesCluster)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.RotationPolicy
+}
+
 type k8sConnector struct {
-	kube client.Client
+	kube    client.Client
+	clients *do.ClientCache
 }
 
 func (c *k8sConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
+	client := c.clients.GetFor(a)
 	return &k8sExternal{Client: client, kube: c.kube}, nil
 }
 
@@ -98,14 +126,21 @@ func (c *k8sExternal) Observe(ctx context.Context, mg resource.Managed) (managed
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetK8s)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	autoscaler, response, err := dok8s.GetClusterAutoscalerConfiguration(ctx, c.Client, meta.GetExternalName(cr))
+	if do.IgnoreNotFound(err, response) != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetK8sAutoscaler)
+	}
+
+	original := cr.DeepCopy()
 	dok8s.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
+	if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+		if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errK8sUpdate)
 		}
 	}
 
+	kubeconfigExpiresAt := cr.Status.AtProvider.KubeconfigExpiresAt
+	tokenExpiresAt := cr.Status.AtProvider.TokenExpiresAt
 	cr.Status.AtProvider = v1alpha1.DOKubernetesClusterObservation{
 		ID:            observed.ID,
 		Name:          observed.Name,
@@ -129,55 +164,92 @@ func (c *k8sExternal) Observe(ctx context.Context, mg resource.Managed) (managed
 			State:   string(observed.Status.State),
 			Message: observed.Status.Message,
 		},
-		CreatedAt:       observed.CreatedAt.String(),
-		UpdatedAt:       observed.UpdatedAt.String(),
+		CreatedAt:       do.Timestamp(observed.CreatedAt),
+		UpdatedAt:       do.Timestamp(observed.UpdatedAt),
 		SurgeUpgrade:    observed.SurgeUpgrade,
 		HighlyAvailable: observed.HA,
 		RegistryEnabled: observed.RegistryEnabled,
+
+		KubeconfigExpiresAt: kubeconfigExpiresAt,
+		TokenExpiresAt:      tokenExpiresAt,
+
+		ClusterAutoscalerConfiguration: dok8s.GenerateClusterAutoscalerObservation(autoscaler),
 	}
 
 	cr.Status.AtProvider.NodePools = make([]v1alpha1.KubernetesNodePoolObservation, len(observed.NodePools))
 	for i, nodePool := range observed.NodePools {
-		cr.Status.AtProvider.NodePools[i] = v1alpha1.KubernetesNodePoolObservation{
-			ID:        nodePool.ID,
-			Size:      nodePool.Size,
-			Name:      nodePool.Name,
-			Count:     nodePool.Count,
-			Tags:      nodePool.Tags,
-			Labels:    nodePool.Labels,
-			AutoScale: nodePool.AutoScale,
-			MinNodes:  nodePool.MinNodes,
-			MaxNodes:  nodePool.MaxNodes,
-		}
+		cr.Status.AtProvider.NodePools[i] = dok8s.GenerateNodePoolObservation(*nodePool)
+	}
 
-		cr.Status.AtProvider.NodePools[i].Taints = make([]v1alpha1.KubernetesNodePoolTaint, len(nodePool.Taints))
-		for taintIndex, taint := range nodePool.Taints {
-			cr.Status.AtProvider.NodePools[i].Taints[taintIndex] = v1alpha1.KubernetesNodePoolTaint{
-				Key:    taint.Key,
-				Value:  taint.Value,
-				Effect: taint.Effect,
-			}
-		}
+	cr.Status.AtProvider.NodeHealth = dok8s.GenerateNodeHealth(cr.Status.AtProvider.NodePools)
+	if ready, ok := dok8s.ReadyCondition(cr.Status.AtProvider.Status, cr.Status.AtProvider.NodeHealth); ok {
+		cr.SetConditions(ready)
+	}
+
+	cd, err := c.connectionDetails(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
-		cr.Status.AtProvider.NodePools[i].Nodes = make([]v1alpha1.KubernetesNode, len(nodePool.Nodes))
-		for nodeIndex, node := range nodePool.Nodes {
-			cr.Status.AtProvider.NodePools[i].Nodes[nodeIndex] = v1alpha1.KubernetesNode{
-				ID:   node.ID,
-				Name: node.Name,
-				Status: v1alpha1.KubernetesStatus{
-					State:   node.Status.State,
-					Message: node.Status.Message,
-				},
-				DropletID: node.DropletID,
-				CreatedAt: node.CreatedAt.String(),
-				UpdatedAt: node.UpdatedAt.String(),
-			}
+	// Only the default node pool is reconciled here; the remaining pools
+	// are seeded on create and left alone afterwards.
+	if _, pool := dok8s.GenerateDefaultNodePoolUpdate(cr.Spec.ForProvider, cr.Status.AtProvider.NodePools); pool != nil || !dok8s.IsUpToDate(cr.Spec.ForProvider, *observed) ||
+		!dok8s.IsClusterAutoscalerUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider.ClusterAutoscalerConfiguration) {
+		diff := k8sOutDated
+		if d := dok8s.Diff(cr.Spec.ForProvider, *observed, cr.Status.AtProvider); d != "" {
+			diff += ":\n" + d
 		}
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			Diff:              diff,
+			ConnectionDetails: cd,
+		}, nil
 	}
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: cd,
+	}, nil
+}
+
+// connectionDetails returns the kubeconfig and a token of a running cluster if
+// either published last is missing or about to expire. Connection details
+// that are not returned are kept in the connection secret, so the credentials
+// are only requested again when they need to be refreshed.
+func (c *k8sExternal) connectionDetails(ctx context.Context, cr *v1alpha1.DOKubernetesCluster) (managed.ConnectionDetails, error) {
+	now := time.Now()
+	if cr.GetWriteConnectionSecretToReference() == nil ||
+		cr.Status.AtProvider.Status.State != v1alpha1.StatusRunning ||
+		(!dok8s.KubeconfigNeedsRefresh(cr.Spec.ForProvider, cr.Status.AtProvider.KubeconfigExpiresAt, now) &&
+			!dok8s.KubeconfigNeedsRefresh(cr.Spec.ForProvider, cr.Status.AtProvider.TokenExpiresAt, now)) {
+		return nil, nil
+	}
+
+	expiry := dok8s.KubeconfigExpiry(cr.Spec.ForProvider)
+	kc, _, err := c.Kubernetes.GetKubeConfigWithExpiry(ctx, meta.GetExternalName(cr), int64(expiry.Seconds()))
+	if err != nil {
+		return nil, errors.Wrap(err, errK8sGetKubeconfig)
+	}
+	seconds := int(expiry.Seconds())
+	creds, _, err := c.Kubernetes.GetCredentials(ctx, meta.GetExternalName(cr), &godo.KubernetesClusterCredentialsGetRequest{ExpirySeconds: &seconds})
+	if err != nil {
+		return nil, errors.Wrap(err, errK8sGetCredentials)
+	}
+	expiresAt := metav1.NewTime(now.Add(expiry))
+	cr.Status.AtProvider.KubeconfigExpiresAt = &expiresAt
+	tokenExpiresAt := expiresAt
+	if !creds.ExpiresAt.IsZero() {
+		tokenExpiresAt = metav1.NewTime(creds.ExpiresAt)
+	}
+	cr.Status.AtProvider.TokenExpiresAt = &tokenExpiresAt
+
+	return managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretKubeconfigKey: kc.KubeconfigYAML,
+		xpv1.ResourceCredentialsSecretEndpointKey:   []byte(cr.Status.AtProvider.Endpoint),
+		xpv1.ResourceCredentialsSecretTokenKey:      []byte(creds.Token),
+		connectionKeyTokenExpiresAt:                 []byte(tokenExpiresAt.UTC().Format(time.RFC3339)),
 	}, nil
 }
 
@@ -201,6 +273,13 @@ func (c *k8sExternal) Create(ctx context.Context, mg resource.Managed) (managed.
 		return managed.ExternalCreation{}, errors.New(errK8sNameRequired)
 	}
 
+	if err := dok8s.ValidateNodePools(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := dok8s.ValidateClusterAutoscaler(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	dok8s.GenerateKubernetes(name, cr.Spec.ForProvider, create)
 
 	k8s, _, err := c.Kubernetes.Create(ctx, create)
@@ -213,8 +292,38 @@ func (c *k8sExternal) Create(ctx context.Context, mg resource.Managed) (managed.
 }
 
 func (c *k8sExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.DOKubernetesCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotK8s)
+	}
+
+	if cr.Status.AtProvider.HighlyAvailable && !do.BoolValue(cr.Spec.ForProvider.HighlyAvailable) {
+		return managed.ExternalUpdate{}, errors.New(errK8sDisableHA)
+	}
+
+	if err := dok8s.ValidateNodePools(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := dok8s.ValidateClusterAutoscaler(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	update := dok8s.GenerateKubernetesUpdate(cr.Spec.ForProvider, cr.Status.AtProvider)
+	if _, err := dok8s.UpdateKubernetesCluster(ctx, c.Client, meta.GetExternalName(cr), update); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errK8sUpdateFailed)
+	}
+
+	poolID, pool := dok8s.GenerateDefaultNodePoolUpdate(cr.Spec.ForProvider, cr.Status.AtProvider.NodePools)
+	if pool == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	// The default node pool is left alone while a DOKubernetesNodePool
+	// claims it too, so that the two never fight over its scale and tags.
+	if err := checkDefaultNodePoolUnclaimed(ctx, c.kube, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err := c.Kubernetes.UpdateNodePool(ctx, meta.GetExternalName(cr), poolID, pool)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errK8sNodePoolUpdateFailed)
 }
 
 func (c *k8sExternal) Delete(ctx context.Context, mg resource.Managed) error {
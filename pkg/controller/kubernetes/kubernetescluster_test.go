@@ -12,3 +12,728 @@ limitations under the License.
 */
 
 package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+)
+
+const (
+	testClusterID = "cluster-id"
+	clusterPath   = "/v2/kubernetes/clusters/" + testClusterID
+)
+
+type clusterModifier func(*v1alpha1.DOKubernetesCluster)
+
+func withSurgeUpgrade(b bool) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) { cr.Spec.ForProvider.SurgeUpgrade = &b }
+}
+
+func withHighlyAvailable(b bool) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) { cr.Spec.ForProvider.HighlyAvailable = &b }
+}
+
+func withTags(tags ...string) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) {
+		if tags == nil {
+			tags = []string{}
+		}
+		cr.Spec.ForProvider.Tags = &tags
+	}
+}
+
+func withPropagateTags(b bool) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) { cr.Spec.ForProvider.PropagateTags = &b }
+}
+
+func withClusterAutoscaler(threshold float64, unneeded string) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) {
+		cr.Spec.ForProvider.ClusterAutoscalerConfiguration = &v1alpha1.KubernetesClusterAutoscalerConfiguration{
+			ScaleDownUtilizationThreshold: &threshold,
+			ScaleDownUnneededTime:         &unneeded,
+		}
+	}
+}
+
+func withObservedHighlyAvailable(b bool) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) { cr.Status.AtProvider.HighlyAvailable = b }
+}
+
+func withNodePool(p v1alpha1.KubernetesNodePool) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) {
+		cr.Spec.ForProvider.NodePools = append(cr.Spec.ForProvider.NodePools, p)
+	}
+}
+
+func withObservedNodePool(p v1alpha1.KubernetesNodePoolObservation) clusterModifier {
+	return func(cr *v1alpha1.DOKubernetesCluster) {
+		cr.Status.AtProvider.NodePools = append(cr.Status.AtProvider.NodePools, p)
+	}
+}
+
+func cluster(m ...clusterModifier) *v1alpha1.DOKubernetesCluster {
+	cr := &v1alpha1.DOKubernetesCluster{}
+	meta.SetExternalName(cr, testClusterID)
+	cr.Status.AtProvider.Name = "example"
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func observedCluster(surge, ha bool) *godo.KubernetesCluster {
+	return &godo.KubernetesCluster{
+		ID:                testClusterID,
+		Name:              "example",
+		SurgeUpgrade:      surge,
+		HA:                ha,
+		MaintenancePolicy: &godo.KubernetesMaintenancePolicy{},
+		Status:            &godo.KubernetesClusterStatus{},
+	}
+}
+
+func withObservedTags(k *godo.KubernetesCluster, tags ...string) *godo.KubernetesCluster {
+	k.Tags = tags
+	return k
+}
+
+func withPools(k *godo.KubernetesCluster, pools ...*godo.KubernetesNodePool) *godo.KubernetesCluster {
+	k.NodePools = pools
+	return k
+}
+
+// respondCluster responds with the supplied cluster and cluster autoscaler
+// configuration, which godo does not know about.
+func respondCluster(t *testing.T, k *godo.KubernetesCluster, autoscaler *dok8s.ClusterAutoscalerConfiguration) http.HandlerFunc {
+	return fake.Respond(t, map[string]interface{}{"kubernetes_cluster": struct {
+		*godo.KubernetesCluster
+		ClusterAutoscalerConfiguration *dok8s.ClusterAutoscalerConfiguration `json:"cluster_autoscaler_configuration,omitempty"`
+	}{k, autoscaler}})
+}
+
+func TestKubernetesClusterObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       resource.Managed
+		observed *godo.KubernetesCluster
+		want     want
+	}{
+		"UpToDate": {
+			reason:   "A cluster whose surge upgrade and HA settings match should be up to date.",
+			cr:       cluster(withSurgeUpgrade(true), withHighlyAvailable(true)),
+			observed: observedCluster(true, true),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SurgeUpgradeDisabled": {
+			reason:   "Disabling surge upgrade should be reported as drift.",
+			cr:       cluster(withSurgeUpgrade(false)),
+			observed: observedCluster(true, false),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"HighlyAvailableEnabled": {
+			reason:   "Enabling HA on an existing cluster should be reported as drift.",
+			cr:       cluster(withHighlyAvailable(true)),
+			observed: observedCluster(false, false),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"DefaultNodePoolCountChanged": {
+			reason:   "A change to the count of the default node pool should be reported as drift.",
+			cr:       cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 3})),
+			observed: withPools(observedCluster(false, false), &godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 2}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"AutoScaledNodePoolCount": {
+			reason:   "The count of an autoscaled default node pool is owned by the autoscaler and should not be reported as drift.",
+			cr:       cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 1, AutoScale: true, MinNodes: 1, MaxNodes: 5})),
+			observed: withPools(observedCluster(false, false), &godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 4, AutoScale: true, MinNodes: 1, MaxNodes: 5}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"TagsUpToDate": {
+			reason:   "Tags automatically applied by DigitalOcean should not be reported as drift.",
+			cr:       cluster(withTags("team:platform")),
+			observed: withObservedTags(observedCluster(false, false), "k8s", "k8s:"+testClusterID, "team:platform"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"TagsChanged": {
+			reason:   "A change to the cluster tags should be reported as drift.",
+			cr:       cluster(withTags("team:platform", "env:prod")),
+			observed: withObservedTags(observedCluster(false, false), "k8s", "k8s:"+testClusterID, "team:platform"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"TagsRemoved": {
+			reason:   "An empty array of cluster tags should be reported as drift while the cluster still has tags.",
+			cr:       cluster(withTags()),
+			observed: withObservedTags(observedCluster(false, false), "k8s", "k8s:"+testClusterID, "team:platform"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"DefaultNodePoolTagsChanged": {
+			reason:   "A change to the tags of the default node pool should be reported as drift.",
+			cr:       cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 2, Tags: []string{"pool:default"}})),
+			observed: withPools(observedCluster(false, false), &godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 2, Tags: []string{"k8s", "k8s-worker", "k8s:" + testClusterID}}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"PropagatedTagsMissing": {
+			reason:   "Cluster tags missing from the default node pool should be reported as drift when they are propagated.",
+			cr:       cluster(withTags("team:platform"), withPropagateTags(true), withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 2})),
+			observed: withPools(withObservedTags(observedCluster(false, false), "team:platform"), &godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 2}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: k8sOutDated},
+			},
+		},
+		"OtherNodePoolChanged": {
+			reason:   "Node pools other than the default one should not be reconciled.",
+			cr:       cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 2}), withNodePool(v1alpha1.KubernetesNodePool{Name: "other", Count: 1})),
+			observed: withPools(observedCluster(false, false), &godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 2}, &godo.KubernetesNodePool{ID: "other-id", Name: "other", Count: 5}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + clusterPath: respondCluster(t, tc.observed, nil),
+			})
+			e := &k8sExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			// The output of cmp.Diff is deliberately unstable, so only the
+			// summary that precedes it is compared.
+			o.Diff = strings.SplitN(o.Diff, ":\n", 2)[0]
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesClusterKubeconfig(t *testing.T) {
+	expiry := int64(3600)
+	withConnectionSecret := func(cr *v1alpha1.DOKubernetesCluster) {
+		cr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "kubeconfig", Namespace: "default"})
+	}
+	withExpiry := func(cr *v1alpha1.DOKubernetesCluster) { cr.Spec.ForProvider.KubeconfigExpirySeconds = &expiry }
+	withExpiresAt := func(kubeconfig, token time.Duration) clusterModifier {
+		return func(cr *v1alpha1.DOKubernetesCluster) {
+			k := metav1.NewTime(time.Now().Add(kubeconfig))
+			t := metav1.NewTime(time.Now().Add(token))
+			cr.Status.AtProvider.KubeconfigExpiresAt = &k
+			cr.Status.AtProvider.TokenExpiresAt = &t
+		}
+	}
+	tokenExpiresAt := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	kubeconfig := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretKubeconfigKey: []byte("kubeconfig"),
+		xpv1.ResourceCredentialsSecretEndpointKey:   []byte("https://example.k8s.ondigitalocean.com"),
+		xpv1.ResourceCredentialsSecretTokenKey:      []byte("token"),
+		connectionKeyTokenExpiresAt:                 []byte("2021-06-01T12:00:00Z"),
+	}
+
+	type want struct {
+		cd          managed.ConnectionDetails
+		expiry      int64
+		tokenExpiry int
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.DOKubernetesCluster
+		want   want
+	}{
+		"NoConnectionSecret": {
+			reason: "No kubeconfig should be requested if there is no connection secret to publish it to.",
+			cr:     cluster(withExpiry),
+		},
+		"Unpublished": {
+			reason: "A kubeconfig valid for the requested duration should be published if none was published before.",
+			cr:     cluster(withConnectionSecret, withExpiry),
+			want:   want{cd: kubeconfig, expiry: expiry, tokenExpiry: int(expiry)},
+		},
+		"DefaultExpiry": {
+			reason: "A kubeconfig and token valid for the default duration should be requested if no expiry is set.",
+			cr:     cluster(withConnectionSecret),
+			want: want{
+				cd:          kubeconfig,
+				expiry:      int64(dok8s.DefaultKubeconfigExpiry.Seconds()),
+				tokenExpiry: int(dok8s.DefaultKubeconfigExpiry.Seconds()),
+			},
+		},
+		"Fresh": {
+			reason: "A kubeconfig and token that are far from expiring should not be requested again.",
+			cr:     cluster(withConnectionSecret, withExpiry, withExpiresAt(50*time.Minute, 50*time.Minute)),
+		},
+		"NearExpiry": {
+			reason: "A kubeconfig that is about to expire should be requested and published again.",
+			cr:     cluster(withConnectionSecret, withExpiry, withExpiresAt(5*time.Minute, 50*time.Minute)),
+			want:   want{cd: kubeconfig, expiry: expiry, tokenExpiry: int(expiry)},
+		},
+		"TokenNearExpiry": {
+			reason: "A token that is about to expire should be requested and published again.",
+			cr:     cluster(withConnectionSecret, withExpiry, withExpiresAt(50*time.Minute, 5*time.Minute)),
+			want:   want{cd: kubeconfig, expiry: expiry, tokenExpiry: int(expiry)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotExpiry int64
+			var gotTokenExpiry int
+			observed := observedCluster(false, false)
+			observed.Endpoint = "https://example.k8s.ondigitalocean.com"
+			observed.Status.State = v1alpha1.StatusRunning
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + clusterPath: respondCluster(t, observed, nil),
+				"GET " + clusterPath + "/kubeconfig": func(w http.ResponseWriter, r *http.Request) {
+					gotExpiry, _ = strconv.ParseInt(r.URL.Query().Get("expiry_seconds"), 10, 64)
+					_, _ = w.Write([]byte("kubeconfig"))
+				},
+				"GET " + clusterPath + "/credentials": func(w http.ResponseWriter, r *http.Request) {
+					gotTokenExpiry, _ = strconv.Atoi(r.URL.Query().Get("expiry_seconds"))
+					fake.Respond(t, godo.KubernetesClusterCredentials{Token: "token", ExpiresAt: tokenExpiresAt})(w, r)
+				},
+			})
+			e := &k8sExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{cd: o.ConnectionDetails, expiry: gotExpiry, tokenExpiry: gotTokenExpiry}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.cd != nil && tc.cr.Status.AtProvider.KubeconfigExpiresAt == nil {
+				t.Errorf("\n%s\ne.Observe(...): expected the kubeconfig expiry to be recorded", tc.reason)
+			}
+			if tc.want.cd != nil && !tc.cr.Status.AtProvider.TokenExpiresAt.Time.Equal(tokenExpiresAt) {
+				t.Errorf("\n%s\ne.Observe(...): want the token expiry %s to be recorded, got %s", tc.reason, tokenExpiresAt, tc.cr.Status.AtProvider.TokenExpiresAt)
+			}
+		})
+	}
+}
+
+func TestKubernetesClusterScaleToZero(t *testing.T) {
+	zero := v1alpha1.KubernetesNodePool{Name: "batch", AutoScale: true, MinNodes: 0, MaxNodes: 3}
+
+	t.Run("DefaultPoolRejected", func(t *testing.T) {
+		cr := cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", AutoScale: true, MaxNodes: 3}))
+		e := &k8sExternal{Client: fake.NewClient(t, fake.Routes(t, nil))}
+		want := errors.Errorf("the default node pool %q cannot be auto-scaled to zero nodes", "default")
+		_, err := e.Update(context.Background(), cr)
+		if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+			t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	t.Run("OtherPoolAtZero", func(t *testing.T) {
+		cr := cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 2}), withNodePool(zero))
+		observed := withPools(observedCluster(false, false),
+			&godo.KubernetesNodePool{ID: "pool-id", Name: "default", Count: 2},
+			&godo.KubernetesNodePool{ID: "batch-id", Name: "batch", AutoScale: true, MaxNodes: 3})
+		observed.Status.State = v1alpha1.StatusRunning
+		h := fake.Routes(t, map[string]http.HandlerFunc{
+			"GET " + clusterPath: respondCluster(t, observed, nil),
+		})
+		e := &k8sExternal{
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			Client: fake.NewClient(t, h),
+		}
+		o, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("e.Observe(...): %v", err)
+		}
+		if !o.ResourceUpToDate {
+			t.Error("e.Observe(...): a pool auto-scaled to zero should be up to date")
+		}
+		b, err := json.Marshal(cr.Status.AtProvider.NodePools[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), `"count":0`) {
+			t.Errorf("e.Observe(...): want a count of zero in the status, got %s", b)
+		}
+		if c := cr.GetCondition(xpv1.TypeReady); c.Reason == xpv1.ReasonUnavailable {
+			t.Error("e.Observe(...): a pool auto-scaled to zero should not make the cluster unavailable")
+		}
+	})
+}
+
+func TestKubernetesClusterNodeHealth(t *testing.T) {
+	type want struct {
+		health v1alpha1.KubernetesNodeHealth
+		ready  xpv1.Condition
+	}
+
+	node := func(name, dropletID, state string) *godo.KubernetesNode {
+		return &godo.KubernetesNode{Name: name, DropletID: dropletID, Status: &godo.KubernetesNodeStatus{State: state}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		state  string
+		pools  []*godo.KubernetesNodePool
+		want   want
+	}{
+		"AllReady": {
+			reason: "A running cluster whose nodes are all running should be available.",
+			state:  v1alpha1.StatusRunning,
+			pools: []*godo.KubernetesNodePool{
+				{ID: "pool-id", Name: "default", Count: 2, Nodes: []*godo.KubernetesNode{node("default-a", "1", "running"), node("default-b", "2", "running")}},
+			},
+			want: want{
+				health: v1alpha1.KubernetesNodeHealth{Total: 2, Ready: 2, Nodes: []v1alpha1.KubernetesNodeHealthStatus{
+					{Name: "default-a", NodePool: "default", DropletID: "1", State: "running"},
+					{Name: "default-b", NodePool: "default", DropletID: "2", State: "running"},
+				}},
+				ready: xpv1.Available(),
+			},
+		},
+		"Mixed": {
+			reason: "A running cluster with a degraded node should be degraded, while provisioning and draining nodes should not degrade it.",
+			state:  v1alpha1.StatusRunning,
+			pools: []*godo.KubernetesNodePool{
+				{ID: "pool-id", Name: "default", Count: 2, Nodes: []*godo.KubernetesNode{node("default-a", "1", "running"), node("default-b", "2", "draining")}},
+				{ID: "batch-id", Name: "batch", Count: 2, Nodes: []*godo.KubernetesNode{node("batch-a", "3", "provisioning"), node("batch-b", "4", "error")}},
+			},
+			want: want{
+				health: v1alpha1.KubernetesNodeHealth{Total: 4, Ready: 1, Provisioning: 1, Degraded: 1, Nodes: []v1alpha1.KubernetesNodeHealthStatus{
+					{Name: "default-a", NodePool: "default", DropletID: "1", State: "running"},
+					{Name: "default-b", NodePool: "default", DropletID: "2", State: "draining"},
+					{Name: "batch-a", NodePool: "batch", DropletID: "3", State: "provisioning"},
+					{Name: "batch-b", NodePool: "batch", DropletID: "4", State: "error"},
+				}},
+				ready: v1alpha1.Degraded("1 of 4 nodes are degraded: batch-b"),
+			},
+		},
+		"Provisioning": {
+			reason: "A cluster that is being provisioned should be creating.",
+			state:  v1alpha1.StatusProvisioning,
+			pools: []*godo.KubernetesNodePool{
+				{ID: "pool-id", Name: "default", Count: 1, Nodes: []*godo.KubernetesNode{node("default-a", "", "provisioning")}},
+			},
+			want: want{
+				health: v1alpha1.KubernetesNodeHealth{Total: 1, Provisioning: 1, Nodes: []v1alpha1.KubernetesNodeHealthStatus{
+					{Name: "default-a", NodePool: "default", State: "provisioning"},
+				}},
+				ready: xpv1.Creating(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := withPools(observedCluster(false, false), tc.pools...)
+			observed.Status.State = godo.KubernetesClusterStatusState(tc.state)
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + clusterPath: respondCluster(t, observed, nil),
+			})
+			e := &k8sExternal{
+				kube:   &test.MockClient{MockPatch: test.NewMockPatchFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			cr := cluster()
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{health: cr.Status.AtProvider.NodeHealth, ready: cr.GetCondition(xpv1.TypeReady)}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesClusterUpdate(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	count := 3
+	two := 2
+	minNodes := 2
+	maxNodes := 6
+	half := 0.5
+	fiveMinutes := "5m0s"
+
+	type want struct {
+		update *dok8s.KubernetesClusterUpdateRequest
+		pool   *godo.KubernetesNodePoolUpdateRequest
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     resource.Managed
+		pools  []v1alpha1.DOKubernetesNodePool
+		want   want
+	}{
+		"EnableHighlyAvailable": {
+			reason: "HA should be sent to the API when it is enabled on an existing cluster.",
+			cr:     cluster(withHighlyAvailable(true), withObservedHighlyAvailable(false), withSurgeUpgrade(true)),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", SurgeUpgrade: &enabled, HA: &enabled},
+			},
+		},
+		"AlreadyHighlyAvailable": {
+			reason: "HA should not be sent again when it is already enabled.",
+			cr:     cluster(withHighlyAvailable(true), withObservedHighlyAvailable(true), withSurgeUpgrade(false)),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", SurgeUpgrade: &disabled},
+			},
+		},
+		"DisableHighlyAvailable": {
+			reason: "Disabling HA on a highly available cluster should be rejected without calling the API.",
+			cr:     cluster(withHighlyAvailable(false), withObservedHighlyAvailable(true)),
+			want: want{
+				err: errors.New(errK8sDisableHA),
+			},
+		},
+		"ScaleDefaultNodePool": {
+			reason: "A changed count of the default node pool should be sent to the node pool update API.",
+			cr: cluster(
+				withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 3}),
+				withObservedNodePool(v1alpha1.KubernetesNodePoolObservation{ID: "pool-id", Name: "default", Count: 2}),
+			),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example"},
+				pool:   &godo.KubernetesNodePoolUpdateRequest{Name: "default", AutoScale: &disabled, Count: &count},
+			},
+		},
+		"DefaultNodePoolClaimed": {
+			reason: "The default node pool should not be updated while a DOKubernetesNodePool manages it too.",
+			cr: cluster(
+				withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 3}),
+				withObservedNodePool(v1alpha1.KubernetesNodePoolObservation{ID: "pool-id", Name: "default", Count: 2}),
+			),
+			pools: []v1alpha1.DOKubernetesNodePool{
+				nodePool("other", withNodePoolName("default"), withNodePoolCluster("other-cluster-id")),
+				nodePool("claiming", withNodePoolName("default")),
+			},
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example"},
+				err:    errors.Errorf(errDefaultNodePoolClaimed, "default", "claiming"),
+			},
+		},
+		"ClusterTags": {
+			reason: "The cluster tags should be sent to the cluster update API.",
+			cr:     cluster(withTags("team:platform")),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", Tags: &[]string{"team:platform"}},
+			},
+		},
+		"RemoveTags": {
+			reason: "An empty array of cluster tags should be sent to the cluster update API to remove them.",
+			cr:     cluster(withTags()),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", Tags: &[]string{}},
+			},
+		},
+		"PropagateTags": {
+			reason: "The cluster tags should be added to the tags of the default node pool when they are propagated.",
+			cr: cluster(
+				withTags("team:platform"),
+				withPropagateTags(true),
+				withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 2, Tags: []string{"pool:default"}}),
+				withObservedNodePool(v1alpha1.KubernetesNodePoolObservation{ID: "pool-id", Name: "default", Count: 2, Tags: []string{"k8s", "pool:default"}}),
+			),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", Tags: &[]string{"team:platform"}},
+				pool:   &godo.KubernetesNodePoolUpdateRequest{Name: "default", Tags: []string{"pool:default", "team:platform"}, AutoScale: &disabled, Count: &two},
+			},
+		},
+		"ClusterAutoscaler": {
+			reason: "The cluster autoscaler configuration should be sent to the cluster update API with a canonical duration.",
+			cr:     cluster(withClusterAutoscaler(0.5, "5m")),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example", ClusterAutoscalerConfiguration: &dok8s.ClusterAutoscalerConfiguration{
+					ScaleDownUtilizationThreshold: &half,
+					ScaleDownUnneededTime:         &fiveMinutes,
+				}},
+			},
+		},
+		"ClusterAutoscalerThresholdOutOfRange": {
+			reason: "A utilization threshold above 1 should be rejected without calling the API.",
+			cr:     cluster(withClusterAutoscaler(1.5, "5m")),
+			want: want{
+				err: errors.Errorf("scale down utilization threshold %v must be between 0 and 1", 1.5),
+			},
+		},
+		"ClusterAutoscalerNegativeUnneededTime": {
+			reason: "A negative unneeded time should be rejected without calling the API.",
+			cr:     cluster(withClusterAutoscaler(0.5, "-1m")),
+			want: want{
+				err: errors.Errorf("scale down unneeded time %q must be a duration that is not negative", "-1m"),
+			},
+		},
+		"AutoScaleDefaultNodePool": {
+			reason: "Enabling autoscaling on the default node pool should send its bounds but not its count.",
+			cr: cluster(
+				withNodePool(v1alpha1.KubernetesNodePool{Name: "default", Count: 3, AutoScale: true, MinNodes: 2, MaxNodes: 6}),
+				withObservedNodePool(v1alpha1.KubernetesNodePoolObservation{ID: "pool-id", Name: "default", Count: 3}),
+			),
+			want: want{
+				update: &dok8s.KubernetesClusterUpdateRequest{Name: "example"},
+				pool:   &godo.KubernetesNodePoolUpdateRequest{Name: "default", AutoScale: &enabled, MinNodes: &minNodes, MaxNodes: &maxNodes},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *dok8s.KubernetesClusterUpdateRequest
+			var pool *godo.KubernetesNodePoolUpdateRequest
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"PUT " + clusterPath: func(w http.ResponseWriter, r *http.Request) {
+					got = &dok8s.KubernetesClusterUpdateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusAccepted)
+				},
+				"PUT " + clusterPath + "/node_pools/pool-id": func(w http.ResponseWriter, r *http.Request) {
+					pool = &godo.KubernetesNodePoolUpdateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(pool); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"node_pool": godo.KubernetesNodePool{ID: "pool-id"}})(w, r)
+				},
+			})
+			kube := &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				obj.(*v1alpha1.DOKubernetesNodePoolList).Items = tc.pools
+				return nil
+			})}
+			e := &k8sExternal{Client: fake.NewClient(t, h), kube: kube}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.update, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want request, +got request:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pool, pool); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want node pool request, +got node pool request:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesClusterAutoscalerObserve(t *testing.T) {
+	threshold := 0.65
+	unneeded := "1m0s"
+	configured := &dok8s.ClusterAutoscalerConfiguration{ScaleDownUtilizationThreshold: &threshold, ScaleDownUnneededTime: &unneeded}
+
+	type want struct {
+		upToDate bool
+		observed *v1alpha1.KubernetesClusterAutoscalerConfiguration
+	}
+
+	cases := map[string]struct {
+		reason     string
+		cr         *v1alpha1.DOKubernetesCluster
+		autoscaler *dok8s.ClusterAutoscalerConfiguration
+		want       want
+	}{
+		"NotConfigured": {
+			reason:     "A cluster that does not configure its autoscaler should be up to date whatever its configuration.",
+			cr:         cluster(),
+			autoscaler: configured,
+			want: want{
+				upToDate: true,
+				observed: &v1alpha1.KubernetesClusterAutoscalerConfiguration{ScaleDownUtilizationThreshold: &threshold, ScaleDownUnneededTime: &unneeded},
+			},
+		},
+		"UpToDate": {
+			reason:     "A cluster whose autoscaler is configured as desired should be up to date, whatever the form of its durations.",
+			cr:         cluster(withClusterAutoscaler(0.65, "60s")),
+			autoscaler: configured,
+			want: want{
+				upToDate: true,
+				observed: &v1alpha1.KubernetesClusterAutoscalerConfiguration{ScaleDownUtilizationThreshold: &threshold, ScaleDownUnneededTime: &unneeded},
+			},
+		},
+		"Drifted": {
+			reason:     "A cluster whose autoscaler configuration drifted should not be up to date.",
+			cr:         cluster(withClusterAutoscaler(0.5, "1m")),
+			autoscaler: configured,
+			want: want{
+				observed: &v1alpha1.KubernetesClusterAutoscalerConfiguration{ScaleDownUtilizationThreshold: &threshold, ScaleDownUnneededTime: &unneeded},
+			},
+		},
+		"NotReported": {
+			reason: "A cluster whose autoscaler configuration is not reported should not be up to date if it configures one.",
+			cr:     cluster(withClusterAutoscaler(0.5, "1m")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + clusterPath: respondCluster(t, observedCluster(false, false), tc.autoscaler),
+			})
+			e := &k8sExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: fake.NewClient(t, h),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{upToDate: o.ResourceUpToDate, observed: tc.cr.Status.AtProvider.ClusterAutoscalerConfiguration}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotNodePool          = "managed resource is not a DOKubernetesNodePool resource"
+	errGetNodePool          = "cannot get a DOKubernetesNodePool"
+	errNodePoolCreateFailed = "creation of DOKubernetesNodePool resource has failed"
+	errNodePoolUpdateFailed = "update of DOKubernetesNodePool resource has failed"
+	errNodePoolDeleteFailed = "deletion of DOKubernetesNodePool resource has failed"
+	errNodePoolNoCluster    = "cluster of DOKubernetesNodePool is required"
+	errListNodePools        = "cannot list DOKubernetesNodePools"
+	errListClusters         = "cannot list DOKubernetesClusters"
+
+	errNodePoolIsDefault      = "node pool %q is the default node pool of DOKubernetesCluster %q"
+	errDefaultNodePoolClaimed = "default node pool %q is also managed by DOKubernetesNodePool %q"
+)
+
+// SetupKubernetesNodePool adds a controller that reconciles
+// DOKubernetesNodePool managed resources.
+func SetupKubernetesNodePool(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.DOKubernetesNodePoolGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DOKubernetesNodePool{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DOKubernetesNodePoolGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.DOKubernetesNodePoolGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &nodePoolConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type nodePoolConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *nodePoolConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &nodePoolExternal{Client: c.clients.GetFor(a), kube: c.kube}, nil
+}
+
+type nodePoolExternal struct {
+	kube client.Reader
+	*godo.Client
+}
+
+func (c *nodePoolExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DOKubernetesNodePool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNodePool)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Kubernetes.GetNodePool(ctx, do.StringValue(cr.Spec.ForProvider.Cluster), meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetNodePool)
+	}
+
+	cr.Status.AtProvider = dok8s.GenerateNodePoolObservation(*observed)
+	cr.SetConditions(dok8s.NodePoolReadyCondition(cr.Status.AtProvider))
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: dok8s.GenerateNodePoolUpdate(cr.Spec.ForProvider, cr.Status.AtProvider) == nil,
+	}, nil
+}
+
+func (c *nodePoolExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DOKubernetesNodePool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNodePool)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	cluster := do.StringValue(cr.Spec.ForProvider.Cluster)
+	if cluster == "" {
+		return managed.ExternalCreation{}, errors.New(errNodePoolNoCluster)
+	}
+	if err := checkNotDefaultNodePool(ctx, c.kube, cluster, cr.Spec.ForProvider.Name); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	pool, _, err := c.Kubernetes.CreateNodePool(ctx, cluster, dok8s.GenerateNodePool(cr.Spec.ForProvider))
+	if err != nil || pool == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errNodePoolCreateFailed)
+	}
+
+	meta.SetExternalName(cr, pool.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *nodePoolExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DOKubernetesNodePool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNodePool)
+	}
+
+	update := dok8s.GenerateNodePoolUpdate(cr.Spec.ForProvider, cr.Status.AtProvider)
+	if update == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	cluster := do.StringValue(cr.Spec.ForProvider.Cluster)
+	if err := checkNotDefaultNodePool(ctx, c.kube, cluster, cr.Status.AtProvider.Name); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	_, _, err := c.Kubernetes.UpdateNodePool(ctx, cluster, meta.GetExternalName(cr), update)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errNodePoolUpdateFailed)
+}
+
+func (c *nodePoolExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DOKubernetesNodePool)
+	if !ok {
+		return errors.New(errNotNodePool)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Kubernetes.DeleteNodePool(ctx, do.StringValue(cr.Spec.ForProvider.Cluster), meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errNodePoolDeleteFailed)
+}
+
+// checkNotDefaultNodePool returns an error if the node pool with the supplied
+// name of the cluster with the supplied ID is the default node pool of a
+// DOKubernetesCluster, which reconciles it.
+func checkNotDefaultNodePool(ctx context.Context, kube client.Reader, cluster, pool string) error {
+	l := &v1alpha1.DOKubernetesClusterList{}
+	if err := kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListClusters)
+	}
+	for i := range l.Items {
+		k := &l.Items[i]
+		if meta.GetExternalName(k) == cluster && dok8s.DefaultNodePoolName(k.Spec.ForProvider) == pool {
+			return errors.Errorf(errNodePoolIsDefault, pool, k.GetName())
+		}
+	}
+	return nil
+}
+
+// checkDefaultNodePoolUnclaimed returns an error if the default node pool of
+// the supplied DOKubernetesCluster is also managed by a DOKubernetesNodePool.
+func checkDefaultNodePoolUnclaimed(ctx context.Context, kube client.Reader, cr *v1alpha1.DOKubernetesCluster) error {
+	l := &v1alpha1.DOKubernetesNodePoolList{}
+	if err := kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListNodePools)
+	}
+	pool := dok8s.DefaultNodePoolName(cr.Spec.ForProvider)
+	for _, np := range l.Items {
+		if do.StringValue(np.Spec.ForProvider.Cluster) == meta.GetExternalName(cr) && np.Spec.ForProvider.Name == pool {
+			return errors.Errorf(errDefaultNodePoolClaimed, pool, np.GetName())
+		}
+	}
+	return nil
+}
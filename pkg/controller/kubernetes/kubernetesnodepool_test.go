@@ -0,0 +1,269 @@
+/*
+Copyright 2020 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const (
+	testNodePoolID = "pool-id"
+	nodePoolsPath  = clusterPath + "/node_pools"
+)
+
+type nodePoolModifier func(*v1alpha1.DOKubernetesNodePool)
+
+func withNodePoolName(name string) nodePoolModifier {
+	return func(cr *v1alpha1.DOKubernetesNodePool) { cr.Spec.ForProvider.Name = name }
+}
+
+func withNodePoolCluster(id string) nodePoolModifier {
+	return func(cr *v1alpha1.DOKubernetesNodePool) { cr.Spec.ForProvider.Cluster = &id }
+}
+
+func withNodePoolCount(n int) nodePoolModifier {
+	return func(cr *v1alpha1.DOKubernetesNodePool) { cr.Spec.ForProvider.Count = n }
+}
+
+func withNodePoolID(id string) nodePoolModifier {
+	return func(cr *v1alpha1.DOKubernetesNodePool) { meta.SetExternalName(cr, id) }
+}
+
+func nodePool(name string, m ...nodePoolModifier) v1alpha1.DOKubernetesNodePool {
+	cluster := testClusterID
+	cr := v1alpha1.DOKubernetesNodePool{}
+	cr.SetName(name)
+	cr.Spec.ForProvider.Cluster = &cluster
+	cr.Spec.ForProvider.Name = "workers"
+	cr.Spec.ForProvider.Size = "s-2vcpu-4gb"
+	cr.Spec.ForProvider.Count = 2
+	for _, f := range m {
+		f(&cr)
+	}
+	return cr
+}
+
+// clusters returns a client that lists DOKubernetesClusters whose default
+// node pools have the supplied names.
+func clusters(defaultPools ...string) *test.MockClient {
+	return &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		l := obj.(*v1alpha1.DOKubernetesClusterList)
+		for _, pool := range defaultPools {
+			cr := cluster(withNodePool(v1alpha1.KubernetesNodePool{Name: pool, Count: 1}))
+			cr.SetName("example")
+			l.Items = append(l.Items, *cr)
+		}
+		return nil
+	})}
+}
+
+func observedNodePool(count int, states ...string) *godo.KubernetesNodePool {
+	p := &godo.KubernetesNodePool{ID: testNodePoolID, Name: "workers", Size: "s-2vcpu-4gb", Count: count}
+	for _, s := range states {
+		p.Nodes = append(p.Nodes, &godo.KubernetesNode{Name: "node", Status: &godo.KubernetesNodeStatus{State: s}})
+	}
+	return p
+}
+
+func TestKubernetesNodePoolObserve(t *testing.T) {
+	type want struct {
+		o     managed.ExternalObservation
+		ready xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       v1alpha1.DOKubernetesNodePool
+		observed *godo.KubernetesNodePool
+		want     want
+	}{
+		"NotCreated": {
+			reason: "A node pool without an external name should not exist.",
+			cr:     nodePool("pool"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NotFound": {
+			reason: "A node pool that was deleted should not exist.",
+			cr:     nodePool("pool", withNodePoolID(testNodePoolID)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Running": {
+			reason:   "A node pool whose nodes are running should be available and up to date.",
+			cr:       nodePool("pool", withNodePoolID(testNodePoolID)),
+			observed: observedNodePool(2, v1alpha1.NodeStateRunning, v1alpha1.NodeStateRunning),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: xpv1.Available(),
+			},
+		},
+		"Provisioning": {
+			reason:   "A node pool none of whose nodes are running yet should be creating.",
+			cr:       nodePool("pool", withNodePoolID(testNodePoolID)),
+			observed: observedNodePool(2, v1alpha1.NodeStateProvisioning, v1alpha1.NodeStateProvisioning),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: xpv1.Creating(),
+			},
+		},
+		"Scaled": {
+			reason:   "A node pool whose count changed should not be up to date.",
+			cr:       nodePool("pool", withNodePoolID(testNodePoolID), withNodePoolCount(3)),
+			observed: observedNodePool(2, v1alpha1.NodeStateRunning, v1alpha1.NodeStateRunning),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				ready: xpv1.Available(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			get := http.NotFound
+			if tc.observed != nil {
+				get = fake.Respond(t, map[string]interface{}{"node_pool": tc.observed})
+			}
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET " + nodePoolsPath + "/" + testNodePoolID: get,
+			})
+			e := &nodePoolExternal{Client: fake.NewClient(t, h)}
+			o, err := e.Observe(context.Background(), &tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{o: o, ready: tc.cr.GetCondition(xpv1.TypeReady)}
+			if tc.observed == nil {
+				got.ready = tc.want.ready
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesNodePoolCreate(t *testing.T) {
+	type want struct {
+		create *godo.KubernetesNodePoolCreateRequest
+		id     string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   *test.MockClient
+		want   want
+	}{
+		"Create": {
+			reason: "A node pool that is not the default pool of a DOKubernetesCluster should be added to its cluster.",
+			kube:   clusters("default"),
+			want: want{
+				create: &godo.KubernetesNodePoolCreateRequest{Name: "workers", Size: "s-2vcpu-4gb", Count: 2},
+				id:     testNodePoolID,
+			},
+		},
+		"DefaultNodePool": {
+			reason: "A node pool that is the default pool of a DOKubernetesCluster should be rejected without calling the API.",
+			kube:   clusters("workers"),
+			want:   want{err: errors.Errorf(errNodePoolIsDefault, "workers", "example")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *godo.KubernetesNodePoolCreateRequest
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST " + nodePoolsPath: func(w http.ResponseWriter, r *http.Request) {
+					got = &godo.KubernetesNodePoolCreateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"node_pool": godo.KubernetesNodePool{ID: testNodePoolID}})(w, r)
+				},
+			})
+			cr := nodePool("pool")
+			e := &nodePoolExternal{Client: fake.NewClient(t, h), kube: tc.kube}
+			_, err := e.Create(context.Background(), &cr)
+			if diff := cmp.Diff(tc.want, want{create: got, id: meta.GetExternalName(&cr), err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesNodePoolUpdate(t *testing.T) {
+	three := 3
+	disabled := false
+
+	type want struct {
+		update *godo.KubernetesNodePoolUpdateRequest
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   *test.MockClient
+		want   want
+	}{
+		"Scale": {
+			reason: "A changed count should be sent to the node pool update API.",
+			kube:   clusters("default"),
+			want:   want{update: &godo.KubernetesNodePoolUpdateRequest{Name: "workers", AutoScale: &disabled, Count: &three}},
+		},
+		"DefaultNodePool": {
+			reason: "The default node pool of a DOKubernetesCluster should not be updated.",
+			kube:   clusters("workers"),
+			want:   want{err: errors.Errorf(errNodePoolIsDefault, "workers", "example")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *godo.KubernetesNodePoolUpdateRequest
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"PUT " + nodePoolsPath + "/" + testNodePoolID: func(w http.ResponseWriter, r *http.Request) {
+					got = &godo.KubernetesNodePoolUpdateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusAccepted)
+					fake.Respond(t, map[string]interface{}{"node_pool": godo.KubernetesNodePool{ID: testNodePoolID}})(w, r)
+				},
+			})
+			cr := nodePool("pool", withNodePoolID(testNodePoolID), withNodePoolCount(3))
+			cr.Status.AtProvider = v1alpha1.KubernetesNodePoolObservation{ID: testNodePoolID, Name: "workers", Count: 2}
+			e := &nodePoolExternal{Client: fake.NewClient(t, h), kube: tc.kube}
+			_, err := e.Update(context.Background(), &cr)
+			if diff := cmp.Diff(tc.want, want{update: got, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
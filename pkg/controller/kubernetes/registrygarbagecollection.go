@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotGarbageCollection          = "managed resource is not a RegistryGarbageCollection resource"
+	errGetGarbageCollection          = "cannot get garbage collection"
+	errGetActiveGarbageCollection    = "cannot get active garbage collection"
+	errGarbageCollectionCreateFailed = "creation of RegistryGarbageCollection resource has failed"
+
+	msgActiveGarbageCollection = "waiting for active garbage collection %s to end"
+)
+
+// garbageCollectionConditions maps the status of a garbage collection run to
+// its Ready condition. A run is only available once it succeeded.
+var garbageCollectionConditions = do.StatusConditions{
+	dok8s.GCStatusRequested:            xpv1.Creating,
+	dok8s.GCStatusWaitingForJWTs:       xpv1.Creating,
+	dok8s.GCStatusScanningManifests:    xpv1.Creating,
+	dok8s.GCStatusDeletingUnreferenced: xpv1.Creating,
+	dok8s.GCStatusCancelling:           xpv1.Unavailable,
+	dok8s.GCStatusSucceeded:            xpv1.Available,
+	dok8s.GCStatusFailed:               xpv1.Unavailable,
+	dok8s.GCStatusCancelled:            xpv1.Unavailable,
+}
+
+// SetupRegistryGarbageCollection adds a controller that reconciles
+// RegistryGarbageCollection managed resources.
+func SetupRegistryGarbageCollection(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.RegistryGarbageCollectionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RegistryGarbageCollection{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RegistryGarbageCollectionGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.RegistryGarbageCollectionGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &garbageCollectionConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type garbageCollectionConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *garbageCollectionConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &garbageCollectionExternal{Client: c.clients.GetFor(a)}, nil
+}
+
+type garbageCollectionExternal struct {
+	*godo.Client
+}
+
+func (c *garbageCollectionExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RegistryGarbageCollection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGarbageCollection)
+	}
+	registry := do.StringValue(cr.Spec.ForProvider.Registry)
+
+	uuid := meta.GetExternalName(cr)
+	if uuid == "" {
+		// Only one run can be active in a registry, so the run is not
+		// triggered until the active one, e.g. a run triggered outside of
+		// Crossplane, ends.
+		active, response, err := c.Registry.GetGarbageCollection(ctx, registry)
+		if err != nil || active == nil {
+			return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetActiveGarbageCollection)
+		}
+		cr.SetConditions(xpv1.Creating().WithMessage(fmt.Sprintf(msgActiveGarbageCollection, active.UUID)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	gc, err := dok8s.GetGarbageCollection(ctx, c.Client, registry, uuid)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetGarbageCollection)
+	}
+	if gc == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = dok8s.GenerateGarbageCollectionObservation(gc)
+	garbageCollectionConditions.SetCondition(cr, gc.Status)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *garbageCollectionExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RegistryGarbageCollection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGarbageCollection)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	gc, _, err := c.Registry.StartGarbageCollection(ctx, do.StringValue(cr.Spec.ForProvider.Registry), dok8s.GenerateGarbageCollection(cr.Spec.ForProvider))
+	if err != nil || gc == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGarbageCollectionCreateFailed)
+	}
+
+	meta.SetExternalName(cr, gc.UUID)
+	cr.Status.AtProvider = dok8s.GenerateGarbageCollectionObservation(gc)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// Update does nothing, since a garbage collection run cannot be changed.
+func (c *garbageCollectionExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete stops tracking the garbage collection run. A run that is still active
+// is left to end on its own.
+func (c *garbageCollectionExternal) Delete(_ context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RegistryGarbageCollection)
+	if !ok {
+		return errors.New(errNotGarbageCollection)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	return nil
+}
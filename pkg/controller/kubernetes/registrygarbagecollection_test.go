@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/kubernetes/v1alpha1"
+	dok8s "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/kubernetes"
+)
+
+const (
+	testRegistry = "registrytest"
+	testGCID     = "gc-uuid"
+)
+
+type fakeRegistry struct {
+	godo.RegistryService
+
+	active  *godo.GarbageCollection
+	ended   []*godo.GarbageCollection
+	started *godo.StartGarbageCollectionRequest
+}
+
+func (f *fakeRegistry) GetGarbageCollection(_ context.Context, _ string) (*godo.GarbageCollection, *godo.Response, error) {
+	if f.active == nil {
+		r := &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{}}
+		return nil, &godo.Response{Response: r}, &godo.ErrorResponse{Response: r, Message: "not found"}
+	}
+	return f.active, nil, nil
+}
+
+func (f *fakeRegistry) ListGarbageCollections(_ context.Context, _ string, _ *godo.ListOptions) ([]*godo.GarbageCollection, *godo.Response, error) {
+	return f.ended, nil, nil
+}
+
+func (f *fakeRegistry) StartGarbageCollection(_ context.Context, registry string, req ...*godo.StartGarbageCollectionRequest) (*godo.GarbageCollection, *godo.Response, error) {
+	f.started = req[0]
+	return &godo.GarbageCollection{UUID: testGCID, RegistryName: registry, Status: dok8s.GCStatusRequested, Type: req[0].Type}, nil, nil
+}
+
+func garbageCollection(tracked bool) *v1alpha1.RegistryGarbageCollection {
+	registry := testRegistry
+	cr := &v1alpha1.RegistryGarbageCollection{}
+	cr.Spec.ForProvider.Registry = &registry
+	if tracked {
+		meta.SetExternalName(cr, testGCID)
+	}
+	return cr
+}
+
+func TestGarbageCollectionObserve(t *testing.T) {
+	unknown := xpv1.Condition{Type: xpv1.TypeReady, Status: corev1.ConditionUnknown}
+
+	type want struct {
+		o         managed.ExternalObservation
+		phase     string
+		freed     uint64
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason   string
+		tracked  bool
+		registry *fakeRegistry
+		want     want
+	}{
+		"NotTriggered": {
+			reason:   "A run should be triggered if no other run is active in the registry.",
+			registry: &fakeRegistry{},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}, condition: unknown},
+		},
+		"OtherRunActive": {
+			reason:   "A run should not be triggered while another run is active in the registry.",
+			registry: &fakeRegistry{active: &godo.GarbageCollection{UUID: "other", Status: dok8s.GCStatusScanningManifests}},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				condition: xpv1.Creating().WithMessage("waiting for active garbage collection other to end"),
+			},
+		},
+		"Blocked": {
+			reason:   "A run waiting for writes to the registry to end should be blocked.",
+			tracked:  true,
+			registry: &fakeRegistry{active: &godo.GarbageCollection{UUID: testGCID, Status: dok8s.GCStatusWaitingForJWTs}},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				phase:     v1alpha1.GarbageCollectionBlocked,
+				condition: xpv1.Creating(),
+			},
+		},
+		"Completed": {
+			reason:  "A run that succeeded should be completed and report the space it freed.",
+			tracked: true,
+			registry: &fakeRegistry{
+				active: &godo.GarbageCollection{UUID: "other", Status: dok8s.GCStatusRequested},
+				ended:  []*godo.GarbageCollection{{UUID: testGCID, Status: dok8s.GCStatusSucceeded, FreedBytes: 1024}},
+			},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				phase:     v1alpha1.GarbageCollectionCompleted,
+				freed:     1024,
+				condition: xpv1.Available(),
+			},
+		},
+		"Gone": {
+			reason:   "A run that is no longer listed should not exist.",
+			tracked:  true,
+			registry: &fakeRegistry{},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}, condition: unknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := godo.NewClient(nil)
+			c.Registry = tc.registry
+			e := &garbageCollectionExternal{Client: c}
+			cr := garbageCollection(tc.tracked)
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			got := want{
+				o:         o,
+				phase:     cr.Status.AtProvider.Phase,
+				freed:     cr.Status.AtProvider.FreedBytes,
+				condition: cr.Status.GetCondition(xpv1.TypeReady),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectionCreate(t *testing.T) {
+	gcType := string(godo.GCTypeUntaggedManifestsOnly)
+
+	cases := map[string]struct {
+		reason string
+		gcType *string
+		want   godo.GarbageCollectionType
+	}{
+		"DefaultType": {
+			reason: "A run should only collect unreferenced blobs by default.",
+			want:   godo.GCTypeUnreferencedBlobsOnly,
+		},
+		"Type": {
+			reason: "A run should collect the desired type of garbage.",
+			gcType: &gcType,
+			want:   godo.GCTypeUntaggedManifestsOnly,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &fakeRegistry{}
+			c := godo.NewClient(nil)
+			c.Registry = r
+			e := &garbageCollectionExternal{Client: c}
+			cr := garbageCollection(false)
+			cr.Spec.ForProvider.Type = tc.gcType
+			if _, err := e.Create(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, r.started.Type); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want type, +got type:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(testGCID, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external-name, +got external-name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
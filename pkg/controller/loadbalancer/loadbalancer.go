@@ -18,12 +18,18 @@ package loadbalancer
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -32,9 +38,13 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	certificatev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	computev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
 	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
 	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
 	dolb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
 )
 
 const (
@@ -45,19 +55,42 @@ const (
 	errLBCreateFailed = "creation of LoadBalancer resource has failed"
 	errLBDeleteFailed = "deletion of LoadBalancer resource has failed"
 	errLBUpdate       = "cannot update managed LoadBalancer resource"
+	errLBUpdateFailed = "update of LoadBalancer resource has failed"
+
+	errGetDroplet           = "cannot get Droplet %q assigned to LoadBalancer"
+	errDropletNotCreated    = "Droplet %q assigned to LoadBalancer has not been created yet"
+	errGetCertificate       = "cannot get Certificate %q of LoadBalancer"
+	errCertificateNotIssued = "forwarding rule %d: Certificate %q has not been issued yet"
+	errGetVPC               = "cannot get VPC of LoadBalancer"
+	errVPCRegionMismatch    = "VPC %q is in region %q, but the LoadBalancer would be created in region %q"
+
+	lbOutDated = "load balancer is not up to date"
 )
 
+// updateTimeout is how long Update waits for a LoadBalancer to become active
+// again once the update was accepted. It leaves the update enough of the
+// reconcile timeout.
+const updateTimeout = 30 * time.Second
+
+// loadBalancerConditions maps the status of a LoadBalancer to its Ready
+// condition.
+var loadBalancerConditions = do.StatusConditions{
+	v1alpha1.StatusNew:    xpv1.Creating,
+	v1alpha1.StatusActive: xpv1.Available,
+}
+
 // SetupLB adds a controller that reconciles LB managed
 // resources.
-func SetupLB(mgr ctrl.Manager, l logging.Logger) error {
+func SetupLB(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
 	name := managed.ControllerName(v1alpha1.LBGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.LB{}).
+		Watches(&source.Kind{Type: &certificatev1alpha1.Certificate{}}, handler.EnqueueRequestsFromMapFunc(certificateDependents(mgr.GetClient()))).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.LBGroupVersionKind),
-			managed.WithExternalConnecter(&lbConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.LBGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &lbConnector{kube: mgr.GetClient(), clients: cc}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
@@ -65,21 +98,49 @@ func SetupLB(mgr ctrl.Manager, l logging.Logger) error {
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
+// certificateDependents returns a function that maps a Certificate to the LBs
+// whose forwarding rules reference it, so that they are updated as soon as a
+// renewed Certificate gets a new ID rather than on their next poll.
+func certificateDependents(kube client.Reader) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.LBList{}
+		if err := kube.List(context.Background(), l); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, lb := range l.Items {
+			p, err := dolb.MergePools(lb.Spec.ForProvider)
+			if err != nil {
+				continue
+			}
+			for _, r := range p.ForwardingRules {
+				if r.CertificateRef != nil && r.CertificateRef.Name == o.GetName() {
+					reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: lb.GetName()}})
+					break
+				}
+			}
+		}
+		return reqs
+	}
+}
+
 type lbConnector struct {
-	kube client.Client
+	kube    client.Client
+	clients *do.ClientCache
 }
 
 func (c *lbConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	token, err := do.GetAuthInfo(ctx, c.kube, mg)
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	client := godo.NewFromToken(token)
-	return &lbExternal{Client: client, kube: c.kube}, nil
+	client := c.clients.GetFor(a)
+	return &lbExternal{Client: client, kube: c.kube, updateTimeout: updateTimeout}, nil
 }
 
 type lbExternal struct {
-	kube client.Client
+	kube          client.Client
+	updateTimeout time.Duration
 	*godo.Client
 }
 
@@ -95,15 +156,15 @@ func (c *lbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		}, nil
 	}
 
-	observed, response, err := c.LoadBalancers.Get(ctx, meta.GetExternalName(cr))
+	observed, response, err := dolb.GetLoadBalancer(ctx, c.Client, meta.GetExternalName(cr))
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetLB)
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	original := cr.DeepCopy()
 	dolb.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
-	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
-		if err := c.kube.Update(ctx, cr); err != nil {
+	if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+		if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errLBUpdate)
 		}
 	}
@@ -111,19 +172,51 @@ func (c *lbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 	cr.Status.AtProvider = v1alpha1.LBObservation{
 		CreationTimestamp: observed.Created,
 		ID:                observed.ID,
+		DropletIDs:        observed.DropletIDs,
 		Status:            observed.Status,
 	}
 
-	switch cr.Status.AtProvider.Status {
-	case v1alpha1.StatusNew:
-		cr.SetConditions(xpv1.Creating())
-	case v1alpha1.StatusActive:
-		cr.SetConditions(xpv1.Available())
+	// A LB that is briefly reported as new while an update is applied stays
+	// available rather than flapping back to creating.
+	if cr.Status.AtProvider.Status != v1alpha1.StatusNew || cr.GetCondition(xpv1.TypeReady).Reason != xpv1.ReasonAvailable {
+		loadBalancerConditions.SetCondition(cr, cr.Status.AtProvider.Status)
+	}
+
+	// A LB that is being deleted is never updated, so its references are not
+	// resolved. A referenced Droplet or Certificate that was deleted first
+	// must not keep the LB from being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	// A LB whose references cannot be resolved yet is not up to date. Update
+	// resolves them again and reports why they cannot be resolved.
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             lbOutDated + ": " + err.Error(),
+		}, nil
+	}
+	if !dolb.IsUpToDate(p, *observed) {
+		diff := lbOutDated
+		if d := dolb.Diff(p, *observed); d != "" {
+			diff += ":\n" + d
+		}
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             diff,
+		}, nil
 	}
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceUpToDate: do.ProjectUpToDate(cr, cr.Spec.ForProvider.ProjectID),
 	}, nil
 }
 
@@ -140,10 +233,22 @@ func (c *lbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 		name = cr.GetName()
 	}
 
-	create := &godo.LoadBalancerRequest{}
-	dolb.GenerateLoadBalancer(name, cr.Spec.ForProvider, create)
+	if err := validate(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.validateVPCRegion(ctx, p); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	create := &dolb.LoadBalancerRequest{Firewall: dolb.GenerateFirewall(p)}
+	dolb.GenerateLoadBalancer(name, p, &create.LoadBalancerRequest)
 
-	lb, _, err := c.LoadBalancers.Create(ctx, create)
+	lb, _, err := dolb.CreateLoadBalancer(ctx, c.Client, create)
 	if err != nil || lb == nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errLBCreateFailed)
 	}
@@ -152,12 +257,163 @@ func (c *lbExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 		meta.SetExternalName(cr, lb.ID)
 	}
 
-	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+	return managed.ExternalCreation{ExternalNameAssigned: true}, do.AssignProject(ctx, c.Client, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("LoadBalancer", lb.ID))
 }
 
 func (c *lbExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Droplets cannot be updated.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.LB)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLB)
+	}
+
+	if err := validate(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	p, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Updates replace the whole load balancer, so the fields that are not
+	// managed by the spec are sent as observed.
+	observed, _, err := dolb.GetLoadBalancer(ctx, c.Client, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetLB)
+	}
+	if err := dolb.ValidateVPC(p, *observed); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// A LB whose project is the only change is moved to it without
+	// replacing the LB.
+	if err := do.UpdateProject(ctx, c.Client, c.kube, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("LoadBalancer", observed.ID)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if dolb.IsUpToDate(p, *observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// The forwarding rules, including their certificates, are replaced in a
+	// single request, so they are never applied partially.
+	if _, _, err := dolb.UpdateLoadBalancer(ctx, c.Client, meta.GetExternalName(cr), dolb.GenerateLoadBalancerUpdate(p, *observed)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errLBUpdateFailed)
+	}
+	if rotations := certificateRotations(p, observed.ForwardingRules); len(rotations) > 0 {
+		cr.SetConditions(do.CertificateRotatedCondition(rotations))
+	}
+
+	// A LB that does not become active in time is observed again on the
+	// next reconcile.
+	wctx, cancel := context.WithTimeout(ctx, c.updateTimeout)
+	defer cancel()
+	_, err = dolb.WaitForActive(wctx, c.Client, meta.GetExternalName(cr))
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(err, errLBUpdateFailed)
+}
+
+// validate returns an error if the parameters of the supplied LB, including
+// those of its pools, would be rejected by the API.
+func validate(cr *v1alpha1.LB) error {
+	p, err := dolb.MergePools(cr.Spec.ForProvider)
+	if err != nil {
+		return err
+	}
+	if err := dolb.ValidateFirewall(p); err != nil {
+		return err
+	}
+	if err := dolb.ValidateForwardingRules(p); err != nil {
+		return err
+	}
+	return dolb.ValidateMembership(p)
+}
+
+// validateVPCRegion returns an error if the LB is placed in a VPC of a
+// different region. The API rejects cross-region VPC placement with an
+// unhelpful error.
+func (c *lbExternal) validateVPCRegion(ctx context.Context, p v1alpha1.LBParameters) error {
+	if do.StringValue(p.VPCUUID) == "" {
+		return nil
+	}
+	vpc, _, err := c.VPCs.Get(ctx, *p.VPCUUID)
+	if err != nil {
+		return errors.Wrap(err, errGetVPC)
+	}
+	if vpc.RegionSlug != p.Region {
+		return errors.Errorf(errVPCRegionMismatch, *p.VPCUUID, vpc.RegionSlug, p.Region)
+	}
+	return nil
+}
+
+// desiredParameters returns the parameters of the supplied LB with its pools
+// merged into them, the IDs of the referenced Droplets added to its Droplet
+// IDs and the IDs of the referenced Certificates set on its forwarding rules.
+// The references are resolved on every call rather than once, so a
+// referenced Droplet that is recreated with a new ID stays assigned to the LB
+// and a referenced Certificate that is renewed with a new ID stays in use.
+func (c *lbExternal) desiredParameters(ctx context.Context, cr *v1alpha1.LB) (v1alpha1.LBParameters, error) {
+	p, err := dolb.MergePools(*cr.Spec.ForProvider.DeepCopy())
+	if err != nil {
+		return p, err
+	}
+	for _, ref := range p.DropletRefs {
+		d := &computev1alpha1.Droplet{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, d); err != nil {
+			return p, errors.Wrapf(err, errGetDroplet, ref.Name)
+		}
+		id, ok := do.ExternalNameAsInt(d)
+		if !ok {
+			return p, errors.Errorf(errDropletNotCreated, ref.Name)
+		}
+		if !containsID(p.DropletIDs, id) {
+			p.DropletIDs = append(p.DropletIDs, id)
+		}
+	}
+	for i := range p.ForwardingRules {
+		ref := p.ForwardingRules[i].CertificateRef
+		if ref == nil {
+			continue
+		}
+		cert := &certificatev1alpha1.Certificate{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cert); err != nil {
+			return p, errors.Wrapf(err, errGetCertificate, ref.Name)
+		}
+		id := certificatev1alpha1.CertificateID()(cert)
+		if id == "" {
+			return p, errors.Errorf(errCertificateNotIssued, i, ref.Name)
+		}
+		p.ForwardingRules[i].CertificateID = id
+	}
+	return p, nil
+}
+
+// certificateRotations describes the forwarding rules of the supplied
+// parameters that were updated to a new ID of the Certificate they reference,
+// given the observed forwarding rules. Rules are matched by their entry
+// protocol and port, which are unique within a LB.
+func certificateRotations(p v1alpha1.LBParameters, observed []godo.ForwardingRule) []string {
+	var rotations []string
+	for i, r := range p.ForwardingRules {
+		if r.CertificateRef == nil {
+			continue
+		}
+		for _, o := range observed {
+			if o.EntryProtocol == r.EntryProtocol && o.EntryPort == r.EntryPort && o.CertificateID != "" && o.CertificateID != r.CertificateID {
+				rotations = append(rotations, do.CertificateRotation(fmt.Sprintf("forwarding rule %d", i), r.CertificateRef.Name, o.CertificateID, r.CertificateID))
+			}
+		}
+	}
+	return rotations
+}
+
+func containsID(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *lbExternal) Delete(ctx context.Context, mg resource.Managed) error {
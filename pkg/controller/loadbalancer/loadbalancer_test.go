@@ -0,0 +1,525 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	certificatev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/certificate/v1alpha1"
+	computev1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/compute/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/apis/loadbalancer/v1alpha1"
+	vpcv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	dolb "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/loadbalancer"
+)
+
+const (
+	testLBID  = "lb-id"
+	testVPCID = "5a4981aa-9653-4bd1-bef5-d6bff52042e4"
+)
+
+// droplets returns a client that serves Droplets with the supplied external
+// names by name.
+func droplets(ids map[string]string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			d, ok := obj.(*computev1alpha1.Droplet)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			d.SetName(key.Name)
+			meta.SetExternalName(d, ids[key.Name])
+			return nil
+		},
+		MockUpdate: test.NewMockUpdateFn(nil),
+	}
+}
+
+func TestLBDropletRefsFollowRecreation(t *testing.T) {
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:         testLBID,
+		Status:     v1alpha1.StatusActive,
+		Region:     &godo.Region{Slug: "nyc1"},
+		DropletIDs: []int{111, 333},
+	}}
+
+	var got []int
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/load_balancers/" + testLBID:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		case "PUT /v2/load_balancers/" + testLBID:
+			req := &dolb.LoadBalancerRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			got = req.DropletIDs
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	// The referenced Droplet "web" was recreated with ID 222.
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	cr.Spec.ForProvider.DropletIDs = []int{333}
+	cr.Spec.ForProvider.DropletRefs = []xpv1.Reference{{Name: "web"}}
+	e := &lbExternal{kube: droplets(map[string]string{"web": "222"}), Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a LB assigned the previous ID of a recreated Droplet not to be up to date")
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if diff := cmp.Diff([]int{333, 222}, got); diff != "" {
+		t.Errorf("e.Update(...): -want Droplet IDs, +got Droplet IDs:\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{333}, cr.Spec.ForProvider.DropletIDs); diff != "" {
+		t.Errorf("e.Update(...): want resolved Droplet IDs not to be written to the spec:\n%s", diff)
+	}
+}
+
+func TestLBDropletRefsNotCreated(t *testing.T) {
+	cr := &v1alpha1.LB{}
+	cr.Spec.ForProvider.DropletRefs = []xpv1.Reference{{Name: "web"}}
+	e := &lbExternal{kube: droplets(map[string]string{"web": "web"})}
+
+	_, err := e.desiredParameters(context.Background(), cr)
+	want := errors.Errorf(errDropletNotCreated, "web")
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.desiredParameters(...): -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestLBObserveUnresolvedDropletRef(t *testing.T) {
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:     testLBID,
+		Status: v1alpha1.StatusActive,
+		Region: &godo.Region{Slug: "nyc1"},
+	}}
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/load_balancers/" + testLBID: fake.Respond(t, map[string]interface{}{"load_balancer": observed}),
+	})
+
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	cr.Spec.ForProvider.DropletRefs = []xpv1.Reference{{Name: "web"}}
+	e := &lbExternal{kube: droplets(map[string]string{"web": "web"}), Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !o.ResourceExists || o.ResourceUpToDate {
+		t.Errorf("e.Observe(...): want a LB whose Droplet has not been created yet to exist but not be up to date, got %+v", o)
+	}
+}
+
+func TestLBDeleteDropletRefGone(t *testing.T) {
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:     testLBID,
+		Status: v1alpha1.StatusActive,
+		Region: &godo.Region{Slug: "nyc1"},
+	}}
+	deleted := false
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/load_balancers/" + testLBID: fake.Respond(t, map[string]interface{}{"load_balancer": observed}),
+		"DELETE /v2/load_balancers/" + testLBID: func(w http.ResponseWriter, _ *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	// The referenced Droplet "web" was deleted before the LB.
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		},
+		MockUpdate: test.NewMockUpdateFn(nil),
+	}
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	cr.Spec.ForProvider.DropletRefs = []xpv1.Reference{{Name: "web"}}
+	e := &lbExternal{kube: kube, Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !o.ResourceExists {
+		t.Fatal("e.Observe(...): want a LB being deleted to exist")
+	}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if !deleted {
+		t.Error("e.Delete(...): want the LB to be deleted")
+	}
+}
+
+func TestLBCreatePoolsHealthChecks(t *testing.T) {
+	h := func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}
+
+	cr := &v1alpha1.LB{}
+	cr.Spec.ForProvider.Pools = []v1alpha1.LBPool{
+		{Name: "web", DropletIDs: []int{1}, HealthCheck: &v1alpha1.DOLoadBalancerHealthCheck{Interval: 10}},
+		{Name: "api", DropletIDs: []int{2}, HealthCheck: &v1alpha1.DOLoadBalancerHealthCheck{Interval: 30}},
+	}
+	e := &lbExternal{kube: droplets(nil), Client: fake.NewClient(t, h)}
+
+	_, err := e.Create(context.Background(), cr)
+	want := errors.Errorf("a LoadBalancer supports a single health check, but %s and %s define different ones", `pool "web"`, `pool "api"`)
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestLBVPCReference(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			v, ok := obj.(*vpcv1alpha1.VPC)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			v.SetName(key.Name)
+			meta.SetExternalName(v, testVPCID)
+			return nil
+		},
+	}
+
+	cr := &v1alpha1.LB{}
+	cr.Spec.ForProvider.VPCRef = &xpv1.Reference{Name: "prod"}
+	if err := cr.ResolveReferences(context.Background(), kube); err != nil {
+		t.Fatalf("cr.ResolveReferences(...): %v", err)
+	}
+	if diff := cmp.Diff(testVPCID, do.StringValue(cr.Spec.ForProvider.VPCUUID)); diff != "" {
+		t.Errorf("cr.ResolveReferences(...): -want VPC UUID, +got VPC UUID:\n%s", diff)
+	}
+}
+
+func TestLBCreateVPCRegion(t *testing.T) {
+	type want struct {
+		err     error
+		created bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		region string
+		want   want
+	}{
+		"SameRegion": {
+			reason: "A LB should be created in a VPC of its region.",
+			region: "nyc1",
+			want:   want{created: true},
+		},
+		"OtherRegion": {
+			reason: "A LB should not be created in a VPC of another region.",
+			region: "sfo3",
+			want:   want{err: errors.Errorf(errVPCRegionMismatch, testVPCID, "nyc1", "sfo3")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := false
+			h := func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /v2/vpcs/" + testVPCID:
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"vpc": godo.VPC{ID: testVPCID, RegionSlug: "nyc1"}})
+				case "POST /v2/load_balancers":
+					created = true
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": godo.LoadBalancer{ID: testLBID}})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}
+
+			cr := &v1alpha1.LB{}
+			cr.Spec.ForProvider.Region = tc.region
+			vpc := testVPCID
+			cr.Spec.ForProvider.VPCUUID = &vpc
+			e := &lbExternal{kube: droplets(nil), Client: fake.NewClient(t, h)}
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, want{err: err, created: created}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLBUpdateVPCImmutable(t *testing.T) {
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:      testLBID,
+		Status:  v1alpha1.StatusActive,
+		Region:  &godo.Region{Slug: "nyc1"},
+		VPCUUID: testVPCID,
+	}}
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "GET /v2/load_balancers/"+testLBID {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+	}
+
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	vpc := "0d3176ad-41e0-4021-b831-0c5c45c60959"
+	cr.Spec.ForProvider.VPCUUID = &vpc
+	e := &lbExternal{kube: droplets(nil), Client: fake.NewClient(t, h)}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a LB whose VPC was changed not to be up to date")
+	}
+
+	_, err = e.Update(context.Background(), cr)
+	want := errors.Errorf("the VPC of a LoadBalancer cannot be changed once it is created: it is in VPC %q, not %q", testVPCID, "0d3176ad-41e0-4021-b831-0c5c45c60959")
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestLBRotateCertificate(t *testing.T) {
+	rules := []godo.ForwardingRule{
+		{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: "web-cert"},
+		{EntryProtocol: "https", EntryPort: 8443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "api-cert"},
+	}
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:              testLBID,
+		Status:          v1alpha1.StatusActive,
+		Region:          &godo.Region{Slug: "nyc1"},
+		ForwardingRules: rules,
+	}}
+
+	var got []godo.ForwardingRule
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/load_balancers/" + testLBID:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		case "PUT /v2/load_balancers/" + testLBID:
+			req := &dolb.LoadBalancerRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			got = req.ForwardingRules
+			// The LB is briefly reported as new while the update is applied.
+			observed.ForwardingRules, observed.Status = req.ForwardingRules, v1alpha1.StatusNew
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	// The certificate of the api rule was renewed with a new ID.
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	cr.Spec.ForProvider.ForwardingRules = []v1alpha1.LBForwardingRule{
+		{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: "web-cert"},
+		{EntryProtocol: "https", EntryPort: 8443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "api-cert-renewed"},
+	}
+	e := &lbExternal{kube: droplets(nil), Client: fake.NewClient(t, h), updateTimeout: 10 * time.Millisecond}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a LB with a rotated certificate not to be up to date")
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := []godo.ForwardingRule{rules[0], rules[1]}
+	want[1].CertificateID = "api-cert-renewed"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want forwarding rules, +got forwarding rules:\n%s", diff)
+	}
+
+	// An observe while the update is applied should not flap the Ready
+	// condition back to creating.
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if diff := cmp.Diff(xpv1.Available(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Observe(...): -want Ready condition, +got Ready condition:\n%s", diff)
+	}
+}
+
+// certificates returns a client that serves Certificates with the supplied
+// observed IDs keyed by the names of their managed resources. A Certificate
+// without an ID was not issued yet.
+func certificates(ids map[string]string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			cert, ok := obj.(*certificatev1alpha1.Certificate)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			cert.SetName(key.Name)
+			if id := ids[key.Name]; id != "" {
+				cert.Status.AtProvider.ID = id
+				cert.Status.AtProvider.State = certificatev1alpha1.StateVerified
+			}
+			return nil
+		},
+	}
+}
+
+func TestLBCertificateRefFollowsRenewal(t *testing.T) {
+	observed := dolb.LoadBalancer{LoadBalancer: godo.LoadBalancer{
+		ID:     testLBID,
+		Status: v1alpha1.StatusActive,
+		Region: &godo.Region{Slug: "nyc1"},
+		ForwardingRules: []godo.ForwardingRule{
+			{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: "web-cert"},
+		},
+	}}
+
+	var got []godo.ForwardingRule
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/load_balancers/" + testLBID:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		case "PUT /v2/load_balancers/" + testLBID:
+			req := &dolb.LoadBalancerRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			got = req.ForwardingRules
+			observed.ForwardingRules = req.ForwardingRules
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"load_balancer": observed})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	// The referenced Certificate was renewed with a new ID after the ID it
+	// had was resolved into the spec.
+	cr := &v1alpha1.LB{}
+	meta.SetExternalName(cr, testLBID)
+	cr.Spec.ForProvider.ForwardingRules = []v1alpha1.LBForwardingRule{{
+		EntryProtocol:  "https",
+		EntryPort:      443,
+		TargetProtocol: "http",
+		TargetPort:     80,
+		CertificateID:  "web-cert",
+		CertificateRef: &xpv1.Reference{Name: "web"},
+	}}
+	e := &lbExternal{kube: certificates(map[string]string{"web": "web-cert-renewed"}), Client: fake.NewClient(t, h), updateTimeout: 10 * time.Millisecond}
+
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("e.Observe(...): want a LB whose referenced Certificate was renewed not to be up to date")
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := []godo.ForwardingRule{
+		{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: "web-cert-renewed"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want forwarding rules, +got forwarding rules:\n%s", diff)
+	}
+	rotated := do.CertificateRotatedCondition([]string{`forwarding rule 0: Certificate "web" rotated from web-cert to web-cert-renewed`})
+	if diff := cmp.Diff(rotated, cr.GetCondition(do.TypeCertificateRotated), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Update(...): -want CertificateRotated condition, +got CertificateRotated condition:\n%s", diff)
+	}
+}
+
+func TestLBCertificateDependents(t *testing.T) {
+	rule := func(cert string) v1alpha1.LBForwardingRule {
+		return v1alpha1.LBForwardingRule{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateRef: &xpv1.Reference{Name: cert}}
+	}
+	lb := func(name string, rules ...v1alpha1.LBForwardingRule) v1alpha1.LB {
+		cr := v1alpha1.LB{}
+		cr.SetName(name)
+		cr.Spec.ForProvider.ForwardingRules = rules
+		return cr
+	}
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1alpha1.LBList).Items = []v1alpha1.LB{lb("web", rule("web")), lb("api", rule("api")), lb("plain")}
+			return nil
+		},
+	}
+	cert := &certificatev1alpha1.Certificate{}
+	cert.SetName("web")
+
+	got := certificateDependents(kube)(cert)
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "web"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("certificateDependents(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLBCertificateRefNotIssued(t *testing.T) {
+	cr := &v1alpha1.LB{}
+	cr.Spec.ForProvider.ForwardingRules = []v1alpha1.LBForwardingRule{{
+		EntryProtocol:  "https",
+		EntryPort:      443,
+		TargetProtocol: "http",
+		TargetPort:     80,
+		CertificateRef: &xpv1.Reference{Name: "web"},
+	}}
+	e := &lbExternal{kube: certificates(nil)}
+
+	_, err := e.desiredParameters(context.Background(), cr)
+	want := errors.Errorf(errCertificateNotIssued, 0, "web")
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.desiredParameters(...): -want error, +got error:\n%s", diff)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics instruments the external clients of the DigitalOcean
+// controllers.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Operations of an external client.
+const (
+	OperationObserve = "observe"
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+)
+
+// ExternalDuration measures the time spent in each operation of the external
+// clients, per kind of managed resource. Its buckets range from 50ms, for
+// observing a resource, to about 27 minutes, for creating a database cluster.
+var ExternalDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "provider_digitalocean",
+	Name:      "external_operation_duration_seconds",
+	Help:      "Time spent observing, creating, updating and deleting external resources.",
+	Buckets:   prometheus.ExponentialBuckets(0.05, 2, 16),
+}, []string{"group", "kind", "operation"})
+
+// Register registers the metrics with the supplied registerer.
+func Register(r prometheus.Registerer) error {
+	return r.Register(ExternalDuration)
+}
+
+// NewInstrumentedConnecter returns an ExternalConnecter whose external
+// clients record the duration of their operations for the supplied kind.
+func NewInstrumentedConnecter(gk schema.GroupKind, c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &instrumentedConnecter{gk: gk, connecter: c}
+}
+
+type instrumentedConnecter struct {
+	gk        schema.GroupKind
+	connecter managed.ExternalConnecter
+}
+
+func (c *instrumentedConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedExternal{gk: c.gk, client: e, now: time.Now}, nil
+}
+
+type instrumentedExternal struct {
+	gk     schema.GroupKind
+	client managed.ExternalClient
+	now    func() time.Time
+}
+
+// observe records the time elapsed since the supplied start of an operation.
+func (e *instrumentedExternal) observe(operation string, start time.Time) {
+	ExternalDuration.WithLabelValues(e.gk.Group, e.gk.Kind, operation).Observe(e.now().Sub(start).Seconds())
+}
+
+func (e *instrumentedExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer e.observe(OperationObserve, e.now())
+	return e.client.Observe(ctx, mg)
+}
+
+func (e *instrumentedExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer e.observe(OperationCreate, e.now())
+	return e.client.Create(ctx, mg)
+}
+
+func (e *instrumentedExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer e.observe(OperationUpdate, e.now())
+	return e.client.Update(ctx, mg)
+}
+
+func (e *instrumentedExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	defer e.observe(OperationDelete, e.now())
+	return e.client.Delete(ctx, mg)
+}
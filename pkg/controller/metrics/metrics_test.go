@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+// fakeExternal takes the supplied duration to perform every operation.
+type fakeExternal struct {
+	clock *time.Time
+	took  time.Duration
+	err   error
+}
+
+func (e *fakeExternal) elapse() { *e.clock = e.clock.Add(e.took) }
+
+func (e *fakeExternal) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	e.elapse()
+	return managed.ExternalObservation{ResourceExists: true}, e.err
+}
+
+func (e *fakeExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	e.elapse()
+	return managed.ExternalCreation{}, e.err
+}
+
+func (e *fakeExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	e.elapse()
+	return managed.ExternalUpdate{}, e.err
+}
+
+func (e *fakeExternal) Delete(_ context.Context, _ resource.Managed) error {
+	e.elapse()
+	return e.err
+}
+
+// histogram returns the sample count and sum of the supplied labels.
+func histogram(t *testing.T, gk schema.GroupKind, operation string) (uint64, float64) {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := ExternalDuration.WithLabelValues(gk.Group, gk.Kind, operation).(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestInstrumentedExternal(t *testing.T) {
+	type want struct {
+		count uint64
+		sum   float64
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		operation string
+		took      time.Duration
+		err       error
+		want      want
+	}{
+		"Observe": {
+			reason:    "The duration of an observe should be recorded.",
+			operation: OperationObserve,
+			took:      100 * time.Millisecond,
+			want:      want{count: 1, sum: 0.1},
+		},
+		"Create": {
+			reason:    "The duration of a multi-minute create should be recorded.",
+			operation: OperationCreate,
+			took:      5 * time.Minute,
+			want:      want{count: 1, sum: 300},
+		},
+		"Update": {
+			reason:    "The duration of an update should be recorded.",
+			operation: OperationUpdate,
+			took:      2 * time.Second,
+			want:      want{count: 1, sum: 2},
+		},
+		"DeleteFailed": {
+			reason:    "The duration of a failed operation should be recorded and its error returned.",
+			operation: OperationDelete,
+			took:      time.Second,
+			err:       errBoom,
+			want:      want{count: 1, sum: 1, err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gk := schema.GroupKind{Group: "test.do.crossplane.io", Kind: name}
+			clock := time.Now()
+			e := &instrumentedExternal{
+				gk:     gk,
+				client: &fakeExternal{clock: &clock, took: tc.took, err: tc.err},
+				now:    func() time.Time { return clock },
+			}
+
+			var err error
+			switch tc.operation {
+			case OperationObserve:
+				_, err = e.Observe(context.Background(), nil)
+			case OperationCreate:
+				_, err = e.Create(context.Background(), nil)
+			case OperationUpdate:
+				_, err = e.Update(context.Background(), nil)
+			case OperationDelete:
+				err = e.Delete(context.Background(), nil)
+			}
+
+			count, sum := histogram(t, gk, tc.operation)
+			if diff := cmp.Diff(tc.want, want{count: count, sum: sum, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.%s(...): -want, +got:\n%s", tc.reason, tc.operation, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	doproject "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/project"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotProject = "managed resource is not a Project resource"
+	errGetProject = "cannot get Project"
+
+	errProjectCreateFailed = "creation of Project resource has failed"
+	errProjectDeleteFailed = "deletion of Project resource has failed"
+	errProjectUpdate       = "cannot update managed Project resource"
+	errProjectUpdateFailed = "update of Project resource has failed"
+)
+
+// SetupProject adds a controller that reconciles Project managed resources.
+func SetupProject(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.ProjectGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Project{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ProjectGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.ProjectGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &projectConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type projectConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *projectConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &projectExternal{Client: client, kube: c.kube}, nil
+}
+
+type projectExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *projectExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Project)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProject)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Projects.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetProject)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		doproject.LateInitializeProject(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errProjectUpdate)
+			}
+		}
+	}
+
+	cr.Status.AtProvider = doproject.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: doproject.IsUpToDate(cr.Spec.ForProvider, *observed),
+	}, nil
+}
+
+func (c *projectExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Project)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProject)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	project, _, err := c.Projects.Create(ctx, doproject.GenerateProject(cr.GetName(), cr.Spec.ForProvider))
+	if err != nil || project == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errProjectCreateFailed)
+	}
+
+	meta.SetExternalName(cr, project.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *projectExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Project)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProject)
+	}
+
+	_, _, err := c.Projects.Update(ctx, meta.GetExternalName(cr), doproject.GenerateProjectUpdate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errProjectUpdateFailed)
+}
+
+func (c *projectExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Project)
+	if !ok {
+		return errors.New(errNotProject)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// DigitalOcean refuses to delete a project that still has resources, so
+	// the deletion is retried until they are gone or moved.
+	response, err := c.Projects.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errProjectDeleteFailed)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservedip
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/reservedip/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	doreservedip "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/reservedip"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotReservedIP = "managed resource is not a ReservedIP resource"
+	errGetReservedIP = "cannot get ReservedIP"
+	errNoRegion      = "spec.forProvider.region is required unless spec.forProvider.dropletId is set"
+
+	errReservedIPCreateFailed = "creation of ReservedIP resource has failed"
+	errReservedIPDeleteFailed = "deletion of ReservedIP resource has failed"
+	errAssign                 = "cannot assign ReservedIP to Droplet %d"
+	errUnassign               = "cannot unassign ReservedIP"
+	errAssignedOnDelete       = "reserved IP %s is assigned to Droplet %d: unassign it or set spec.forProvider.unassignOnDelete"
+	errGetDroplet             = "cannot get Droplet %d"
+	errRegionMismatch         = "reserved IP is reserved in region %q, but Droplet %d is in region %q"
+	errAssignProject          = "cannot assign ReservedIP to project %s"
+	errTag                    = "cannot tag ReservedIP with %q"
+	errUntag                  = "cannot remove tag %q from ReservedIP"
+)
+
+// SetupReservedIP adds a controller that reconciles ReservedIP managed
+// resources.
+func SetupReservedIP(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.ReservedIPGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ReservedIP{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ReservedIPGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.ReservedIPGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &reservedIPConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type reservedIPConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *reservedIPConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &reservedIPExternal{Client: client, kube: c.kube}, nil
+}
+
+type reservedIPExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *reservedIPExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ReservedIP)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotReservedIP)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := doreservedip.Get(ctx, c.Client, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetReservedIP)
+	}
+
+	cr.Status.AtProvider = doreservedip.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: doreservedip.IsUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider),
+	}, nil
+}
+
+func (c *reservedIPExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ReservedIP)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotReservedIP)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	if do.StringValue(cr.Spec.ForProvider.Region) == "" && cr.Spec.ForProvider.DropletID == nil {
+		return managed.ExternalCreation{}, errors.New(errNoRegion)
+	}
+	if id := cr.Spec.ForProvider.DropletID; id != nil {
+		if err := c.checkRegion(ctx, do.StringValue(cr.Spec.ForProvider.Region), *id); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
+	ip, _, err := doreservedip.Create(ctx, c.Client, doreservedip.GenerateCreateRequest(cr.Spec.ForProvider))
+	if err != nil || ip == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errReservedIPCreateFailed)
+	}
+
+	// The address is recorded before the project is assigned, so that a
+	// reserved IP that cannot be assigned is still observed and assigned
+	// by a later update.
+	meta.SetExternalName(cr, ip.IP)
+	if id := cr.Spec.ForProvider.ProjectID; id != nil {
+		if _, _, err := c.Projects.AssignResources(ctx, *id, doreservedip.URN(ip.IP)); err != nil {
+			return managed.ExternalCreation{ExternalNameAssigned: true}, errors.Wrapf(err, errAssignProject, *id)
+		}
+	}
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *reservedIPExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ReservedIP)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotReservedIP)
+	}
+
+	ip := meta.GetExternalName(cr)
+	if id := cr.Spec.ForProvider.ProjectID; !doreservedip.ProjectUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if _, _, err := c.Projects.AssignResources(ctx, *id, doreservedip.URN(ip)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errAssignProject, *id)
+		}
+	}
+	if err := c.updateTags(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// A reserved IP that is locked by an action in progress is reassigned
+	// on a later reconcile.
+	if doreservedip.IsAssigned(cr.Spec.ForProvider, cr.Status.AtProvider) || cr.Status.AtProvider.Locked {
+		return managed.ExternalUpdate{}, nil
+	}
+	if cr.Spec.ForProvider.DropletID == nil {
+		_, _, err := doreservedip.Unassign(ctx, c.Client, ip)
+		return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errUnassign)
+	}
+	if err := c.checkRegion(ctx, cr.Status.AtProvider.Region, *cr.Spec.ForProvider.DropletID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err := doreservedip.Assign(ctx, c.Client, ip, *cr.Spec.ForProvider.DropletID)
+	return managed.ExternalUpdate{}, errors.Wrapf(do.IgnoreLocked(err), errAssign, *cr.Spec.ForProvider.DropletID)
+}
+
+// updateTags tags the supplied reserved IP with the tags it declares but
+// lacks, and removes the tags it carries but does not declare. Tags are
+// created before they are applied, since only existing tags can be.
+func (c *reservedIPExternal) updateTags(ctx context.Context, cr *v1alpha1.ReservedIP) error {
+	resources := []godo.Resource{{ID: meta.GetExternalName(cr), Type: doreservedip.ResourceType}}
+	add, remove := doreservedip.TagsDiff(cr.Spec.ForProvider, cr.Status.AtProvider)
+	for _, tag := range add {
+		if _, _, err := c.Tags.Create(ctx, &godo.TagCreateRequest{Name: tag}); err != nil {
+			return errors.Wrapf(err, errTag, tag)
+		}
+		if _, err := c.Tags.TagResources(ctx, tag, &godo.TagResourcesRequest{Resources: resources}); err != nil {
+			return errors.Wrapf(err, errTag, tag)
+		}
+	}
+	for _, tag := range remove {
+		if response, err := c.Tags.UntagResources(ctx, tag, &godo.UntagResourcesRequest{Resources: resources}); do.IgnoreNotFound(err, response) != nil {
+			return errors.Wrapf(err, errUntag, tag)
+		}
+	}
+	return nil
+}
+
+// checkRegion returns an error naming both regions if the Droplet with the
+// supplied ID is not in the supplied region, since DigitalOcean only assigns
+// a reserved IP to Droplets in the region it is reserved in.
+func (c *reservedIPExternal) checkRegion(ctx context.Context, region string, dropletID int) error {
+	if region == "" {
+		return nil
+	}
+	droplet, _, err := c.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return errors.Wrapf(err, errGetDroplet, dropletID)
+	}
+	if droplet.Region != nil && droplet.Region.Slug != region {
+		return errors.Errorf(errRegionMismatch, region, dropletID, droplet.Region.Slug)
+	}
+	return nil
+}
+
+func (c *reservedIPExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ReservedIP)
+	if !ok {
+		return errors.New(errNotReservedIP)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// DigitalOcean may refuse to release a reserved IP that is assigned to
+	// a Droplet. Unassigning it would cut the Droplet off from the address,
+	// so that only happens if it was asked for.
+	ip := meta.GetExternalName(cr)
+	if id := cr.Status.AtProvider.DropletID; id != 0 {
+		if !do.BoolValue(cr.Spec.ForProvider.UnassignOnDelete) {
+			return errors.Errorf(errAssignedOnDelete, ip, id)
+		}
+		action, _, err := doreservedip.Unassign(ctx, c.Client, ip)
+		if err != nil {
+			return errors.Wrap(do.IgnoreLocked(err), errUnassign)
+		}
+		if action != nil {
+			if _, err := do.WaitForAction(ctx, c.Actions, action.ID); err != nil {
+				return errors.Wrap(err, errUnassign)
+			}
+		}
+	}
+
+	response, err := doreservedip.Delete(ctx, c.Client, ip)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errReservedIPDeleteFailed)
+}
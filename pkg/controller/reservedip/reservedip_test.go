@@ -0,0 +1,334 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservedip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/reservedip/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+	doreservedip "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/reservedip"
+)
+
+const testIP = "192.0.2.10"
+
+func stringPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+type reservedIPModifier func(*v1alpha1.ReservedIP)
+
+func withDropletID(id int) reservedIPModifier {
+	return func(cr *v1alpha1.ReservedIP) { cr.Spec.ForProvider.DropletID = &id }
+}
+
+func withAssigned(id int) reservedIPModifier {
+	return func(cr *v1alpha1.ReservedIP) { cr.Status.AtProvider.DropletID = id }
+}
+
+func withUnassignOnDelete() reservedIPModifier {
+	return func(cr *v1alpha1.ReservedIP) {
+		unassign := true
+		cr.Spec.ForProvider.UnassignOnDelete = &unassign
+	}
+}
+
+func reservedIP(m ...reservedIPModifier) *v1alpha1.ReservedIP {
+	cr := &v1alpha1.ReservedIP{}
+	cr.SetName("example")
+	meta.SetExternalName(cr, testIP)
+	cr.Status.AtProvider.Region = "nyc3"
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// actions returns a handler that records the types of the actions requested
+// on the reserved IP.
+func actions(t *testing.T, got *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Type      string `json:"type"`
+			DropletID int    `json:"droplet_id"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		*got = append(*got, req.Type)
+		w.WriteHeader(http.StatusCreated)
+		fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: req.Type, Status: godo.ActionInProgress}})(w, r)
+	}
+}
+
+// droplets returns routes that serve Droplet 1234 in region nyc3 and
+// Droplet 5678 in region ams3.
+func droplets(t *testing.T) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"GET /v2/droplets/1234": fake.Respond(t, map[string]interface{}{"droplet": godo.Droplet{ID: 1234, Region: &godo.Region{Slug: "nyc3"}}}),
+		"GET /v2/droplets/5678": fake.Respond(t, map[string]interface{}{"droplet": godo.Droplet{ID: 5678, Region: &godo.Region{Slug: "ams3"}}}),
+	}
+}
+
+func TestReservedIPUpdate(t *testing.T) {
+	type want struct {
+		err     error
+		actions []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.ReservedIP
+		want   want
+	}{
+		"Assign": {
+			reason: "A reserved IP should be assigned to the Droplet it declares.",
+			cr:     reservedIP(withDropletID(1234)),
+			want:   want{actions: []string{"assign"}},
+		},
+		"Unassign": {
+			reason: "A reserved IP that declares no Droplet should be unassigned.",
+			cr:     reservedIP(withAssigned(1234)),
+			want:   want{actions: []string{"unassign"}},
+		},
+		"Locked": {
+			reason: "A reserved IP that is locked by an action in progress should not be reassigned.",
+			cr: reservedIP(withDropletID(1234), func(cr *v1alpha1.ReservedIP) {
+				cr.Status.AtProvider.Locked = true
+			}),
+		},
+		"RegionMismatch": {
+			reason: "A reserved IP should not be assigned to a Droplet in another region than it is reserved in.",
+			cr:     reservedIP(withDropletID(5678)),
+			want:   want{err: errors.Errorf(errRegionMismatch, "nyc3", 5678, "ams3")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			routes := droplets(t)
+			routes["POST /v2/reserved_ips/"+testIP+"/actions"] = actions(t, &got.actions)
+			e := &reservedIPExternal{Client: fake.NewClient(t, fake.Routes(t, routes))}
+			_, got.err = e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReservedIPCreate(t *testing.T) {
+	type want struct {
+		err     error
+		request *doreservedip.CreateRequest
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.ReservedIPParameters
+		want   want
+	}{
+		"Region": {
+			reason: "A reserved IP that declares no Droplet should be reserved in its region.",
+			params: v1alpha1.ReservedIPParameters{Region: stringPtr("nyc3")},
+			want:   want{request: &doreservedip.CreateRequest{Region: "nyc3"}},
+		},
+		"RegionMatches": {
+			reason: "A reserved IP whose region matches its Droplet should be reserved by assigning it to the Droplet.",
+			params: v1alpha1.ReservedIPParameters{Region: stringPtr("nyc3"), DropletID: intPtr(1234)},
+			want:   want{request: &doreservedip.CreateRequest{DropletID: 1234}},
+		},
+		"RegionMismatch": {
+			reason: "A reserved IP whose region does not match its Droplet should not be reserved.",
+			params: v1alpha1.ReservedIPParameters{Region: stringPtr("nyc3"), DropletID: intPtr(5678)},
+			want:   want{err: errors.Errorf(errRegionMismatch, "nyc3", 5678, "ams3")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			routes := droplets(t)
+			routes["POST /v2/reserved_ips"] = func(w http.ResponseWriter, r *http.Request) {
+				got.request = &doreservedip.CreateRequest{}
+				if err := json.NewDecoder(r.Body).Decode(got.request); err != nil {
+					t.Error(err)
+				}
+				w.WriteHeader(http.StatusAccepted)
+				fake.Respond(t, map[string]interface{}{"reserved_ip": doreservedip.ReservedIP{IP: testIP}})(w, r)
+			}
+			cr := &v1alpha1.ReservedIP{}
+			cr.Spec.ForProvider = tc.params
+			e := &reservedIPExternal{Client: fake.NewClient(t, fake.Routes(t, routes))}
+			_, got.err = e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReservedIPDelete(t *testing.T) {
+	type want struct {
+		err   error
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.ReservedIP
+		want   want
+	}{
+		"Unassigned": {
+			reason: "A reserved IP that is not assigned should be released.",
+			cr:     reservedIP(),
+			want:   want{calls: []string{"delete"}},
+		},
+		"Assigned": {
+			reason: "A reserved IP that is assigned to a Droplet should not be released unless unassignOnDelete is set.",
+			cr:     reservedIP(withAssigned(1234)),
+			want:   want{err: errors.Errorf(errAssignedOnDelete, testIP, 1234)},
+		},
+		"UnassignOnDelete": {
+			reason: "A reserved IP that is assigned to a Droplet should be unassigned and then released if unassignOnDelete is set.",
+			cr:     reservedIP(withAssigned(1234), withUnassignOnDelete()),
+			want:   want{calls: []string{"unassign", "delete"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/reserved_ips/" + testIP + "/actions": actions(t, &got.calls),
+				"GET /v2/actions/1":                            fake.Respond(t, map[string]interface{}{"action": godo.Action{ID: 1, Type: "unassign", Status: godo.ActionCompleted}}),
+				"DELETE /v2/reserved_ips/" + testIP: func(w http.ResponseWriter, r *http.Request) {
+					got.calls = append(got.calls, "delete")
+					w.WriteHeader(http.StatusNoContent)
+				},
+			})
+			e := &reservedIPExternal{Client: fake.NewClient(t, h)}
+			got.err = e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// recorder returns a handler that records the method and path of requests,
+// and responds with an empty object.
+func recorder(got *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*got = append(*got, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestReservedIPCreateProject(t *testing.T) {
+	var got []string
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"POST /v2/reserved_ips": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			fake.Respond(t, map[string]interface{}{"reserved_ip": doreservedip.ReservedIP{IP: testIP}})(w, r)
+		},
+		"POST /v2/projects/p-1/resources": func(w http.ResponseWriter, r *http.Request) {
+			req := struct {
+				Resources []string `json:"resources"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+			got = append(got, req.Resources...)
+			_, _ = w.Write([]byte(`{"resources":[]}`))
+		},
+	})
+	cr := &v1alpha1.ReservedIP{}
+	cr.Spec.ForProvider = v1alpha1.ReservedIPParameters{Region: stringPtr("nyc3"), ProjectID: stringPtr("p-1")}
+	e := &reservedIPExternal{Client: fake.NewClient(t, h)}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"do:floatingip:" + testIP}, got); diff != "" {
+		t.Errorf("e.Create(...): assigned resources: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestReservedIPUpdateProjectAndTags(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.ReservedIP
+		want   []string
+	}{
+		"ProjectChanged": {
+			reason: "A reserved IP should be assigned to the project it declares if it belongs to another.",
+			cr: reservedIP(func(cr *v1alpha1.ReservedIP) {
+				cr.Spec.ForProvider.ProjectID = stringPtr("p-1")
+				cr.Status.AtProvider.ProjectID = "p-0"
+			}),
+			want: []string{"POST /v2/projects/p-1/resources"},
+		},
+		"TagsDrifted": {
+			reason: "A reserved IP should gain the tags it declares, and lose the tags it does not.",
+			cr: reservedIP(func(cr *v1alpha1.ReservedIP) {
+				cr.Spec.ForProvider.Tags = []string{"web", "prod"}
+				cr.Status.AtProvider.Tags = []string{"web", "old"}
+			}),
+			want: []string{"POST /v2/tags", "POST /v2/tags/prod/resources", "DELETE /v2/tags/old/resources"},
+		},
+		"UpToDate": {
+			reason: "A reserved IP whose project and tags are up to date should not be changed.",
+			cr: reservedIP(func(cr *v1alpha1.ReservedIP) {
+				cr.Spec.ForProvider.ProjectID = stringPtr("p-1")
+				cr.Spec.ForProvider.Tags = []string{"web"}
+				cr.Status.AtProvider.ProjectID = "p-1"
+				cr.Status.AtProvider.Tags = []string{"web"}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/projects/p-1/resources": recorder(&got),
+				"POST /v2/tags":                   recorder(&got),
+				"POST /v2/tags/prod/resources":    recorder(&got),
+				"DELETE /v2/tags/old/resources":   recorder(&got),
+			})
+			e := &reservedIPExternal{Client: fake.NewClient(t, h)}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("e.Update(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
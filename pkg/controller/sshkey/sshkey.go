@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/sshkey/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dosshkey "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/sshkey"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotSSHKey     = "managed resource is not an SSHKey resource"
+	errGetSSHKey     = "cannot get SSHKey"
+	errExternalName  = "external-name of SSHKey is not an SSH key ID"
+	errGetPublicKey  = "cannot get public key of SSHKey"
+	errInvalidKey    = "public key of SSHKey is invalid"
+	errSSHKeyRenamed = "cannot rename SSHKey"
+
+	errSSHKeyCreateFailed = "creation of SSHKey resource has failed"
+	errSSHKeyDeleteFailed = "deletion of SSHKey resource has failed"
+)
+
+// SetupSSHKey adds a controller that reconciles SSHKey managed resources.
+func SetupSSHKey(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.SSHKeyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.SSHKey{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SSHKeyGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.SSHKeyGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &sshKeyConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type sshKeyConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *sshKeyConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &sshKeyExternal{Client: client, kube: c.kube}, nil
+}
+
+type sshKeyExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *sshKeyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SSHKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSSHKey)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errExternalName)
+	}
+
+	observed, response, err := c.Keys.GetByID(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetSSHKey)
+	}
+
+	cr.Status.AtProvider = dosshkey.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+
+	// SSH keys cannot be changed, so a key that no longer matches the one
+	// it was created from is reported rather than replaced.
+	if !meta.WasDeleted(cr) {
+		_, fingerprint, err := c.publicKey(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		cr.SetConditions(do.ImmutableFieldCondition(do.ImmutableFields{
+			"publicKey": fingerprint == observed.Fingerprint,
+		}))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: observed.Name == cr.GetName(),
+	}, nil
+}
+
+// publicKey returns the validated public key of the supplied SSHKey, read
+// from its Secret if it references one, and its fingerprint.
+func (c *sshKeyExternal) publicKey(ctx context.Context, cr *v1alpha1.SSHKey) (key, fingerprint string, err error) {
+	p := cr.Spec.ForProvider
+	if err := dosshkey.ValidateParameters(p); err != nil {
+		return "", "", err
+	}
+	key = do.StringValue(p.PublicKey)
+	if ref := p.PublicKeySecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+			return "", "", errors.Wrap(err, errGetPublicKey)
+		}
+		key = string(s.Data[ref.Key])
+	}
+	fingerprint, err = dosshkey.Fingerprint(key)
+	return key, fingerprint, errors.Wrap(err, errInvalidKey)
+}
+
+func (c *sshKeyExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SSHKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSSHKey)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	key, _, err := c.publicKey(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	created, _, err := c.Keys.Create(ctx, &godo.KeyCreateRequest{Name: cr.GetName(), PublicKey: key})
+	if err != nil || created == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSSHKeyCreateFailed)
+	}
+
+	meta.SetExternalName(cr, strconv.Itoa(created.ID))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (c *sshKeyExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SSHKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSSHKey)
+	}
+
+	// Only the name of an SSH key can be changed.
+	_, _, err := c.Keys.UpdateByID(ctx, cr.Status.AtProvider.ID, &godo.KeyUpdateRequest{Name: cr.GetName()})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errSSHKeyRenamed)
+}
+
+func (c *sshKeyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SSHKey)
+	if !ok {
+		return errors.New(errNotSSHKey)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Keys.DeleteByID(ctx, cr.Status.AtProvider.ID)
+	return errors.Wrap(do.IgnoreNotFound(err, response), errSSHKeyDeleteFailed)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/sshkey/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const (
+	testPublicKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f user@example"
+	testFingerprint = "0f:a2:0a:d7:38:3e:65:45:08:6b:63:84:1c:ff:dc:ba"
+)
+
+func stringPtr(s string) *string { return &s }
+
+// keySecret returns a client that holds a Secret with the supplied public key
+// under the key "id_ed25519.pub".
+func keySecret(publicKey string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			s, ok := obj.(*corev1.Secret)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			s.Data = map[string][]byte{"id_ed25519.pub": []byte(publicKey)}
+			return nil
+		}),
+	}
+}
+
+var secretRef = &xpv1.SecretKeySelector{
+	SecretReference: xpv1.SecretReference{Name: "keys", Namespace: "default"},
+	Key:             "id_ed25519.pub",
+}
+
+func TestSSHKeyCreate(t *testing.T) {
+	type want struct {
+		publicKey    string
+		externalName string
+		err          bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.SSHKeyParameters
+		secret string
+		want   want
+	}{
+		"Inline": {
+			reason: "An SSH key should be registered with the public key it declares.",
+			params: v1alpha1.SSHKeyParameters{PublicKey: stringPtr(testPublicKey)},
+			want:   want{publicKey: testPublicKey, externalName: "42"},
+		},
+		"Secret": {
+			reason: "An SSH key should be registered with the public key held by the Secret it references.",
+			params: v1alpha1.SSHKeyParameters{PublicKeySecretRef: secretRef},
+			secret: testPublicKey,
+			want:   want{publicKey: testPublicKey, externalName: "42"},
+		},
+		"InvalidSecret": {
+			reason: "An SSH key should not be registered if the Secret it references holds no valid public key.",
+			params: v1alpha1.SSHKeyParameters{PublicKeySecretRef: secretRef},
+			secret: "not a public key",
+			want:   want{err: true},
+		},
+		"Both": {
+			reason: "An SSH key should not be registered if it declares a public key and references a Secret.",
+			params: v1alpha1.SSHKeyParameters{PublicKey: stringPtr(testPublicKey), PublicKeySecretRef: secretRef},
+			secret: testPublicKey,
+			want:   want{err: true},
+		},
+		"Neither": {
+			reason: "An SSH key should not be registered if it declares no public key.",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/account/keys": func(w http.ResponseWriter, r *http.Request) {
+					req := &godo.KeyCreateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+						t.Error(err)
+					}
+					got.publicKey = req.PublicKey
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"ssh_key": godo.Key{ID: 42, Name: req.Name, PublicKey: req.PublicKey}})(w, r)
+				},
+			})
+			cr := &v1alpha1.SSHKey{}
+			cr.SetName("example")
+			cr.Spec.ForProvider = tc.params
+			e := &sshKeyExternal{Client: fake.NewClient(t, h), kube: keySecret(tc.secret)}
+
+			_, err := e.Create(context.Background(), cr)
+			got.externalName, got.err = meta.GetExternalName(cr), err != nil
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSSHKeyObserve(t *testing.T) {
+	type want struct {
+		ready     xpv1.Condition
+		immutable bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		secret string
+		want   want
+	}{
+		"Unchanged": {
+			reason: "An SSH key whose Secret holds the registered public key should be available and unchanged.",
+			secret: testPublicKey,
+			want:   want{ready: xpv1.Available()},
+		},
+		"Changed": {
+			reason: "An SSH key whose Secret holds another public key should report the change, since keys cannot be changed.",
+			secret: "ssh-rsa AAAAB3NzaC1yc2EAAAABAw==",
+			want:   want{ready: xpv1.Available(), immutable: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/account/keys/42": fake.Respond(t, map[string]interface{}{"ssh_key": godo.Key{ID: 42, Name: "example", Fingerprint: testFingerprint}}),
+			})
+			cr := &v1alpha1.SSHKey{}
+			cr.SetName("example")
+			meta.SetExternalName(cr, "42")
+			cr.Spec.ForProvider.PublicKeySecretRef = secretRef
+			e := &sshKeyExternal{Client: fake.NewClient(t, h), kube: keySecret(tc.secret)}
+
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): %v", err)
+			}
+			if !o.ResourceExists || !o.ResourceUpToDate {
+				t.Errorf("\n%s\ne.Observe(...): want existing and up to date, got %+v", tc.reason, o)
+			}
+			got := want{
+				ready:     cr.GetCondition(xpv1.TypeReady),
+				immutable: cr.GetCondition(do.TypeImmutableFieldChanged).Status == corev1.ConditionTrue,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/storage/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dostorage "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/storage"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotVolume   = "managed resource is not a Volume resource"
+	errGetVolume   = "cannot get Volume"
+	errListVolumes = "cannot list Volumes"
+	errNoRegion    = "cannot look up Volume %q by name: spec.forProvider.region is required"
+
+	errVolumeCreateFailed = "creation of Volume resource has failed"
+	errVolumeDeleteFailed = "deletion of Volume resource has failed"
+	errVolumeUpdate       = "cannot update managed Volume resource"
+	errResize             = "cannot resize Volume"
+	errTag                = "cannot tag Volume with %q"
+	errUntag              = "cannot remove tag %q from Volume"
+)
+
+// SetupVolume adds a controller that reconciles Volume managed resources.
+func SetupVolume(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.VolumeGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Volume{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.VolumeGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.VolumeGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &volumeConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type volumeConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *volumeConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &volumeExternal{Client: client, kube: c.kube}, nil
+}
+
+type volumeExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *volumeExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Volume)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotVolume)
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// An external-name that is not an ID names an existing volume to
+	// import. Volumes are only unique by name within a region, so it is
+	// looked up in the region of the spec.
+	if !do.IsUUID(id) {
+		if cr.Spec.ForProvider.Region == "" {
+			return managed.ExternalObservation{}, errors.Errorf(errNoRegion, id)
+		}
+		adopted, err := do.AdoptByName(ctx, c.kube, cr, c.findByName(cr.Spec.ForProvider.Region))
+		if err != nil || adopted == "" {
+			return managed.ExternalObservation{ResourceExists: false}, err
+		}
+		id = adopted
+	}
+
+	observed, response, err := c.Storage.GetVolume(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetVolume)
+	}
+
+	if do.ShouldLateInitialize(cr) {
+		original := cr.DeepCopy()
+		dostorage.LateInitializeVolume(&cr.Spec.ForProvider, *observed)
+		if !cmp.Equal(original.Spec.ForProvider, cr.Spec.ForProvider) {
+			if err := do.PatchSpec(ctx, c.kube, original, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errVolumeUpdate)
+			}
+		}
+	}
+
+	cr.Status.AtProvider = dostorage.GenerateObservation(*observed)
+	do.SetAvailableOnObserve(cr)
+	cr.SetConditions(do.ImmutableFieldCondition(dostorage.ImmutableFields(cr.Spec.ForProvider, *observed)))
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !dostorage.NeedsResize(cr.Spec.ForProvider, *observed) && do.ProjectUpToDate(cr, cr.Spec.ForProvider.ProjectID) && dostorage.TagsUpToDate(cr.Spec.ForProvider, cr.Status.AtProvider),
+	}, nil
+}
+
+// findByName returns a FindByName that finds the volumes in the supplied
+// region.
+func (c *volumeExternal) findByName(region string) do.FindByName {
+	return func(ctx context.Context, name string) ([]string, error) {
+		var ids []string
+		opts := &godo.ListVolumeParams{Name: name, Region: region, ListOptions: &godo.ListOptions{PerPage: 200}}
+		for {
+			page, resp, err := c.Storage.ListVolumes(ctx, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, errListVolumes)
+			}
+			for _, v := range page {
+				if v.Name == name && v.Region != nil && v.Region.Slug == region {
+					ids = append(ids, v.ID)
+				}
+			}
+			if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+				return ids, nil
+			}
+			current, err := resp.Links.CurrentPage()
+			if err != nil {
+				return nil, err
+			}
+			opts.ListOptions.Page = current + 1
+		}
+	}
+}
+
+func (c *volumeExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Volume)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotVolume)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	if err := dostorage.ValidateVolume(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	volume, _, err := c.Storage.CreateVolume(ctx, dostorage.GenerateVolume(cr.GetName(), cr.Spec.ForProvider))
+	if err != nil || volume == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errVolumeCreateFailed)
+	}
+
+	meta.SetExternalName(cr, volume.ID)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, do.AssignProject(ctx, c.Client, cr, cr.Spec.ForProvider.ProjectID, volume.URN())
+}
+
+func (c *volumeExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Volume)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotVolume)
+	}
+
+	id := meta.GetExternalName(cr)
+	if err := do.UpdateProject(ctx, c.Client, c.kube, cr, cr.Spec.ForProvider.ProjectID, godo.ToURN("Volume", id)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.updateTags(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Growing the volume is the only other change that can be applied.
+	// Changes to its other fields are reported by the ImmutableFieldChanged
+	// condition. A resize of a volume that is still locked by a previous
+	// action is retried on the next reconcile.
+	if cr.Spec.ForProvider.SizeGigabytes <= cr.Status.AtProvider.SizeGigabytes {
+		return managed.ExternalUpdate{}, nil
+	}
+	_, _, err := c.StorageActions.Resize(ctx, id, int(cr.Spec.ForProvider.SizeGigabytes), cr.Spec.ForProvider.Region)
+	return managed.ExternalUpdate{}, errors.Wrap(do.IgnoreLocked(err), errResize)
+}
+
+// updateTags tags the supplied volume with the tags it declares but lacks,
+// and removes the tags it carries but must not. Tags are created before they
+// are applied, since only existing tags can be.
+func (c *volumeExternal) updateTags(ctx context.Context, cr *v1alpha1.Volume) error {
+	resources := []godo.Resource{{ID: meta.GetExternalName(cr), Type: godo.VolumeResourceType}}
+	add, remove := dostorage.TagsDiff(cr.Spec.ForProvider, cr.Status.AtProvider)
+	for _, tag := range add {
+		if _, _, err := c.Tags.Create(ctx, &godo.TagCreateRequest{Name: tag}); err != nil {
+			return errors.Wrapf(err, errTag, tag)
+		}
+		if _, err := c.Tags.TagResources(ctx, tag, &godo.TagResourcesRequest{Resources: resources}); err != nil {
+			return errors.Wrapf(err, errTag, tag)
+		}
+	}
+	for _, tag := range remove {
+		if response, err := c.Tags.UntagResources(ctx, tag, &godo.UntagResourcesRequest{Resources: resources}); do.IgnoreNotFound(err, response) != nil {
+			return errors.Wrapf(err, errUntag, tag)
+		}
+	}
+	return nil
+}
+
+func (c *volumeExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Volume)
+	if !ok {
+		return errors.New(errNotVolume)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	response, err := c.Storage.DeleteVolume(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errVolumeDeleteFailed)
+}
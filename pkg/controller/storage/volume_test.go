@@ -0,0 +1,383 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	projectv1alpha1 "github.com/crossplane-contrib/provider-digitalocean/apis/project/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/apis/storage/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const volumeID = "506f78a4-e098-11e5-ad9f-000f53306ae1"
+
+func stringPtr(s string) *string { return &s }
+
+func volume(p v1alpha1.VolumeParameters) *v1alpha1.Volume {
+	cr := &v1alpha1.Volume{}
+	cr.SetName("example")
+	cr.Spec.ForProvider = p
+	return cr
+}
+
+func TestVolumeCreate(t *testing.T) {
+	type want struct {
+		err     error
+		request *godo.VolumeCreateRequest
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.VolumeParameters
+		want   want
+	}{
+		"Formatted": {
+			reason: "The filesystem type and label should be sent with the create request, so the volume is formatted at creation.",
+			params: v1alpha1.VolumeParameters{
+				Region:          "nyc1",
+				SizeGigabytes:   10,
+				Description:     stringPtr("data"),
+				FilesystemType:  stringPtr(v1alpha1.FilesystemExt4),
+				FilesystemLabel: stringPtr("data"),
+			},
+			want: want{request: &godo.VolumeCreateRequest{
+				Name:            "example",
+				Region:          "nyc1",
+				SizeGigaBytes:   10,
+				Description:     "data",
+				FilesystemType:  "ext4",
+				FilesystemLabel: "data",
+			}},
+		},
+		"Unformatted": {
+			reason: "A volume without a filesystem type should be created without formatting it.",
+			params: v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10},
+			want: want{request: &godo.VolumeCreateRequest{
+				Name:          "example",
+				Region:        "nyc1",
+				SizeGigaBytes: 10,
+			}},
+		},
+		"LabelWithoutFilesystem": {
+			reason: "A filesystem label without a filesystem type should be rejected before the volume is created.",
+			params: v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10, FilesystemLabel: stringPtr("data")},
+			want:   want{err: errors.New("spec.forProvider.filesystemLabel requires spec.forProvider.filesystemType")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *godo.VolumeCreateRequest
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/volumes": func(w http.ResponseWriter, r *http.Request) {
+					got = &godo.VolumeCreateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"volume": godo.Volume{ID: volumeID, Name: got.Name}})(w, r)
+				},
+			})
+			cr := volume(tc.params)
+			e := &volumeExternal{Client: fake.NewClient(t, h)}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.request, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want request, +got request:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err == nil && meta.GetExternalName(cr) != volumeID {
+				t.Errorf("\n%s\ne.Create(...): want external-name %q, got %q", tc.reason, volumeID, meta.GetExternalName(cr))
+			}
+		})
+	}
+}
+
+func TestVolumeObserveLateInitFilesystem(t *testing.T) {
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"GET /v2/volumes/" + volumeID: fake.Respond(t, map[string]interface{}{"volume": godo.Volume{
+			ID:              volumeID,
+			Name:            "example",
+			Region:          &godo.Region{Slug: "nyc1"},
+			SizeGigaBytes:   10,
+			FilesystemType:  "xfs",
+			FilesystemLabel: "data",
+		}}),
+	})
+	cr := volume(v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10})
+	meta.SetExternalName(cr, volumeID)
+	e := &volumeExternal{
+		Client: fake.NewClient(t, h),
+		kube:   &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error { return nil }},
+	}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !o.ResourceUpToDate {
+		t.Errorf("e.Observe(...): a Volume late initialized with its filesystem should be up to date")
+	}
+	want := v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10, FilesystemType: stringPtr("xfs"), FilesystemLabel: stringPtr("data")}
+	if diff := cmp.Diff(want, cr.Spec.ForProvider); diff != "" {
+		t.Errorf("e.Observe(...): -want spec, +got spec:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(xpv1.Available(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Observe(...): a Volume has no status and should be available as soon as it exists: -want, +got:\n%s\n", diff)
+	}
+}
+
+const projectID = "4e1bfbc3-dc3e-41f2-a18f-1b4d7ba71679"
+
+// assignments returns a handler that records the URNs of the resources that
+// are assigned to a project.
+func assignments(t *testing.T, got *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Resources []string `json:"resources"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		*got = append(*got, req.Resources...)
+		fake.Respond(t, map[string]interface{}{"resources": []godo.ProjectResource{}})(w, r)
+	}
+}
+
+func TestVolumeProjectResolution(t *testing.T) {
+	cr := volume(v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10})
+	cr.Spec.ForProvider.ProjectRef = &xpv1.Reference{Name: "example"}
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			p, ok := obj.(*projectv1alpha1.Project)
+			if !ok {
+				return errors.Errorf("unexpected object %T", obj)
+			}
+			p.SetName("example")
+			meta.SetExternalName(p, projectID)
+			return nil
+		}),
+	}
+	if err := cr.ResolveReferences(context.Background(), kube); err != nil {
+		t.Fatalf("cr.ResolveReferences(...): %v", err)
+	}
+	if diff := cmp.Diff(projectID, do.StringValue(cr.Spec.ForProvider.ProjectID)); diff != "" {
+		t.Errorf("cr.ResolveReferences(...): -want ID, +got ID:\n%s", diff)
+	}
+}
+
+func TestVolumeProjectAssignment(t *testing.T) {
+	type want struct {
+		assigned []string
+		project  string
+		updated  bool
+	}
+
+	urn := "do:volume:" + volumeID
+	cases := map[string]struct {
+		reason   string
+		assigned string
+		create   bool
+		want     want
+	}{
+		"Create": {
+			reason: "A new Volume should be assigned to its project.",
+			create: true,
+			want:   want{assigned: []string{urn}, project: projectID},
+		},
+		"Moved": {
+			reason:   "A Volume whose project changed should be moved to it and the assignment persisted.",
+			assigned: "aa9ac182-6b92-4b8e-81ea-d3ed1ad4e776",
+			want:     want{assigned: []string{urn}, project: projectID, updated: true},
+		},
+		"UpToDate": {
+			reason:   "A Volume that was assigned to its project should not be assigned again.",
+			assigned: projectID,
+			want:     want{project: projectID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/projects/" + projectID + "/resources": assignments(t, &got.assigned),
+				"POST /v2/volumes": func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"volume": godo.Volume{ID: volumeID}})(w, r)
+				},
+			})
+			cr := volume(v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10, ProjectID: stringPtr(projectID)})
+			cr.Status.AtProvider.SizeGigabytes = 10
+			if !tc.create {
+				meta.SetExternalName(cr, volumeID)
+			}
+			if tc.assigned != "" {
+				meta.AddAnnotations(cr, map[string]string{do.AnnotationProject: tc.assigned})
+			}
+			e := &volumeExternal{
+				Client: fake.NewClient(t, h),
+				kube: &test.MockClient{MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					got.updated = true
+					return nil
+				}},
+			}
+			var err error
+			if tc.create {
+				_, err = e.Create(context.Background(), cr)
+			} else {
+				_, err = e.Update(context.Background(), cr)
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nunexpected error: %v", tc.reason, err)
+			}
+			got.project = cr.GetAnnotations()[do.AnnotationProject]
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestVolumeObserveAdoptByName(t *testing.T) {
+	existing := godo.Volume{ID: volumeID, Name: "data", Region: &godo.Region{Slug: "nyc1"}, SizeGigaBytes: 10}
+	elsewhere := godo.Volume{ID: "7724db7c-e098-11e5-b522-000f53304e51", Name: "data", Region: &godo.Region{Slug: "ams3"}, SizeGigaBytes: 10}
+
+	type want struct {
+		exists       bool
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		region  string
+		volumes []godo.Volume
+		want    want
+	}{
+		"Adopt": {
+			reason:  "A Volume whose external-name is the name of a volume in its region should adopt it.",
+			region:  "nyc1",
+			volumes: []godo.Volume{existing},
+			want:    want{exists: true, externalName: volumeID},
+		},
+		"SameNameElsewhere": {
+			reason:  "A Volume should only adopt the volume with its external-name in its region.",
+			region:  "nyc1",
+			volumes: []godo.Volume{existing, elsewhere},
+			want:    want{exists: true, externalName: volumeID},
+		},
+		"Missing": {
+			reason:  "A Volume whose external-name names no volume in its region should not exist.",
+			region:  "nyc1",
+			volumes: []godo.Volume{elsewhere},
+			want:    want{externalName: "data"},
+		},
+		"Ambiguous": {
+			reason:  "A Volume should not adopt a volume whose name is not unique in its region.",
+			region:  "nyc1",
+			volumes: []godo.Volume{existing, existing},
+			want:    want{externalName: "data", err: errors.Errorf("cannot adopt existing resource: %d resources are named %q", 2, "data")},
+		},
+		"NoRegion": {
+			reason: "A Volume should not look up a volume by name without a region, since names are only unique within one.",
+			want:   want{externalName: "data", err: errors.Errorf(errNoRegion, "data")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/volumes": func(w http.ResponseWriter, r *http.Request) {
+					if got := r.URL.Query().Get("name"); got != "data" {
+						t.Errorf("GET /v2/volumes: name = %q, want %q", got, "data")
+					}
+					// The region is not filtered here, so that the
+					// controller is seen to tell volumes apart.
+					fake.Respond(t, map[string]interface{}{"volumes": tc.volumes})(w, r)
+				},
+				"GET /v2/volumes/" + volumeID: fake.Respond(t, map[string]interface{}{"volume": existing}),
+			})
+			cr := volume(v1alpha1.VolumeParameters{Region: tc.region, SizeGigabytes: 10})
+			meta.SetExternalName(cr, "data")
+			e := &volumeExternal{
+				Client: fake.NewClient(t, h),
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+					MockPatch:  test.NewMockPatchFn(nil),
+				},
+			}
+			o, err := e.Observe(context.Background(), cr)
+			got := want{exists: o.ResourceExists, externalName: meta.GetExternalName(cr), err: err}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestVolumeUpdateTags(t *testing.T) {
+	var got []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}
+	h := fake.Routes(t, map[string]http.HandlerFunc{
+		"POST /v2/tags":                         record,
+		"POST /v2/tags/team:storage/resources":  record,
+		"DELETE /v2/tags/cost-center/resources": record,
+	})
+
+	cr := volume(v1alpha1.VolumeParameters{Region: "nyc1", SizeGigabytes: 10, Tags: []string{"env:prod", "team:storage"}})
+	meta.SetExternalName(cr, volumeID)
+	cr.Status.AtProvider = v1alpha1.VolumeObservation{SizeGigabytes: 10, Tags: []string{"env:prod", "cost-center"}}
+	e := &volumeExternal{Client: fake.NewClient(t, h)}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := []string{"POST /v2/tags", "POST /v2/tags/team:storage/resources"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): a Volume that does not manage its tags should only add them: -want requests, +got requests:\n%s", diff)
+	}
+
+	got = nil
+	managed := true
+	cr.Spec.ForProvider.ManageTags = &managed
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want = []string{"POST /v2/tags", "POST /v2/tags/team:storage/resources", "DELETE /v2/tags/cost-center/resources"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): a Volume that manages its tags should remove the others: -want requests, +got requests:\n%s", diff)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotTag = "managed resource is not a Tag resource"
+	errGetTag = "cannot get Tag"
+
+	errTagCreateFailed = "creation of Tag resource has failed"
+	errTagExists       = "tag %q already exists: set adoptExisting to adopt it"
+	errTagDeleteFailed = "deletion of Tag resource has failed"
+)
+
+// SetupTag adds a controller that reconciles Tag managed resources.
+func SetupTag(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.TagGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Tag{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.TagGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.TagGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &tagConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type tagConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *tagConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &tagExternal{Client: client, kube: c.kube}, nil
+}
+
+type tagExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *tagExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Tag)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTag)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, response, err := c.Tags.Get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetTag)
+	}
+
+	cr.Status.AtProvider = v1alpha1.TagObservation{Name: observed.Name}
+	if observed.Resources != nil {
+		cr.Status.AtProvider.ResourceCount = observed.Resources.Count
+		cr.Status.AtProvider.LastTaggedURI = observed.Resources.LastTaggedURI
+	}
+	do.SetAvailableOnObserve(cr)
+
+	// A tag has no settings besides its name, so it is always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *tagExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Tag)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTag)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		name = cr.GetName()
+	}
+
+	tag, response, err := c.Tags.Create(ctx, &godo.TagCreateRequest{Name: name})
+	if err != nil && response != nil && response.StatusCode == http.StatusUnprocessableEntity {
+		existing, err := c.existing(ctx, name, err)
+		if err != nil {
+			return managed.ExternalCreation{}, err
+		}
+		// The tag was created outside of Crossplane. It is only adopted
+		// if that was asked for, since deleting the Tag would remove a
+		// tag that may be in use by resources Crossplane does not manage.
+		if !do.BoolValue(cr.Spec.ForProvider.AdoptExisting) {
+			return managed.ExternalCreation{}, errors.Errorf(errTagExists, name)
+		}
+		tag = existing
+	} else if err != nil || tag == nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errTagCreateFailed)
+	}
+
+	meta.SetExternalName(cr, tag.Name)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// existing returns the tag with the supplied name that already exists, given
+// the supplied error a create call for it was rejected with. The create error
+// is returned if there is no such tag, e.g. because its name is invalid.
+func (c *tagExternal) existing(ctx context.Context, name string, createErr error) (*godo.Tag, error) {
+	tag, response, err := c.Tags.Get(ctx, name)
+	if do.IgnoreNotFound(err, response) != nil {
+		return nil, errors.Wrap(err, errGetTag)
+	}
+	if err != nil || tag == nil {
+		return nil, errors.Wrap(createErr, errTagCreateFailed)
+	}
+	return tag, nil
+}
+
+func (c *tagExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Tags cannot be updated: renaming a tag creates a new one.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *tagExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Tag)
+	if !ok {
+		return errors.New(errNotTag)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// Deleting a tag removes it from all resources it is applied to.
+	response, err := c.Tags.Delete(ctx, meta.GetExternalName(cr))
+	return errors.Wrap(do.IgnoreNotFound(err, response), errTagDeleteFailed)
+}
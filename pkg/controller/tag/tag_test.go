@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/tag/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+func tag(externalName string) *v1alpha1.Tag {
+	cr := &v1alpha1.Tag{}
+	cr.SetName("web")
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func TestTagObserve(t *testing.T) {
+	type want struct {
+		o    managed.ExternalObservation
+		at   v1alpha1.TagObservation
+		cond xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Tag
+		h      http.HandlerFunc
+		want   want
+	}{
+		"NotCreated": {
+			reason: "A Tag without an external-name should not exist.",
+			cr:     tag(""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NotFound": {
+			reason: "A Tag that was deleted outside of Crossplane should not exist.",
+			cr:     tag("web"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Exists": {
+			reason: "A Tag should be available and up to date as soon as it is observed.",
+			cr:     tag("team:web"),
+			h: fake.Routes(t, map[string]http.HandlerFunc{
+				"GET /v2/tags/team:web": fake.Respond(t, map[string]interface{}{"tag": godo.Tag{
+					Name:      "team:web",
+					Resources: &godo.TaggedResources{Count: 3, LastTaggedURI: "https://api.digitalocean.com/v2/droplets/13457723"},
+				}}),
+			}),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				at: v1alpha1.TagObservation{
+					Name:          "team:web",
+					ResourceCount: 3,
+					LastTaggedURI: "https://api.digitalocean.com/v2/droplets/13457723",
+				},
+				cond: xpv1.Available(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &tagExternal{Client: fake.NewClient(t, tc.h)}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.at, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cond, tc.cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); tc.want.cond.Type != "" && diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTagCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Tag
+		want   string
+	}{
+		"DefaultName": {
+			reason: "A Tag without an external-name should be named after the managed resource.",
+			cr:     tag(""),
+			want:   "web",
+		},
+		"ExternalName": {
+			reason: "A Tag should be named after its external-name, which need not be a valid Kubernetes name.",
+			cr:     tag("team:web"),
+			want:   "team:web",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			h := fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/tags": func(w http.ResponseWriter, r *http.Request) {
+					req := &godo.TagCreateRequest{}
+					if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+						t.Error(err)
+					}
+					got = req.Name
+					w.WriteHeader(http.StatusCreated)
+					fake.Respond(t, map[string]interface{}{"tag": godo.Tag{Name: req.Name}})(w, r)
+				},
+			})
+			e := &tagExternal{Client: fake.NewClient(t, h)}
+			if _, err := e.Create(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external-name, +got external-name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTagCreateExisting(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	exists := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"id":"unprocessable_entity","message":"tag already exists"}`))
+	}
+
+	cases := map[string]struct {
+		reason string
+		adopt  *bool
+		h      http.HandlerFunc
+		want   want
+	}{
+		"Adopt": {
+			reason: "A Tag that already exists should be adopted if adoptExisting is set.",
+			adopt:  boolPtr(true),
+			h: fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/tags":         exists,
+				"GET /v2/tags/team:web": fake.Respond(t, map[string]interface{}{"tag": godo.Tag{Name: "team:web"}}),
+			}),
+			want: want{externalName: "team:web"},
+		},
+		"Conflict": {
+			reason: "A Tag that already exists should not be adopted unless adoptExisting is set.",
+			h: fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/tags":         exists,
+				"GET /v2/tags/team:web": fake.Respond(t, map[string]interface{}{"tag": godo.Tag{Name: "team:web"}}),
+			}),
+			want: want{externalName: "team:web", err: errors.Errorf(errTagExists, "team:web")},
+		},
+		"Unprocessable": {
+			reason: "A Tag that is rejected although no such tag exists should fail to be created.",
+			adopt:  boolPtr(true),
+			h: fake.Routes(t, map[string]http.HandlerFunc{
+				"POST /v2/tags": exists,
+				"GET /v2/tags/team:web": func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				},
+			}),
+			want: want{externalName: "team:web", err: errors.New(errTagCreateFailed)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := tag("team:web")
+			cr.Spec.ForProvider.AdoptExisting = tc.adopt
+			e := &tagExternal{Client: fake.NewClient(t, tc.h)}
+			_, err := e.Create(context.Background(), cr)
+			if tc.want.err == nil && err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v", tc.reason, err)
+			}
+			if tc.want.err != nil && (err == nil || !strings.HasPrefix(err.Error(), tc.want.err.Error())) {
+				t.Errorf("\n%s\ne.Create(...): want error %q, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external-name, +got external-name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
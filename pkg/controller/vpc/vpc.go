@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1"
+	do "github.com/crossplane-contrib/provider-digitalocean/pkg/clients"
+	dovpc "github.com/crossplane-contrib/provider-digitalocean/pkg/clients/vpc"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/deletion"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/controller/metrics"
+)
+
+const (
+	// Error strings.
+	errNotVPC      = "managed resource is not a VPC resource"
+	errGetVPC      = "cannot get VPC"
+	errListMembers = "cannot list members of VPC"
+	errListNATs    = "cannot list NAT gateways of VPC"
+	errListVPCs    = "cannot list VPCs"
+	errNoRegion    = "cannot look up VPC %q by name: spec.forProvider.region is required"
+	errObserveOnly = "VPCs are observe-only: set the crossplane.io/external-name annotation to the UUID or name of an existing VPC"
+)
+
+// SetupVPC adds a controller that reconciles VPC managed resources.
+func SetupVPC(mgr ctrl.Manager, l logging.Logger, cc *do.ClientCache, o deletion.Options) error {
+	name := managed.ControllerName(v1alpha1.VPCGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.VPC{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.VPCGroupVersionKind),
+			managed.WithExternalConnecter(metrics.NewInstrumentedConnecter(v1alpha1.VPCGroupVersionKind.GroupKind(), deletion.NewTimeoutConnecter(o, &vpcConnector{kube: mgr.GetClient(), clients: cc}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type vpcConnector struct {
+	kube    client.Client
+	clients *do.ClientCache
+}
+
+func (c *vpcConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	a, err := do.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	client := c.clients.GetFor(a)
+	return &vpcExternal{Client: client, kube: c.kube}, nil
+}
+
+type vpcExternal struct {
+	kube client.Client
+	*godo.Client
+}
+
+func (c *vpcExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotVPC)
+	}
+
+	// A VPC is observe-only. Once it is deleted we report it as gone so that
+	// the reconciler removes its finalizer without touching the VPC.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if !do.IsUUID(id) {
+		region := do.StringValue(cr.Spec.ForProvider.Region)
+		if region == "" {
+			return managed.ExternalObservation{}, errors.Errorf(errNoRegion, id)
+		}
+		adopted, err := do.AdoptByName(ctx, c.kube, cr, c.findByName(region))
+		if err != nil || adopted == "" {
+			return managed.ExternalObservation{ResourceExists: false}, err
+		}
+		id = adopted
+	}
+
+	observed, response, err := c.VPCs.Get(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(do.IgnoreNotFound(err, response), errGetVPC)
+	}
+	members, err := c.listMembers(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListMembers)
+	}
+	gateways, err := dovpc.ListNATGateways(ctx, c.Client)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListNATs)
+	}
+
+	cr.Status.AtProvider = v1alpha1.VPCObservation{
+		ID:                observed.ID,
+		URN:               observed.URN,
+		Name:              observed.Name,
+		Description:       observed.Description,
+		Region:            observed.RegionSlug,
+		IPRange:           observed.IPRange,
+		Default:           observed.Default,
+		CreationTimestamp: do.Timestamp(observed.CreatedAt),
+		Members:           members,
+		NATGateways:       dovpc.GenerateNATGateways(id, gateways),
+	}
+	do.SetAvailableOnObserve(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// findByName returns a FindByName that finds the VPCs in the supplied region.
+func (c *vpcExternal) findByName(region string) do.FindByName {
+	return func(ctx context.Context, name string) ([]string, error) {
+		var ids []string
+		opts := &godo.ListOptions{PerPage: 200}
+		for {
+			page, resp, err := c.VPCs.List(ctx, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, errListVPCs)
+			}
+			for _, v := range page {
+				if v.Name == name && v.RegionSlug == region {
+					ids = append(ids, v.ID)
+				}
+			}
+			if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+				return ids, nil
+			}
+			current, err := resp.Links.CurrentPage()
+			if err != nil {
+				return nil, err
+			}
+			opts.Page = current + 1
+		}
+	}
+}
+
+// listMembers returns all members of the VPC with the supplied ID.
+func (c *vpcExternal) listMembers(ctx context.Context, id string) ([]v1alpha1.VPCMember, error) {
+	var members []v1alpha1.VPCMember
+	opts := &godo.ListOptions{PerPage: 200}
+	for {
+		page, resp, err := c.VPCs.ListMembers(ctx, id, nil, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			members = append(members, v1alpha1.VPCMember{
+				URN:       m.URN,
+				Type:      memberType(m.URN),
+				Name:      m.Name,
+				CreatedAt: do.Timestamp(m.CreatedAt),
+			})
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		current, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = current + 1
+	}
+	return members, nil
+}
+
+// memberType returns the type of the resource with the supplied URN, which
+// has the form "do:<type>:<id>".
+func memberType(urn string) string {
+	parts := strings.SplitN(urn, ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+func (c *vpcExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	// VPCs are observe-only. A VPC that does not exist cannot be created.
+	return managed.ExternalCreation{}, errors.New(errObserveOnly)
+}
+
+func (c *vpcExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// VPCs are observe-only and cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *vpcExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	// VPCs are observe-only and are never deleted.
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-digitalocean/apis/vpc/v1alpha1"
+	"github.com/crossplane-contrib/provider-digitalocean/pkg/clients/fake"
+)
+
+const vpcID = "5a4981aa-9653-4bd1-bef5-d6bff52042e4"
+
+// serveVPC serves a VPC whose members are listed in two pages.
+func serveVPC(t *testing.T) http.HandlerFunc {
+	created := time.Date(2021, 6, 1, 4, 0, 0, 0, time.UTC)
+	members := "/v2/vpcs/" + vpcID + "/members"
+	bodies := map[string]interface{}{
+		"/v2/vpcs/" + vpcID: map[string]interface{}{"vpc": godo.VPC{
+			ID:         vpcID,
+			URN:        "do:vpc:" + vpcID,
+			Name:       "prod",
+			RegionSlug: "nyc1",
+			IPRange:    "10.10.10.0/24",
+			CreatedAt:  created,
+		}},
+		"/v2/vpcs": map[string]interface{}{"vpcs": []godo.VPC{
+			{ID: vpcID, Name: "prod", RegionSlug: "nyc1"},
+			{ID: "0d3176ad-41e0-4021-b831-0c5c45c60959", Name: "prod", RegionSlug: "ams3"},
+		}},
+		members + "?page=1": map[string]interface{}{
+			"members": []godo.VPCMember{
+				{URN: "do:droplet:13457723", Name: "web-1", CreatedAt: created},
+				{URN: "do:kubernetes:5ba4518b-b9e2-4978-aa92-2d4c727e8824", Name: "k8s", CreatedAt: created},
+			},
+			"links": map[string]interface{}{"pages": map[string]string{
+				"next": "https://api.digitalocean.com" + members + "?page=2",
+				"last": "https://api.digitalocean.com" + members + "?page=2",
+			}},
+		},
+		members + "?page=2": map[string]interface{}{
+			"members": []godo.VPCMember{
+				{URN: "do:loadbalancer:fb294d78-d193-4cb2-8737-ea620993591b", Name: "lb", CreatedAt: created},
+			},
+			"links": map[string]interface{}{"pages": map[string]string{
+				"prev":  "https://api.digitalocean.com" + members + "?page=1",
+				"first": "https://api.digitalocean.com" + members + "?page=1",
+			}},
+		},
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path
+		if r.URL.Path == members {
+			page := r.URL.Query().Get("page")
+			if page == "" {
+				page = "1"
+			}
+			key += "?page=" + page
+		}
+		body, ok := bodies[key]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"id":"not_found","message":"The resource you were accessing could not be found."}`))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func vpc(externalName string) *v1alpha1.VPC {
+	cr := &v1alpha1.VPC{}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func TestVPCObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		at        v1alpha1.VPCObservation
+		available bool
+	}
+
+	now := metav1.Now()
+	deleted := vpc(vpcID)
+	deleted.SetDeletionTimestamp(&now)
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.VPC
+		want   want
+	}{
+		"Members": {
+			reason: "The VPC and all pages of its members should be reported in the status, and it should be available as soon as it is observed.",
+			cr:     vpc(vpcID),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				at: v1alpha1.VPCObservation{
+					ID:                vpcID,
+					URN:               "do:vpc:" + vpcID,
+					Name:              "prod",
+					Region:            "nyc1",
+					IPRange:           "10.10.10.0/24",
+					CreationTimestamp: "2021-06-01T04:00:00Z",
+					Members: []v1alpha1.VPCMember{
+						{URN: "do:droplet:13457723", Type: "droplet", Name: "web-1", CreatedAt: "2021-06-01T04:00:00Z"},
+						{URN: "do:kubernetes:5ba4518b-b9e2-4978-aa92-2d4c727e8824", Type: "kubernetes", Name: "k8s", CreatedAt: "2021-06-01T04:00:00Z"},
+						{URN: "do:loadbalancer:fb294d78-d193-4cb2-8737-ea620993591b", Type: "loadbalancer", Name: "lb", CreatedAt: "2021-06-01T04:00:00Z"},
+					},
+				},
+				available: true,
+			},
+		},
+		"NotFound": {
+			reason: "A VPC that does not exist should be reported as not existing.",
+			cr:     vpc("0d3176ad-41e0-4021-b831-0c5c45c60959"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NoExternalName": {
+			reason: "A VPC without an external-name should be reported as not existing without calling the API.",
+			cr:     vpc(""),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Deleted": {
+			reason: "A deleted VPC should be reported as gone without calling the API.",
+			cr:     deleted,
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &vpcExternal{Client: fake.NewClient(t, serveVPC(t))}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.at, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if available := tc.cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()); available != tc.want.available {
+				t.Errorf("\n%s\ne.Observe(...): want available %t, got %t\n", tc.reason, tc.want.available, available)
+			}
+		})
+	}
+}
+
+func TestVPCObserveNATGateways(t *testing.T) {
+	gateways := `{"vpc_nat_gateways":[
+		{"id":"nat-1","name":"egress","state":"ACTIVE","region":"nyc1",
+		 "vpcs":[{"vpc_uuid":"` + vpcID + `","gateway_ip":"10.10.10.2","default_gateway":true}],
+		 "egresses":{"public_gateways":[{"ipv4":"203.0.113.20"}]}},
+		{"id":"nat-2","name":"other","state":"ACTIVE","region":"nyc1",
+		 "vpcs":[{"vpc_uuid":"0d3176ad-41e0-4021-b831-0c5c45c60959","gateway_ip":"10.20.0.2"}]}
+	]}`
+
+	cases := map[string]struct {
+		reason string
+		status int
+		body   string
+		want   []v1alpha1.VPCNATGateway
+	}{
+		"Attached": {
+			reason: "The NAT gateways attached to the VPC should be reported in the status.",
+			status: http.StatusOK,
+			body:   gateways,
+			want: []v1alpha1.VPCNATGateway{{
+				ID:             "nat-1",
+				Name:           "egress",
+				State:          "ACTIVE",
+				GatewayIP:      "10.10.10.2",
+				DefaultGateway: true,
+				EgressIPs:      []string{"203.0.113.20"},
+			}},
+		},
+		"Unavailable": {
+			reason: "No NAT gateways should be reported if they are not available to the account.",
+			status: http.StatusForbidden,
+			body:   `{"id":"forbidden","message":"You do not have access for the attempted action."}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			vpcs := serveVPC(t)
+			h := func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/vpc_nat_gateways" {
+					vpcs(w, r)
+					return
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}
+			e := &vpcExternal{Client: fake.NewClient(t, h)}
+			cr := vpc(vpcID)
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.Status.AtProvider.NATGateways); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want NAT gateways, +got NAT gateways:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestVPCObserveByName(t *testing.T) {
+	type want struct {
+		o            managed.ExternalObservation
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		region string
+		want   want
+	}{
+		"Adopted": {
+			reason: "A VPC named in the external-name should be looked up in its region and adopted by its UUID.",
+			name:   "prod",
+			region: "nyc1",
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				externalName: vpcID,
+			},
+		},
+		"NotInRegion": {
+			reason: "A VPC that is not named in the external-name in its region should be reported as not existing.",
+			name:   "prod",
+			region: "sfo3",
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: false},
+				externalName: "prod",
+			},
+		},
+		"NoRegion": {
+			reason: "A VPC should not be looked up by name without its region, since VPCs in different regions may share a name.",
+			name:   "prod",
+			want: want{
+				externalName: "prod",
+				err:          errors.Errorf(errNoRegion, "prod"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &vpcExternal{
+				kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				Client: fake.NewClient(t, serveVPC(t)),
+			}
+			cr := vpc(tc.name)
+			if tc.region != "" {
+				cr.Spec.ForProvider.Region = &tc.region
+			}
+			o, err := e.Observe(context.Background(), cr)
+			got := want{o: o, externalName: meta.GetExternalName(cr), err: err}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}